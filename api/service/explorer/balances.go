@@ -0,0 +1,252 @@
+package explorer
+
+import (
+	"bytes"
+	"container/heap"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/core/types"
+	common2 "github.com/harmony-one/harmony/internal/common"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking/slash"
+)
+
+// richlistSize is the number of accounts of a richlist snapshot
+const richlistSize = 1000
+
+// BalanceSource is a bit set of what moved the balance of an account in a block.
+type BalanceSource uint8
+
+const (
+	balanceSourceTx           BalanceSource = 1 << iota // sent or received a transaction
+	balanceSourceStaking                                // sent a staking transaction, collected rewards included
+	balanceSourceCrossShard                             // received a cross shard transaction
+	balanceSourceFee                                    // collected transaction fees
+	balanceSourceReward                                 // block rewards before staking, slash reporter rewards
+	balanceSourceUndelegation                           // unlocked undelegations paid out at the end of the epoch
+)
+
+var balanceSourceNames = []string{"transaction", "staking", "cross-shard", "fee", "reward", "undelegation"}
+
+// Names returns the names of the sources of the set.
+func (bs BalanceSource) Names() []string {
+	var names []string
+	for i, name := range balanceSourceNames {
+		if bs&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (bs BalanceSource) String() string {
+	return strings.Join(bs.Names(), ",")
+}
+
+// BalanceChange is the change of the balance of an account in a block, stored in
+// explorer db.
+type BalanceChange struct {
+	Address     common.Address
+	BlockNumber uint64
+	Timestamp   uint64
+	Previous    *big.Int // balance at the parent block
+	Balance     *big.Int
+	Sources     BalanceSource
+}
+
+// Change returns the signed change of the balance.
+func (bc *BalanceChange) Change() *big.Int {
+	return new(big.Int).Sub(bc.Balance, bc.Previous)
+}
+
+// computeBalanceChanges writes the balance changes of the accounts touched by the
+// block: the parties of its transactions and of its incoming cross shard
+// transactions, and the accounts paid by the finalization of the block, which
+// are the fee collector, the pre-staking block reward signers, the slash
+// reporters and the delegators of the unlocked undelegations. The balances are
+// read from the states of the block and its parent, so nothing is written for
+// the blocks whose states are pruned.
+func computeBalanceChanges(btc batch, bc blockChainTxIndexer, b *types.Block, receipts types.Receipts) {
+	parent := bc.GetHeaderByHash(b.ParentHash())
+	if parent == nil {
+		return
+	}
+	parentState, err := bc.StateAt(parent.Root())
+	if err != nil {
+		return
+	}
+	blockState, err := bc.StateAt(b.Root())
+	if err != nil {
+		return
+	}
+
+	touched := make(map[common.Address]BalanceSource)
+	touch := func(addr common.Address, source BalanceSource) {
+		touched[addr] |= source
+	}
+	for i, tx := range b.Transactions() {
+		if from, err := tx.SenderAddress(); err == nil {
+			touch(from, balanceSourceTx)
+		}
+		if to := tx.To(); to != nil && tx.ShardID() == tx.ToShardID() {
+			touch(*to, balanceSourceTx)
+		} else if to == nil && i < len(receipts) {
+			touch(receipts[i].ContractAddress, balanceSourceTx)
+		}
+	}
+	for _, stk := range b.StakingTransactions() {
+		if from, err := stk.SenderAddress(); err == nil {
+			touch(from, balanceSourceStaking)
+		}
+	}
+	for _, proof := range b.IncomingReceipts() {
+		for _, cx := range proof.Receipts {
+			if cx.To != nil {
+				touch(*cx.To, balanceSourceCrossShard)
+			}
+		}
+	}
+
+	config := bc.Config()
+	epoch := b.Epoch()
+	switch {
+	case !config.IsStaking(epoch):
+		touch(b.Coinbase(), balanceSourceFee)
+		if parentShardState, err := bc.ReadShardState(parent.Epoch()); err == nil {
+			if committee, err := parentShardState.FindCommitteeByID(b.ShardID()); err == nil {
+				for _, slot := range committee.Slots {
+					touch(slot.EcdsaAddress, balanceSourceReward)
+				}
+			}
+		}
+	case config.IsFeeCollectEpoch(epoch):
+		touch(shard.Schedule.InstanceForEpoch(epoch).FeeCollector(), balanceSourceFee)
+	}
+	if s := b.Header().Slashes(); len(s) > 0 {
+		records := slash.Records{}
+		if err := rlp.DecodeBytes(s, &records); err == nil {
+			for _, record := range records {
+				touch(record.Reporter, balanceSourceReward)
+			}
+		}
+	}
+	if b.ShardID() == shard.BeaconChainShardID && b.IsLastBlockInEpoch() && config.IsPreStaking(epoch) {
+		validators, _ := bc.ReadValidatorList()
+		for _, validator := range validators {
+			wrapper, err := parentState.ValidatorWrapper(validator, true, false)
+			if err != nil {
+				continue
+			}
+			for _, delegation := range wrapper.Delegations {
+				if len(delegation.Undelegations) != 0 {
+					touch(delegation.DelegatorAddress, balanceSourceUndelegation)
+				}
+			}
+		}
+	}
+
+	for addr, sources := range touched {
+		previous, balance := parentState.GetBalance(addr), blockState.GetBalance(addr)
+		if previous.Cmp(balance) == 0 {
+			continue
+		}
+		_ = writeAddressEntry(btc, ethToOneAddress(addr))
+		_ = writeBalanceChange(btc, &BalanceChange{
+			Address:     addr,
+			BlockNumber: b.NumberU64(),
+			Timestamp:   b.Time().Uint64(),
+			Previous:    previous,
+			Balance:     balance,
+			Sources:     sources,
+		})
+	}
+}
+
+// RichlistAccount is an account of a richlist snapshot.
+type RichlistAccount struct {
+	Address common.Address
+	Balance *big.Int
+}
+
+// RichlistSnapshot is the accounts with the largest balances at the last block
+// of an epoch, stored in explorer db.
+type RichlistSnapshot struct {
+	Epoch       uint64
+	BlockNumber uint64
+	Timestamp   uint64
+	Accounts    []RichlistAccount // ordered by balance, the largest first
+}
+
+// balanceReader reads the balances of the accounts of a state.
+type balanceReader interface {
+	GetBalance(addr common.Address) *big.Int
+}
+
+// computeRichlist returns the size accounts of the explorer db with the largest
+// balances in the state. Only the accounts which sent or received a transaction
+// or had a balance change indexed are known to the explorer.
+func computeRichlist(db databaseReader, st balanceReader, size int) ([]RichlistAccount, error) {
+	h := &richlistHeap{}
+	it := db.NewPrefixIterator(addrPrefix)
+	defer it.Release()
+	for it.Next() {
+		oneAddr, err := getAddressFromAddressKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		addr, err := common2.Bech32ToAddress(string(oneAddr))
+		if err != nil {
+			continue
+		}
+		balance := st.GetBalance(addr)
+		if balance.Sign() == 0 {
+			continue
+		}
+		account := RichlistAccount{Address: addr, Balance: new(big.Int).Set(balance)}
+		if h.Len() < size {
+			heap.Push(h, account)
+		} else if h.less(h.accounts[0], account) {
+			h.accounts[0] = account
+			heap.Fix(h, 0)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	accounts := h.accounts
+	sort.Slice(accounts, func(i, j int) bool {
+		return h.less(accounts[j], accounts[i])
+	})
+	return accounts, nil
+}
+
+// richlistHeap is a min heap of the accounts by balance, the accounts of equal
+// balances ordered by address for the snapshots to be deterministic.
+type richlistHeap struct {
+	accounts []RichlistAccount
+}
+
+func (h *richlistHeap) less(a, b RichlistAccount) bool {
+	if c := a.Balance.Cmp(b.Balance); c != 0 {
+		return c < 0
+	}
+	return bytes.Compare(a.Address.Bytes(), b.Address.Bytes()) > 0
+}
+
+func (h *richlistHeap) Len() int           { return len(h.accounts) }
+func (h *richlistHeap) Less(i, j int) bool { return h.less(h.accounts[i], h.accounts[j]) }
+func (h *richlistHeap) Swap(i, j int)      { h.accounts[i], h.accounts[j] = h.accounts[j], h.accounts[i] }
+
+func (h *richlistHeap) Push(x interface{}) {
+	h.accounts = append(h.accounts, x.(RichlistAccount))
+}
+
+func (h *richlistHeap) Pop() interface{} {
+	account := h.accounts[len(h.accounts)-1]
+	h.accounts = h.accounts[:len(h.accounts)-1]
+	return account
+}
@@ -0,0 +1,215 @@
+package explorer
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+type balanceBlockChain struct {
+	migrationBlockChain
+	headers map[common.Hash]*block.Header
+	db      state.Database
+}
+
+func (bc *balanceBlockChain) GetHeaderByHash(hash common.Hash) *block.Header {
+	return bc.headers[hash]
+}
+
+func (bc *balanceBlockChain) StateAt(root common.Hash) (*state.DB, error) {
+	return state.New(root, bc.db)
+}
+
+func TestComputeBalanceChanges(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	untouched := common.HexToAddress("0x6666666666666666666666666666666666666666")
+
+	sdb := state.NewDatabase(rawdb.NewMemoryDatabase())
+	commit := func(balances map[common.Address]int64) common.Hash {
+		st, err := state.New(common.Hash{}, sdb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for addr, balance := range balances {
+			st.SetBalance(addr, big.NewInt(balance))
+		}
+		root, err := st.Commit(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return root
+	}
+	parentRoot := commit(map[common.Address]int64{sender: 100, testFrom: 5, untouched: 1})
+	blockRoot := commit(map[common.Address]int64{sender: 90, testFrom: 5, testTo: 10, untouched: 2})
+
+	parent := blockfactory.NewTestHeader().With().Number(big.NewInt(4)).Root(parentRoot).Header()
+	tx, err := types.SignTx(types.NewTransaction(0, testTo, 0, big.NewInt(10), 21000, big.NewInt(0), nil),
+		types.NewEIP155Signer(big.NewInt(2)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a transaction not moving the balance of its sender
+	tx2, err := types.SignTx(types.NewTransaction(1, testFrom, 0, big.NewInt(0), 21000, big.NewInt(0), nil),
+		types.NewEIP155Signer(big.NewInt(2)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+		Number(big.NewInt(5)).
+		Time(big.NewInt(500)).
+		ParentHash(parent.Hash()).
+		Root(blockRoot).
+		Header()).WithBody([]*types.Transaction{tx, tx2}, nil, nil, nil)
+
+	bc := &balanceBlockChain{
+		headers: map[common.Hash]*block.Header{parent.Hash(): parent},
+		db:      sdb,
+	}
+	db := newMemDB()
+	btc := db.NewBatch()
+	computeBalanceChanges(btc, bc, b, types.Receipts{{}, {}})
+	if err := btc.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		addr     common.Address
+		previous int64
+		balance  int64
+	}{
+		{sender, 100, 90},
+		{testTo, 0, 10},
+	}
+	for _, test := range tests {
+		changes, err := getBalanceChangesByAccount(db, ethToOneAddress(test.addr), 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changes) != 1 {
+			t.Fatalf("unexpected balance changes of %x: %v", test.addr, changes)
+		}
+		change := changes[0]
+		if change.BlockNumber != 5 || change.Timestamp != 500 || change.Sources != balanceSourceTx {
+			t.Errorf("unexpected balance change: %+v", change)
+		}
+		if change.Previous.Int64() != test.previous || change.Balance.Int64() != test.balance ||
+			change.Change().Int64() != test.balance-test.previous {
+			t.Errorf("unexpected balances: %v %v", change.Previous, change.Balance)
+		}
+		if ok, _ := isAddressWritten(db, ethToOneAddress(test.addr)); !ok {
+			t.Errorf("address %x not written", test.addr)
+		}
+	}
+	for _, addr := range []common.Address{testFrom, untouched} {
+		changes, err := getBalanceChangesByAccount(db, ethToOneAddress(addr), 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changes) != 0 {
+			t.Errorf("unexpected balance changes of %x: %v", addr, changes)
+		}
+	}
+
+	// Nothing is written without the parent state
+	db = newMemDB()
+	btc = db.NewBatch()
+	computeBalanceChanges(btc, &balanceBlockChain{db: sdb}, b, nil)
+	if btc.ValueSize() != 0 {
+		t.Errorf("balance changes written without parent")
+	}
+}
+
+func TestBalanceSourceNames(t *testing.T) {
+	sources := balanceSourceStaking | balanceSourceUndelegation
+	if names := sources.Names(); !reflect.DeepEqual(names, []string{"staking", "undelegation"}) {
+		t.Errorf("unexpected names: %v", names)
+	}
+	if BalanceSource(0).Names() != nil {
+		t.Errorf("unexpected names of no source")
+	}
+}
+
+type testBalances map[common.Address]*big.Int
+
+func (tb testBalances) GetBalance(addr common.Address) *big.Int {
+	if balance, ok := tb[addr]; ok {
+		return balance
+	}
+	return new(big.Int)
+}
+
+func TestComputeRichlist(t *testing.T) {
+	var (
+		db       = newMemDB()
+		balances = make(testBalances)
+		addrs    []common.Address
+	)
+	for i, balance := range []int64{5, 0, 7, 5, 1} {
+		addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+		addrs = append(addrs, addr)
+		balances[addr] = big.NewInt(balance)
+		if err := writeAddressEntry(db, ethToOneAddress(addr)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		size int
+		exp  []common.Address
+	}{
+		{10, []common.Address{addrs[2], addrs[0], addrs[3], addrs[4]}},
+		{3, []common.Address{addrs[2], addrs[0], addrs[3]}},
+		{2, []common.Address{addrs[2], addrs[0]}},
+	}
+	for i, test := range tests {
+		accounts, err := computeRichlist(db, balances, test.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(accounts) != len(test.exp) {
+			t.Fatalf("Test %v: unexpected account count %v / %v", i, len(accounts), len(test.exp))
+		}
+		for j, account := range accounts {
+			if account.Address != test.exp[j] || account.Balance.Cmp(balances[test.exp[j]]) != 0 {
+				t.Errorf("Test %v: unexpected account %v: %x %v", i, j, account.Address, account.Balance)
+			}
+		}
+	}
+}
+
+func TestRichlistSnapshot(t *testing.T) {
+	db := newMemDB()
+	if _, ok, err := readLatestRichlistEpoch(db); ok || err != nil {
+		t.Fatalf("unexpected latest snapshot: %v %v", ok, err)
+	}
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		err := writeRichlist(db, &RichlistSnapshot{
+			Epoch:       epoch,
+			BlockNumber: epoch * 100,
+			Accounts:    []RichlistAccount{{Address: testTo, Balance: big.NewInt(int64(epoch))}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	latest, ok, err := readLatestRichlistEpoch(db)
+	if err != nil || !ok || latest != 3 {
+		t.Fatalf("unexpected latest snapshot: %v %v %v", latest, ok, err)
+	}
+	snapshot, err := readRichlist(db, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.BlockNumber != 200 || len(snapshot.Accounts) != 1 || snapshot.Accounts[0].Balance.Int64() != 2 {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+}
@@ -0,0 +1,280 @@
+package explorer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy/tracers"
+	"github.com/pkg/errors"
+)
+
+const (
+	// solcTimeout bounds the compilation of the source of a verified contract
+	solcTimeout = 2 * time.Minute
+	// maxVerifyRequestSize bounds the size of a contract verification request
+	maxVerifyRequestSize = 16 * 1024 * 1024
+)
+
+// ContractCreation is the deployment of a contract, stored in explorer db.
+type ContractCreation struct {
+	Address     common.Address
+	Creator     common.Address // sender of the transaction, or the creating contract
+	TxHash      common.Hash
+	BlockNumber uint64
+	CodeHash    common.Hash // empty if the contract self destructed before it was indexed
+}
+
+// computeContractCreations writes the contracts deployed by the transactions of
+// the block. The code hashes are read from the state of the head of the chain.
+func computeContractCreations(btc batch, bc blockChainTxIndexer, b *types.Block, receipts types.Receipts) {
+	var (
+		db       *state.DB
+		stateErr error
+	)
+	for i, tx := range b.Transactions() {
+		if tx.To() != nil || i >= len(receipts) || receipts[i].Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		if db == nil && stateErr == nil {
+			db, stateErr = bc.State()
+		}
+		creator, _ := tx.SenderAddress()
+		cc := &ContractCreation{
+			Address:     receipts[i].ContractAddress,
+			Creator:     creator,
+			TxHash:      tx.HashByType(),
+			BlockNumber: b.NumberU64(),
+		}
+		if stateErr == nil {
+			cc.CodeHash = db.GetCodeHash(cc.Address)
+		}
+		_ = writeContractCreation(btc, cc)
+	}
+}
+
+// writeInternalContractCreation writes the contract deployed by a contract, the
+// code hash is the one of the code returned by the creation.
+func writeInternalContractCreation(btc batch, blockNumber uint64, call *tracers.InternalCall) {
+	_ = writeContractCreation(btc, &ContractCreation{
+		Address:     call.To,
+		Creator:     call.From,
+		TxHash:      call.TxHash,
+		BlockNumber: blockNumber,
+		CodeHash:    crypto.Keccak256Hash(call.Output),
+	})
+}
+
+// VerifiedContract is the verified source of a contract, stored in explorer db.
+type VerifiedContract struct {
+	Address         common.Address
+	ContractName    string // source path and contract name, as in contracts/Token.sol:Token
+	CompilerVersion string
+	ABI             []byte // JSON encoded
+	Metadata        []byte // JSON encoded
+	Input           []byte // solidity standard JSON input
+	VerifiedAt      uint64
+}
+
+// VerifyRequest is a request to verify the source of a contract.
+type VerifyRequest struct {
+	Address         string          `json:"address"`
+	CompilerVersion string          `json:"compilerVersion"`
+	ContractName    string          `json:"contractName"`
+	Input           json.RawMessage `json:"input"`
+}
+
+// solcCompiler compiles the sources of the verified contracts with a local solc
+// binary.
+type solcCompiler struct {
+	path string
+}
+
+// solcContract is a contract of the solc standard JSON output.
+type solcContract struct {
+	ABI      json.RawMessage `json:"abi"`
+	Metadata string          `json:"metadata"`
+	EVM      struct {
+		DeployedBytecode struct {
+			Object              string                 `json:"object"`
+			ImmutableReferences map[string][]codeRange `json:"immutableReferences"`
+		} `json:"deployedBytecode"`
+	} `json:"evm"`
+}
+
+// codeRange is a range of the deployed code holding an immutable variable.
+type codeRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// solcOutputSelection selects the compiler outputs verifying a contract.
+var solcOutputSelection = json.RawMessage(`{"*":{"*":["abi","metadata","evm.deployedBytecode.object","evm.deployedBytecode.immutableReferences"]}}`)
+
+// verify compiles the source of the request and checks the compiled code of the
+// contract against its deployed code.
+func (c *solcCompiler) verify(ctx context.Context, addr common.Address, code []byte, req *VerifyRequest) (*VerifiedContract, error) {
+	if len(code) == 0 {
+		return nil, errors.New("no contract deployed at the address")
+	}
+	sep := strings.LastIndex(req.ContractName, ":")
+	if sep < 0 {
+		return nil, errors.New("contract name is not of the form path:name")
+	}
+	path, name := req.ContractName[:sep], req.ContractName[sep+1:]
+
+	ctx, cancel := context.WithTimeout(ctx, solcTimeout)
+	defer cancel()
+	version, err := c.version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !matchSolcVersion(version, req.CompilerVersion) {
+		return nil, fmt.Errorf("compiler version %v not available, the compiler is %v", req.CompilerVersion, version)
+	}
+	input, err := prepareSolcInput(req.Input)
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := c.compile(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	contract := contracts[path][name]
+	if contract == nil {
+		return nil, fmt.Errorf("contract %v not in the compiler output", req.ContractName)
+	}
+	compiled, err := hex.DecodeString(strings.TrimPrefix(contract.EVM.DeployedBytecode.Object, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode compiled code, are the libraries linked")
+	}
+	if !matchDeployedCode(compiled, contract.EVM.DeployedBytecode.ImmutableReferences, code) {
+		return nil, errors.New("compiled code does not match the deployed code")
+	}
+	return &VerifiedContract{
+		Address:         addr,
+		ContractName:    req.ContractName,
+		CompilerVersion: version,
+		ABI:             contract.ABI,
+		Metadata:        []byte(contract.Metadata),
+		Input:           req.Input,
+		VerifiedAt:      uint64(time.Now().Unix()),
+	}, nil
+}
+
+// version returns the version of the compiler, as in 0.8.19+commit.7dd6d404.Linux.g++
+func (c *solcCompiler) version(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, c.path, "--version").Output()
+	if err != nil {
+		return "", errors.Wrap(err, "run solc")
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "Version: ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "Version: ")), nil
+		}
+	}
+	return "", errors.New("unknown solc version")
+}
+
+// matchSolcVersion returns whether the version of the compiler is the requested
+// one, as in 0.8.19, 0.8.19+commit.7dd6d404 or v0.8.19+commit.7dd6d404.
+func matchSolcVersion(version, requested string) bool {
+	requested = strings.TrimPrefix(requested, "v")
+	if requested == "" || !strings.HasPrefix(version, requested) {
+		return false
+	}
+	rest := version[len(requested):]
+	return rest == "" || rest[0] < '0' || rest[0] > '9'
+}
+
+// compile compiles the standard JSON input, and returns the contracts of the
+// output by source path and contract name.
+func (c *solcCompiler) compile(ctx context.Context, input []byte) (map[string]map[string]*solcContract, error) {
+	cmd := exec.CommandContext(ctx, c.path, "--standard-json")
+	cmd.Stdin = bytes.NewReader(input)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "run solc")
+	}
+	var output struct {
+		Errors []struct {
+			Severity         string `json:"severity"`
+			FormattedMessage string `json:"formattedMessage"`
+		} `json:"errors"`
+		Contracts map[string]map[string]*solcContract `json:"contracts"`
+	}
+	if err := json.Unmarshal(out, &output); err != nil {
+		return nil, errors.Wrap(err, "decode solc output")
+	}
+	for _, e := range output.Errors {
+		if e.Severity == "error" {
+			return nil, errors.New(e.FormattedMessage)
+		}
+	}
+	return output.Contracts, nil
+}
+
+// prepareSolcInput checks the standard JSON input and selects the outputs of the
+// verification in its settings. The sources are only read from the input, never
+// from the files of the node.
+func prepareSolcInput(raw json.RawMessage) ([]byte, error) {
+	var input map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return nil, errors.Wrap(err, "decode standard JSON input")
+	}
+	var language string
+	if err := json.Unmarshal(input["language"], &language); err != nil || language != "Solidity" {
+		return nil, errors.New("standard JSON input language is not Solidity")
+	}
+	var sources map[string]struct {
+		Content *string `json:"content"`
+	}
+	if err := json.Unmarshal(input["sources"], &sources); err != nil || len(sources) == 0 {
+		return nil, errors.New("standard JSON input has no sources")
+	}
+	for path, source := range sources {
+		if source.Content == nil {
+			return nil, fmt.Errorf("source %v has no content", path)
+		}
+	}
+	settings := make(map[string]json.RawMessage)
+	if s, ok := input["settings"]; ok {
+		if err := json.Unmarshal(s, &settings); err != nil {
+			return nil, errors.Wrap(err, "decode standard JSON settings")
+		}
+	}
+	settings["outputSelection"] = solcOutputSelection
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	input["settings"] = b
+	return json.Marshal(input)
+}
+
+// matchDeployedCode returns whether the compiled code is the deployed code, the
+// values of the immutable variables set by the constructor aside.
+func matchDeployedCode(compiled []byte, immutables map[string][]codeRange, deployed []byte) bool {
+	if len(compiled) != len(deployed) {
+		return false
+	}
+	code := common.CopyBytes(compiled)
+	for _, ranges := range immutables {
+		for _, r := range ranges {
+			if r.Start < 0 || r.Length < 0 || r.Start+r.Length > len(code) {
+				return false
+			}
+			copy(code[r.Start:r.Start+r.Length], deployed[r.Start:r.Start+r.Length])
+		}
+	}
+	return bytes.Equal(code, deployed)
+}
@@ -0,0 +1,205 @@
+package explorer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+type contractBlockChain struct {
+	migrationBlockChain
+	state *state.DB
+}
+
+func (bc *contractBlockChain) State() (*state.DB, error) { return bc.state, nil }
+
+func TestComputeContractCreations(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := types.NewEIP155Signer(big.NewInt(2))
+	signTx := func(tx *types.Transaction) *types.Transaction {
+		signed, err := types.SignTx(tx, signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	txs := []*types.Transaction{
+		signTx(types.NewContractCreation(0, 0, big.NewInt(0), 100000, big.NewInt(1), nil)),
+		signTx(types.NewTransaction(1, testTo, 0, big.NewInt(1), 21000, big.NewInt(1), nil)),
+		signTx(types.NewContractCreation(2, 0, big.NewInt(0), 100000, big.NewInt(1), nil)),
+	}
+	var (
+		deployed = common.HexToAddress("0x4444444444444444444444444444444444444444")
+		failed   = common.HexToAddress("0x5555555555555555555555555555555555555555")
+		code     = []byte{0x60, 0x80}
+	)
+	receipts := types.Receipts{
+		{Status: types.ReceiptStatusSuccessful, ContractAddress: deployed},
+		{Status: types.ReceiptStatusSuccessful},
+		{Status: types.ReceiptStatusFailed, ContractAddress: failed},
+	}
+	stateDB, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateDB.SetCode(deployed, code)
+	b := types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+		Number(big.NewInt(5)).
+		Header()).WithBody(txs, nil, nil, nil)
+
+	db := newMemDB()
+	btc := db.NewBatch()
+	computeContractCreations(btc, &contractBlockChain{state: stateDB}, b, receipts)
+	if err := btc.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	cc, err := readContractCreation(db, ethToOneAddress(deployed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cc.Address != deployed || cc.Creator != crypto.PubkeyToAddress(key.PublicKey) ||
+		cc.TxHash != txs[0].HashByType() || cc.BlockNumber != 5 || cc.CodeHash != crypto.Keccak256Hash(code) {
+		t.Errorf("unexpected contract creation: %+v", cc)
+	}
+	if ok, _ := db.Has(getContractCreationKey(ethToOneAddress(failed))); ok {
+		t.Errorf("failed contract creation indexed")
+	}
+}
+
+func TestMatchDeployedCode(t *testing.T) {
+	immutables := map[string][]codeRange{"5": {{Start: 1, Length: 2}}}
+	tests := []struct {
+		compiled, deployed []byte
+		immutables         map[string][]codeRange
+		exp                bool
+	}{
+		{[]byte{1, 2, 3}, []byte{1, 2, 3}, nil, true},
+		{[]byte{1, 2, 3}, []byte{1, 2, 4}, nil, false},
+		{[]byte{1, 2, 3}, []byte{1, 2, 3, 4}, nil, false},
+		{[]byte{1, 0, 0, 4}, []byte{1, 2, 3, 4}, immutables, true},
+		{[]byte{1, 0, 0, 4}, []byte{2, 2, 3, 4}, immutables, false},
+		{[]byte{1, 0}, []byte{1, 2}, immutables, false},
+	}
+	for i, test := range tests {
+		if got := matchDeployedCode(test.compiled, test.immutables, test.deployed); got != test.exp {
+			t.Errorf("Test %v: unexpected result %v / %v", i, got, test.exp)
+		}
+	}
+}
+
+func TestPrepareSolcInput(t *testing.T) {
+	input := `{"language":"Solidity","sources":{"Token.sol":{"content":"contract Token {}"}},"settings":{"optimizer":{"enabled":true,"runs":200},"outputSelection":{"*":{"*":["*"]}}}}`
+	b, err := prepareSolcInput(json.RawMessage(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var prepared struct {
+		Settings struct {
+			Optimizer       json.RawMessage `json:"optimizer"`
+			OutputSelection json.RawMessage `json:"outputSelection"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal(b, &prepared); err != nil {
+		t.Fatal(err)
+	}
+	if string(prepared.Settings.Optimizer) != `{"enabled":true,"runs":200}` {
+		t.Errorf("unexpected optimizer settings: %s", prepared.Settings.Optimizer)
+	}
+	if string(prepared.Settings.OutputSelection) != string(solcOutputSelection) {
+		t.Errorf("unexpected output selection: %s", prepared.Settings.OutputSelection)
+	}
+
+	invalids := []string{
+		`{"language":"Vyper","sources":{"Token.vy":{"content":""}}}`,
+		`{"language":"Solidity","sources":{}}`,
+		`{"language":"Solidity","sources":{"Token.sol":{"urls":["/etc/passwd"]}}}`,
+		`[]`,
+	}
+	for i, input := range invalids {
+		if _, err := prepareSolcInput(json.RawMessage(input)); err == nil {
+			t.Errorf("Test %v: invalid input accepted", i)
+		}
+	}
+}
+
+// testSolcScript fakes a solc compiling the Token contract, the bytes 1 to 2 of
+// its code are an immutable variable.
+const testSolcScript = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "solc, the solidity compiler commandline interface"
+	echo "Version: 0.8.19+commit.7dd6d404.Linux.g++"
+	exit 0
+fi
+cat > /dev/null
+cat <<'EOF'
+{"contracts":{"Token.sol":{"Token":{"abi":[{"type":"constructor","inputs":[]}],"metadata":"{\"language\":\"Solidity\"}","evm":{"deployedBytecode":{"object":"60000000f3","immutableReferences":{"5":[{"start":1,"length":2}]}}}}}}}
+EOF
+`
+
+func TestSolcVerify(t *testing.T) {
+	solcPath := filepath.Join(t.TempDir(), "solc")
+	if err := os.WriteFile(solcPath, []byte(testSolcScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	solc := &solcCompiler{path: solcPath}
+	input := json.RawMessage(`{"language":"Solidity","sources":{"Token.sol":{"content":"contract Token {}"}}}`)
+	code := []byte{0x60, 0x12, 0x34, 0x00, 0xf3}
+
+	vc, err := solc.verify(context.Background(), testToken, code, &VerifyRequest{
+		CompilerVersion: "v0.8.19+commit.7dd6d404",
+		ContractName:    "Token.sol:Token",
+		Input:           input,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vc.Address != testToken || vc.ContractName != "Token.sol:Token" || vc.CompilerVersion != "0.8.19+commit.7dd6d404.Linux.g++" {
+		t.Errorf("unexpected verified contract: %+v", vc)
+	}
+	if string(vc.ABI) != `[{"type":"constructor","inputs":[]}]` || string(vc.Metadata) != `{"language":"Solidity"}` {
+		t.Errorf("unexpected abi and metadata: %s %s", vc.ABI, vc.Metadata)
+	}
+
+	db := newMemDB()
+	if err := writeVerifiedContract(db, vc); err != nil {
+		t.Fatal(err)
+	}
+	read, err := readVerifiedContract(db, ethToOneAddress(testToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.ContractName != vc.ContractName || string(read.Input) != string(input) {
+		t.Errorf("unexpected read verified contract: %+v", read)
+	}
+
+	fails := []struct {
+		req  VerifyRequest
+		code []byte
+		err  string
+	}{
+		{VerifyRequest{CompilerVersion: "0.8.20", ContractName: "Token.sol:Token", Input: input}, code, "compiler version"},
+		{VerifyRequest{CompilerVersion: "0.8.1", ContractName: "Token.sol:Token", Input: input}, code, "compiler version"},
+		{VerifyRequest{CompilerVersion: "0.8.19", ContractName: "Token.sol:Coin", Input: input}, code, "not in the compiler output"},
+		{VerifyRequest{CompilerVersion: "0.8.19", ContractName: "Token", Input: input}, code, "path:name"},
+		{VerifyRequest{CompilerVersion: "0.8.19", ContractName: "Token.sol:Token", Input: input}, []byte{0x61, 0x12, 0x34, 0x00, 0xf3}, "does not match"},
+		{VerifyRequest{CompilerVersion: "0.8.19", ContractName: "Token.sol:Token", Input: input}, nil, "no contract"},
+	}
+	for i, test := range fails {
+		_, err := solc.verify(context.Background(), testToken, test.code, &test.req)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.err)
+		}
+	}
+}
@@ -1,14 +1,21 @@
 package explorer
 
 import (
+	"bytes"
+	"math/big"
 	"path"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
 	tikvCommon "github.com/harmony-one/harmony/internal/tikv/common"
 	"github.com/harmony-one/harmony/internal/tikv/prefix"
 	"github.com/harmony-one/harmony/internal/tikv/remote"
+	"github.com/harmony-one/harmony/shard"
 )
 
 // database is an adapter for *leveldb.DB
@@ -27,6 +34,7 @@ type databaseReader interface {
 	Has(key []byte) (bool, error)
 	NewPrefixIterator(prefix []byte) iterator
 	NewSizedIterator(start []byte, size int) iterator
+	NewRangeIterator(start, limit []byte) iterator
 }
 
 type batch interface {
@@ -116,6 +124,37 @@ func (it *sizedIterator) Value() []byte { return it.it.Value() }
 func (it *sizedIterator) Release()      { it.it.Release() }
 func (it *sizedIterator) Error() error  { return it.it.Error() }
 
+func (db *explorerDB) NewRangeIterator(start, limit []byte) iterator {
+	return &rangeIterator{
+		it:    db.db.NewIteratorWithStart(start),
+		limit: limit,
+	}
+}
+
+// rangeIterator iterates over the keys in [start, limit), with no upper bound
+// if limit is nil.
+type rangeIterator struct {
+	it    iterator
+	limit []byte
+	done  bool
+}
+
+func (it *rangeIterator) Next() bool {
+	if it.done || !it.it.Next() {
+		return false
+	}
+	if it.limit != nil && bytes.Compare(it.it.Key(), it.limit) >= 0 {
+		it.done = true
+		return false
+	}
+	return true
+}
+
+func (it *rangeIterator) Key() []byte   { return it.it.Key() }
+func (it *rangeIterator) Value() []byte { return it.it.Value() }
+func (it *rangeIterator) Release()      { it.it.Release() }
+func (it *rangeIterator) Error() error  { return it.it.Error() }
+
 type iterator interface {
 	Next() bool
 	Key() []byte
@@ -124,8 +163,16 @@ type iterator interface {
 	Error() error
 }
 
-// blockChainTxIndexer is the interface to check the loop up entry for transaction.
-// Implemented by core.BlockChain
+// blockChainTxIndexer is the interface to check the loop up entry for transaction,
+// and to read the blocks, receipts and states to index. Implemented by core.BlockChain
 type blockChainTxIndexer interface {
 	ReadTxLookupEntry(txID common.Hash) (common.Hash, uint64, uint64)
+	GetBlockByNumber(number uint64) *types.Block
+	GetHeaderByHash(hash common.Hash) *block.Header
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+	State() (*state.DB, error)
+	StateAt(root common.Hash) (*state.DB, error)
+	Config() *params.ChainConfig
+	ReadShardState(epoch *big.Int) (*shard.State, error)
+	ReadValidatorList() ([]common.Address, error)
 }
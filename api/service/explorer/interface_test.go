@@ -175,6 +175,32 @@ func (db *memDB) NewSizedIterator(start []byte, size int) iterator {
 	return nil
 }
 
+func (db *memDB) NewRangeIterator(start, limit []byte) iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	var (
+		st     = hex.EncodeToString(start)
+		lm     = hex.EncodeToString(limit)
+		keys   = make([]string, 0, len(db.keyValues))
+		values = make([][]byte, 0, len(db.keyValues))
+	)
+	for key := range db.keyValues {
+		if key >= st && (limit == nil || key < lm) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values = append(values, db.keyValues[key])
+	}
+	return &memPrefixIterator{
+		keys:   keys,
+		values: values,
+		index:  -1,
+	}
+}
+
 type memBatch struct {
 	keyValues map[string][]byte
 	db        *memDB
@@ -0,0 +1,104 @@
+package explorer
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/hmy/tracers"
+)
+
+// InternalTx is a value transfer, contract creation or self destruct made by a
+// contract during the execution of a transaction, stored in explorer db.
+type InternalTx struct {
+	TxHash       common.Hash
+	BlockNumber  uint64
+	TxIndex      uint64 // index of the transaction in the block
+	Index        uint64 // index of the call in the calls of the transaction
+	TraceAddress []uint
+	Type         string // call, callcode, create, create2 or selfdestruct
+	From         common.Address
+	To           common.Address // the created contract of contract creations
+	Value        *big.Int
+	Reverted     bool
+}
+
+func (itx *InternalTx) id() internalTxID {
+	return internalTxID{
+		blockNumber: itx.BlockNumber,
+		txIndex:     itx.TxIndex,
+		index:       itx.Index,
+	}
+}
+
+// isInternalTx returns whether the call is indexed as an internal transaction:
+// a call made by a contract which moves value, or a contract creation or self
+// destruct made by a contract. The calls of the transactions themselves are
+// already indexed as transactions.
+func isInternalTx(call *tracers.InternalCall) bool {
+	if len(call.TraceAddress) == 0 {
+		return false
+	}
+	switch call.Op {
+	case vm.CALL, vm.CALLCODE:
+		return call.Value.Sign() > 0
+	case vm.CREATE, vm.CREATE2, vm.SELFDESTRUCT:
+		return true
+	}
+	return false
+}
+
+// computeInternalTxs writes the internal transactions of the traced block along
+// with their address indexes, and the contracts created by contracts.
+func computeInternalTxs(btc batch, data *tracers.TraceBlockStorage) error {
+	calls, err := data.InternalCalls()
+	if err != nil {
+		return err
+	}
+	var (
+		txIndex = -1
+		index   uint64
+	)
+	for _, call := range calls {
+		if call.TxIndex != txIndex {
+			txIndex, index = call.TxIndex, 0
+		} else {
+			index++
+		}
+		if !isInternalTx(call) {
+			continue
+		}
+		writeInternalTxWithIndexes(btc, &InternalTx{
+			TxHash:       call.TxHash,
+			BlockNumber:  data.Number,
+			TxIndex:      uint64(call.TxIndex),
+			Index:        index,
+			TraceAddress: call.TraceAddress,
+			Type:         strings.ToLower(call.Op.String()),
+			From:         call.From,
+			To:           call.To,
+			Value:        call.Value,
+			Reverted:     call.Reverted,
+		})
+		if (call.Op == vm.CREATE || call.Op == vm.CREATE2) && !call.Reverted {
+			writeInternalContractCreation(btc, data.Number, call)
+		}
+	}
+	return nil
+}
+
+// writeInternalTxWithIndexes writes the internal transaction and its indexes.
+func writeInternalTxWithIndexes(btc batch, itx *InternalTx) {
+	_ = writeInternalTx(btc, itx)
+	_ = writeAddrInternalTxIndex(btc, addrInternalTxIndex{
+		addr: ethToOneAddress(itx.From),
+		id:   itx.id(),
+	}, txSent)
+	if itx.To != itx.From {
+		_ = writeAddrInternalTxIndex(btc, addrInternalTxIndex{
+			addr: ethToOneAddress(itx.To),
+			id:   itx.id(),
+		}, txReceived)
+	}
+}
@@ -0,0 +1,96 @@
+package explorer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/hmy/tracers"
+)
+
+func TestIsInternalTx(t *testing.T) {
+	tests := []struct {
+		call *tracers.InternalCall
+		exp  bool
+	}{
+		{&tracers.InternalCall{TraceAddress: []uint{0}, Op: vm.CALL, Value: big.NewInt(1)}, true},
+		{&tracers.InternalCall{TraceAddress: []uint{0}, Op: vm.CALL, Value: big.NewInt(0)}, false},
+		{&tracers.InternalCall{TraceAddress: []uint{0, 1}, Op: vm.CALLCODE, Value: big.NewInt(1)}, true},
+		{&tracers.InternalCall{TraceAddress: []uint{0}, Op: vm.DELEGATECALL, Value: big.NewInt(1)}, false},
+		{&tracers.InternalCall{TraceAddress: []uint{0}, Op: vm.STATICCALL, Value: big.NewInt(0)}, false},
+		{&tracers.InternalCall{TraceAddress: []uint{0}, Op: vm.CREATE2, Value: big.NewInt(0)}, true},
+		{&tracers.InternalCall{TraceAddress: []uint{0}, Op: vm.SELFDESTRUCT, Value: big.NewInt(0)}, true},
+		{&tracers.InternalCall{Op: vm.CALL, Value: big.NewInt(1)}, false},
+		{&tracers.InternalCall{Op: vm.CREATE, Value: big.NewInt(0)}, false},
+	}
+	for i, test := range tests {
+		if got := isInternalTx(test.call); got != test.exp {
+			t.Errorf("Test %v: unexpected result %v / %v", i, got, test.exp)
+		}
+	}
+}
+
+func TestInternalTxIndex(t *testing.T) {
+	var (
+		contract = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		user     = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	)
+	db := newMemDB()
+	btc := db.NewBatch()
+	for bn := uint64(1); bn <= 3; bn++ {
+		writeInternalTxWithIndexes(btc, &InternalTx{
+			TxHash:       makeTestTxHash(int(bn)),
+			BlockNumber:  bn,
+			TxIndex:      0,
+			Index:        1,
+			TraceAddress: []uint{0},
+			Type:         "call",
+			From:         contract,
+			To:           user,
+			Value:        new(big.Int).SetUint64(bn),
+		})
+	}
+	// self destruct refunding the contract itself
+	writeInternalTxWithIndexes(btc, &InternalTx{
+		TxHash:       makeTestTxHash(4),
+		BlockNumber:  4,
+		TraceAddress: []uint{0},
+		Type:         "selfdestruct",
+		From:         contract,
+		To:           contract,
+		Value:        big.NewInt(0),
+	})
+	if err := btc.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	itxs, tts, err := getInternalTxsByAccount(db, ethToOneAddress(user), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(itxs) != 3 || len(tts) != 3 {
+		t.Fatalf("unexpected internal tx count: %v / %v", len(itxs), 3)
+	}
+	for i, itx := range itxs {
+		bn := uint64(i + 1)
+		if itx.BlockNumber != bn || itx.Index != 1 || itx.TxHash != makeTestTxHash(i+1) ||
+			itx.Value.Uint64() != bn || itx.From != contract || itx.To != user {
+			t.Errorf("unexpected internal tx: %+v", itx)
+		}
+		if len(itx.TraceAddress) != 1 || itx.TraceAddress[0] != 0 {
+			t.Errorf("unexpected trace address: %v", itx.TraceAddress)
+		}
+		if tts[i] != txReceived {
+			t.Errorf("unexpected type: %v", tts[i])
+		}
+	}
+
+	itxs, tts, err = getInternalTxsByAccount(db, ethToOneAddress(contract), 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(itxs) != 1 || itxs[0].Type != "selfdestruct" || tts[0] != txSent {
+		t.Errorf("unexpected page: %v", itxs)
+	}
+}
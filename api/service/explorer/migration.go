@@ -5,10 +5,14 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/abool"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy/tracers"
 	"github.com/harmony-one/harmony/internal/utils"
+	goversion "github.com/hashicorp/go-version"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -309,3 +313,146 @@ func (m *migrationV100) checkMigratedTx(legTx *LegTxRecord) error {
 	}
 	return nil
 }
+
+func (s *storage) migrateToV110() error {
+	m := s.newBlockMigration(versionV110, func(btc batch, b *types.Block) error {
+		computeTokenTransfers(btc, b, s.bc.GetReceiptsByHash(b.Hash()))
+		return nil
+	})
+	return m.do()
+}
+
+func (s *storage) migrateToV120() error {
+	m := s.newBlockMigration(versionV120, s.computeStoredInternalTxs)
+	return m.do()
+}
+
+func (s *storage) migrateToV130() error {
+	bc := &blockComputer{
+		db: s.db,
+		bc: s.bc,
+	}
+	m := s.newBlockMigration(versionV130, func(btc batch, b *types.Block) error {
+		for _, stk := range b.StakingTransactions() {
+			bc.computeStakingTx(btc, b, stk)
+		}
+		return nil
+	})
+	return m.do()
+}
+
+func (s *storage) migrateToV140() error {
+	m := s.newBlockMigration(versionV140, func(btc batch, b *types.Block) error {
+		computeContractCreations(btc, s.bc, b, s.bc.GetReceiptsByHash(b.Hash()))
+		return s.computeStoredInternalTxs(btc, b)
+	})
+	return m.do()
+}
+
+func (s *storage) migrateToV150() error {
+	m := s.newBlockMigration(versionV150, func(btc batch, b *types.Block) error {
+		computeBalanceChanges(btc, s.bc, b, s.bc.GetReceiptsByHash(b.Hash()))
+		return nil
+	})
+	return m.do()
+}
+
+// computeStoredInternalTxs computes the internal transactions of the block from
+// its trace result stored in db, if any.
+func (s *storage) computeStoredInternalTxs(btc batch, b *types.Block) error {
+	if exist, err := isTraceResultInDB(s.db, b.Hash().Bytes()); !exist || err != nil {
+		return err
+	}
+	traceStorage := &tracers.TraceBlockStorage{
+		Hash: b.Hash(),
+	}
+	err := traceStorage.FromDB(func(key []byte) ([]byte, error) {
+		return getTraceResult(s.db, key)
+	})
+	if err != nil {
+		return err
+	}
+	return computeInternalTxs(btc, traceStorage)
+}
+
+func (s *storage) newBlockMigration(version *goversion.Version, compute func(btc batch, b *types.Block) error) *blockMigration {
+	return &blockMigration{
+		db:      s.db,
+		bc:      s.bc,
+		btc:     s.db.NewBatch(),
+		rb:      s.rb,
+		version: version,
+		compute: compute,
+		log: utils.Logger().With().
+			Str("module", fmt.Sprintf("explorer DB migration to %v", version)).Logger(),
+		closeC: s.closeC,
+	}
+}
+
+// blockMigration computes the new indexes of the blocks already computed by the
+// explorer, and writes the version once all of them are done.
+type blockMigration struct {
+	db      database
+	bc      blockChainTxIndexer
+	btc     batch
+	rb      *roaring64.Bitmap
+	version *goversion.Version
+	compute func(btc batch, b *types.Block) error
+
+	log    zerolog.Logger
+	closeC chan struct{}
+}
+
+func (m *blockMigration) do() error {
+	var (
+		total    = m.rb.GetCardinality()
+		migrated uint64
+		logged   time.Time
+	)
+	m.log.Info().Str("progress", fmt.Sprintf("%v / %v", 0, total)).
+		Msg("Start migration")
+	it := m.rb.Iterator()
+	for it.HasNext() {
+		select {
+		case <-m.closeC:
+			if err := m.btc.Write(); err != nil {
+				return err
+			}
+			return errInterrupted
+		default:
+		}
+		bn := it.Next()
+		if b := m.bc.GetBlockByNumber(bn); b != nil {
+			if err := m.compute(m.btc, b); err != nil {
+				return errors.Wrapf(err, "block %v", bn)
+			}
+		}
+		if err := m.flushDBIfBatchFull(); err != nil {
+			return err
+		}
+		migrated++
+		if time.Since(logged) > 2*time.Second {
+			m.log.Info().Str("progress", fmt.Sprintf("%v / %v", migrated, total)).
+				Msg("migration in progress")
+			logged = time.Now()
+		}
+	}
+	if err := m.btc.Write(); err != nil {
+		return err
+	}
+	if err := writeVersion(m.db, m.version); err != nil {
+		return errors.Wrap(err, "write version")
+	}
+	m.log.Info().Msg("Finished migration")
+	return nil
+}
+
+func (m *blockMigration) flushDBIfBatchFull() error {
+	if m.btc.ValueSize() > writeThreshold {
+		if err := m.btc.Write(); err != nil {
+			return err
+		}
+		m.btc = m.db.NewBatch()
+	}
+	return nil
+}
@@ -2,12 +2,19 @@ package explorer
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/abool"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
 
@@ -81,3 +88,23 @@ func (bc *migrationBlockChain) ReadTxLookupEntry(txID common.Hash) (common.Hash,
 	index := txID.Big().Uint64()
 	return common.Hash{}, index / 100, index % 100
 }
+
+func (bc *migrationBlockChain) GetBlockByNumber(number uint64) *types.Block { return nil }
+
+func (bc *migrationBlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts { return nil }
+
+func (bc *migrationBlockChain) GetHeaderByHash(hash common.Hash) *block.Header { return nil }
+
+func (bc *migrationBlockChain) State() (*state.DB, error) { return nil, errors.New("no state") }
+
+func (bc *migrationBlockChain) StateAt(root common.Hash) (*state.DB, error) {
+	return nil, errors.New("no state")
+}
+
+func (bc *migrationBlockChain) Config() *params.ChainConfig { return params.TestChainConfig }
+
+func (bc *migrationBlockChain) ReadShardState(epoch *big.Int) (*shard.State, error) {
+	return nil, errors.New("no shard state")
+}
+
+func (bc *migrationBlockChain) ReadValidatorList() ([]common.Address, error) { return nil, nil }
@@ -1,12 +1,15 @@
 package explorer
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 
 	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
+	staking "github.com/harmony-one/harmony/staking/types"
 	goversion "github.com/hashicorp/go-version"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -73,10 +76,44 @@ func getTraceResult(db databaseReader, key []byte) ([]byte, error) {
 var (
 	versionKey     = []byte("version")
 	versionV100, _ = goversion.NewVersion("1.0.0")
+	versionV110, _ = goversion.NewVersion("1.1.0") // token transfers
+	versionV120, _ = goversion.NewVersion("1.2.0") // internal transactions
+	versionV130, _ = goversion.NewVersion("1.3.0") // staking directives
+	versionV140, _ = goversion.NewVersion("1.4.0") // contract creations
+	versionV150, _ = goversion.NewVersion("1.5.0") // balance changes
 )
 
 // isVersionV100 return whether the version is larger than or equal to 1.0.0
 func isVersionV100(db databaseReader) (bool, error) {
+	return isVersionAtLeast(db, versionV100)
+}
+
+// isVersionV110 return whether the version is larger than or equal to 1.1.0
+func isVersionV110(db databaseReader) (bool, error) {
+	return isVersionAtLeast(db, versionV110)
+}
+
+// isVersionV120 return whether the version is larger than or equal to 1.2.0
+func isVersionV120(db databaseReader) (bool, error) {
+	return isVersionAtLeast(db, versionV120)
+}
+
+// isVersionV130 return whether the version is larger than or equal to 1.3.0
+func isVersionV130(db databaseReader) (bool, error) {
+	return isVersionAtLeast(db, versionV130)
+}
+
+// isVersionV140 return whether the version is larger than or equal to 1.4.0
+func isVersionV140(db databaseReader) (bool, error) {
+	return isVersionAtLeast(db, versionV140)
+}
+
+// isVersionV150 return whether the version is larger than or equal to 1.5.0
+func isVersionV150(db databaseReader) (bool, error) {
+	return isVersionAtLeast(db, versionV150)
+}
+
+func isVersionAtLeast(db databaseReader, ver *goversion.Version) (bool, error) {
 	curVer, err := readVersion(db)
 	if err != nil {
 		if errors.Is(err, leveldb.ErrNotFound) {
@@ -84,7 +121,7 @@ func isVersionV100(db databaseReader) (bool, error) {
 		}
 		return false, err
 	}
-	return curVer.GreaterThanOrEqual(versionV100), nil
+	return curVer.GreaterThanOrEqual(ver), nil
 }
 
 func readVersion(db databaseReader) (*goversion.Version, error) {
@@ -107,6 +144,20 @@ var (
 	txnPrefix                 = []byte("tx")
 	addrNormalTxnIndexPrefix  = []byte("at")
 	addrStakingTxnIndexPrefix = []byte("stk")
+
+	tokenTransferPrefix          = []byte("tkt")
+	addrTokenTransferIndexPrefix = []byte("tka")
+	tokenTransferIndexPrefix     = []byte("tkk")
+
+	internalTxPrefix          = []byte("itx")
+	addrInternalTxIndexPrefix = []byte("ita")
+
+	contractCreationPrefix = []byte("ctr")
+	verifiedContractPrefix = []byte("vct")
+
+	balanceChangePrefix = []byte("bal")
+	richlistPrefix      = []byte("rls")
+	richlistLatestKey   = []byte("richlist_latest")
 )
 
 // bPool is the sync pool for reusing the memory for allocating db keys
@@ -229,7 +280,7 @@ func normalTxnIndexPrefixByAddr(addr oneAddress) []byte {
 
 	_, _ = b.Write(addrNormalTxnIndexPrefix)
 	_, _ = b.Write([]byte(addr))
-	return b.Bytes()
+	return common.CopyBytes(b.Bytes())
 }
 
 func txnHashFromNormalTxnIndexKey(key []byte) (common.Hash, error) {
@@ -297,7 +348,7 @@ func stakingTxnIndexPrefixByAddr(addr oneAddress) []byte {
 
 	_, _ = b.Write(addrStakingTxnIndexPrefix)
 	_, _ = b.Write([]byte(addr))
-	return b.Bytes()
+	return common.CopyBytes(b.Bytes())
 }
 
 func txnHashFromStakingTxnIndexKey(key []byte) (common.Hash, error) {
@@ -340,6 +391,675 @@ func writeStakingTxnIndex(db databaseWriter, entry stakingTxnIndex, tt TxType) e
 	return db.Put(key, []byte{byte(tt)})
 }
 
+// writeStakingTxnIndexWithDirective writes the staking transaction index entry
+// along with the directive of the transaction, which the staking transaction
+// history is filtered by.
+func writeStakingTxnIndexWithDirective(db databaseWriter, entry stakingTxnIndex, tt TxType, directive staking.Directive) error {
+	key := entry.key()
+	return db.Put(key, []byte{byte(tt), byte(directive)})
+}
+
+// txnPosition is the position of a transaction in the chain, which orders the
+// entries of the address-transaction indexes.
+type txnPosition struct {
+	blockNumber uint64
+	txnIndex    uint64
+}
+
+const txnPositionByteLen = 8 + 8
+
+func (pos txnPosition) writeTo(b *buffer.Buffer) {
+	_ = binary.Write(b, binary.BigEndian, pos.blockNumber)
+	_ = binary.Write(b, binary.BigEndian, pos.txnIndex)
+}
+
+func txnPositionFromBytes(b []byte) (txnPosition, error) {
+	if len(b) < txnPositionByteLen {
+		return txnPosition{}, errors.New("unexpected key size")
+	}
+	return txnPosition{
+		blockNumber: binary.BigEndian.Uint64(b[:8]),
+		txnIndex:    binary.BigEndian.Uint64(b[8:16]),
+	}, nil
+}
+
+// txnIndexEntry is an entry of an address-transaction index read by a query.
+type txnIndexEntry struct {
+	position txnPosition
+	txnHash  common.Hash
+	tt       TxType
+}
+
+// txnIndexQuery selects a page of the entries of an address-transaction index
+// between two blocks. The page starts after the cursor in the order of the
+// query, or after the offset first entries without cursor.
+type txnIndexQuery struct {
+	fromBlock uint64
+	toBlock   uint64 // inclusive
+	cursor    *txnPosition
+	desc      bool
+	offset    int
+	size      int
+	filter    func(val []byte) bool // entries to read, all of them if nil
+}
+
+// descWindowBlocks is the size of the first block window scanned by a query in
+// descending order. The windows double until the page is full.
+const descWindowBlocks = 4096
+
+// queryTxnIndex returns the entries of the index at the address prefix selected
+// by the query. The db only iterates forward, so the descending pages are read
+// from windows of blocks going backward from the upper bound.
+func queryTxnIndex(db databaseReader, prefix []byte, q txnIndexQuery) ([]txnIndexEntry, error) {
+	if q.size <= 0 || q.fromBlock > q.toBlock {
+		return nil, nil
+	}
+	limit := txnIndexKeyAtBlock(prefix, q.toBlock+1)
+	if q.toBlock == math.MaxUint64 {
+		limit = prefixEnd(prefix)
+	}
+	if !q.desc {
+		start := txnIndexKeyAtBlock(prefix, q.fromBlock)
+		if q.cursor != nil {
+			if cursorKey := txnIndexKeyAtPosition(prefix, *q.cursor); bytes.Compare(cursorKey, start) > 0 {
+				start = cursorKey
+			}
+		}
+		var (
+			entries []txnIndexEntry
+			skipped int
+		)
+		err := forEachInRange(db, prefix, start, limit, func(entry txnIndexEntry, val []byte) bool {
+			if q.cursor != nil && entry.position == *q.cursor {
+				return true
+			}
+			if q.filter != nil && !q.filter(val) {
+				return true
+			}
+			if skipped < q.offset {
+				skipped++
+				return true
+			}
+			entries = append(entries, entry)
+			return len(entries) < q.size
+		})
+		return entries, err
+	}
+
+	var (
+		need    = q.offset + q.size
+		entries = make([]txnIndexEntry, 0, need)
+		hi      = q.toBlock
+		window  = uint64(descWindowBlocks)
+	)
+	if q.cursor != nil && q.cursor.blockNumber <= hi {
+		if q.cursor.blockNumber < q.fromBlock {
+			return nil, nil
+		}
+		hi = q.cursor.blockNumber
+		limit = txnIndexKeyAtPosition(prefix, *q.cursor)
+	}
+	for {
+		lo := q.fromBlock
+		if hi-q.fromBlock >= window {
+			lo = hi - window + 1
+		}
+		start := txnIndexKeyAtBlock(prefix, lo)
+		var chunk []txnIndexEntry
+		err := forEachInRange(db, prefix, start, limit, func(entry txnIndexEntry, val []byte) bool {
+			if q.filter != nil && !q.filter(val) {
+				return true
+			}
+			chunk = append(chunk, entry)
+			if len(chunk) > need-len(entries) {
+				chunk = chunk[1:]
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := len(chunk) - 1; i >= 0; i-- {
+			entries = append(entries, chunk[i])
+		}
+		if len(entries) >= need || lo == q.fromBlock {
+			break
+		}
+		hi, limit = lo-1, start
+		window *= 2
+	}
+	if len(entries) <= q.offset {
+		return nil, nil
+	}
+	return entries[q.offset:], nil
+}
+
+// forEachInRange calls f on the address-transaction index entries with keys in
+// [start, limit) until f returns false.
+func forEachInRange(db databaseReader, prefix, start, limit []byte, f func(entry txnIndexEntry, val []byte) bool) error {
+	it := db.NewRangeIterator(start, limit)
+	defer it.Release()
+
+	for it.Next() {
+		key, val := it.Key(), it.Value()
+		if len(key) < len(prefix)+txnPositionByteLen+common.HashLength || len(val) < 1 {
+			return errors.New("unexpected index entry size")
+		}
+		position, err := txnPositionFromBytes(key[len(prefix):])
+		if err != nil {
+			return err
+		}
+		entry := txnIndexEntry{
+			position: position,
+			txnHash:  common.BytesToHash(key[len(prefix)+txnPositionByteLen:]),
+			tt:       TxType(val[0]),
+		}
+		if !f(entry, val) {
+			break
+		}
+	}
+	return it.Error()
+}
+
+func txnIndexKeyAtBlock(prefix []byte, blockNumber uint64) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(prefix)
+	_ = binary.Write(b, binary.BigEndian, blockNumber)
+	return common.CopyBytes(b.Bytes())
+}
+
+func txnIndexKeyAtPosition(prefix []byte, pos txnPosition) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(prefix)
+	pos.writeTo(b)
+	return common.CopyBytes(b.Bytes())
+}
+
+// prefixEnd returns the smallest key greater than all the keys with the prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := common.CopyBytes(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// tokenTransferID identifies a token transfer by the number of its block, the
+// index of its log in the block and its index in an ERC1155 batch.
+type tokenTransferID struct {
+	blockNumber uint64
+	logIndex    uint64
+	batchIndex  uint64
+}
+
+const tokenTransferIDByteLen = 8 + 8 + 8
+
+func (id tokenTransferID) writeTo(b *buffer.Buffer) {
+	_ = binary.Write(b, binary.BigEndian, id.blockNumber)
+	_ = binary.Write(b, binary.BigEndian, id.logIndex)
+	_ = binary.Write(b, binary.BigEndian, id.batchIndex)
+}
+
+func tokenTransferIDFromBytes(b []byte) (tokenTransferID, error) {
+	if len(b) < tokenTransferIDByteLen {
+		return tokenTransferID{}, errors.New("unexpected key size")
+	}
+	return tokenTransferID{
+		blockNumber: binary.BigEndian.Uint64(b[:8]),
+		logIndex:    binary.BigEndian.Uint64(b[8:16]),
+		batchIndex:  binary.BigEndian.Uint64(b[16:24]),
+	}, nil
+}
+
+// getTokenTransferKey return the token transfer key. It's a prefix with the token
+// transfer id. The token transfer keys are copied out of the pooled buffer, as
+// they are read while iterating over other keys.
+func getTokenTransferKey(id tokenTransferID) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(tokenTransferPrefix)
+	id.writeTo(b)
+	return common.CopyBytes(b.Bytes())
+}
+
+func readTokenTransfer(db databaseReader, id tokenTransferID) (*TokenTransfer, error) {
+	key := getTokenTransferKey(id)
+	b, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var tt *TokenTransfer
+	if err := rlp.DecodeBytes(b, &tt); err != nil {
+		return nil, err
+	}
+	return tt, nil
+}
+
+func writeTokenTransfer(db databaseWriter, tt *TokenTransfer) error {
+	key := getTokenTransferKey(tt.id())
+	bs, err := rlp.EncodeToBytes(tt)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, bs)
+}
+
+// addrTokenTransferIndex is a single entry of address-token transfer index. The key
+// of the entry in db is a combination of addrTokenTransferIndexPrefix, account
+// address and token transfer id
+type addrTokenTransferIndex struct {
+	addr oneAddress
+	id   tokenTransferID
+}
+
+func (index addrTokenTransferIndex) key() []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(addrTokenTransferIndexPrefix)
+	_, _ = b.Write([]byte(index.addr))
+	index.id.writeTo(b)
+	return common.CopyBytes(b.Bytes())
+}
+
+func addrTokenTransferIndexPrefixByAddr(addr oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(addrTokenTransferIndexPrefix)
+	_, _ = b.Write([]byte(addr))
+	return common.CopyBytes(b.Bytes())
+}
+
+func writeAddrTokenTransferIndex(db databaseWriter, entry addrTokenTransferIndex, tt TxType) error {
+	key := entry.key()
+	return db.Put(key, []byte{byte(tt)})
+}
+
+// getTokenTransfersByAccount returns a page of the token transfers sent or received
+// by the account, ordered by block number.
+func getTokenTransfersByAccount(db databaseReader, addr oneAddress, page, size int) ([]*TokenTransfer, []TxType, error) {
+	var (
+		transfers []*TokenTransfer
+		txTypes   []TxType
+	)
+	prefix := addrTokenTransferIndexPrefixByAddr(addr)
+	err := forEachPageAtPrefix(db, prefix, page, size, func(key, val []byte) error {
+		id, err := tokenTransferIDFromBytes(key[len(prefix):])
+		if err != nil {
+			return err
+		}
+		if len(val) < 1 {
+			return errors.New("val size not expected")
+		}
+		tt, err := readTokenTransfer(db, id)
+		if err != nil {
+			return err
+		}
+		transfers = append(transfers, tt)
+		txTypes = append(txTypes, TxType(val[0]))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return transfers, txTypes, nil
+}
+
+// tokenTransferIndex is a single entry of token-token transfer index. The key of
+// the entry in db is a combination of tokenTransferIndexPrefix, token address
+// and token transfer id
+type tokenTransferIndex struct {
+	token oneAddress
+	id    tokenTransferID
+}
+
+func (index tokenTransferIndex) key() []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(tokenTransferIndexPrefix)
+	_, _ = b.Write([]byte(index.token))
+	index.id.writeTo(b)
+	return common.CopyBytes(b.Bytes())
+}
+
+func tokenTransferIndexPrefixByToken(token oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(tokenTransferIndexPrefix)
+	_, _ = b.Write([]byte(token))
+	return common.CopyBytes(b.Bytes())
+}
+
+func writeTokenTransferIndex(db databaseWriter, entry tokenTransferIndex) error {
+	key := entry.key()
+	return db.Put(key, []byte{})
+}
+
+// getTokenTransfersByToken returns a page of the transfers of the token, ordered
+// by block number.
+func getTokenTransfersByToken(db databaseReader, token oneAddress, page, size int) ([]*TokenTransfer, error) {
+	var transfers []*TokenTransfer
+	prefix := tokenTransferIndexPrefixByToken(token)
+	err := forEachPageAtPrefix(db, prefix, page, size, func(key, val []byte) error {
+		id, err := tokenTransferIDFromBytes(key[len(prefix):])
+		if err != nil {
+			return err
+		}
+		tt, err := readTokenTransfer(db, id)
+		if err != nil {
+			return err
+		}
+		transfers = append(transfers, tt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// internalTxID identifies an internal transaction by the number of its block,
+// the index of its transaction in the block and its index in the calls of the
+// transaction.
+type internalTxID struct {
+	blockNumber uint64
+	txIndex     uint64
+	index       uint64
+}
+
+const internalTxIDByteLen = 8 + 8 + 8
+
+func (id internalTxID) writeTo(b *buffer.Buffer) {
+	_ = binary.Write(b, binary.BigEndian, id.blockNumber)
+	_ = binary.Write(b, binary.BigEndian, id.txIndex)
+	_ = binary.Write(b, binary.BigEndian, id.index)
+}
+
+func internalTxIDFromBytes(b []byte) (internalTxID, error) {
+	if len(b) < internalTxIDByteLen {
+		return internalTxID{}, errors.New("unexpected key size")
+	}
+	return internalTxID{
+		blockNumber: binary.BigEndian.Uint64(b[:8]),
+		txIndex:     binary.BigEndian.Uint64(b[8:16]),
+		index:       binary.BigEndian.Uint64(b[16:24]),
+	}, nil
+}
+
+// getInternalTxKey return the internal transaction key. It's a prefix with the
+// internal transaction id.
+func getInternalTxKey(id internalTxID) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(internalTxPrefix)
+	id.writeTo(b)
+	return common.CopyBytes(b.Bytes())
+}
+
+func readInternalTx(db databaseReader, id internalTxID) (*InternalTx, error) {
+	key := getInternalTxKey(id)
+	b, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var itx *InternalTx
+	if err := rlp.DecodeBytes(b, &itx); err != nil {
+		return nil, err
+	}
+	return itx, nil
+}
+
+func writeInternalTx(db databaseWriter, itx *InternalTx) error {
+	key := getInternalTxKey(itx.id())
+	bs, err := rlp.EncodeToBytes(itx)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, bs)
+}
+
+// addrInternalTxIndex is a single entry of address-internal transaction index. The
+// key of the entry in db is a combination of addrInternalTxIndexPrefix, account
+// address and internal transaction id
+type addrInternalTxIndex struct {
+	addr oneAddress
+	id   internalTxID
+}
+
+func (index addrInternalTxIndex) key() []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(addrInternalTxIndexPrefix)
+	_, _ = b.Write([]byte(index.addr))
+	index.id.writeTo(b)
+	return common.CopyBytes(b.Bytes())
+}
+
+func addrInternalTxIndexPrefixByAddr(addr oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(addrInternalTxIndexPrefix)
+	_, _ = b.Write([]byte(addr))
+	return common.CopyBytes(b.Bytes())
+}
+
+func writeAddrInternalTxIndex(db databaseWriter, entry addrInternalTxIndex, tt TxType) error {
+	key := entry.key()
+	return db.Put(key, []byte{byte(tt)})
+}
+
+// getInternalTxsByAccount returns a page of the internal transactions sent or
+// received by the account, ordered by block number.
+func getInternalTxsByAccount(db databaseReader, addr oneAddress, page, size int) ([]*InternalTx, []TxType, error) {
+	var (
+		itxs    []*InternalTx
+		txTypes []TxType
+	)
+	prefix := addrInternalTxIndexPrefixByAddr(addr)
+	err := forEachPageAtPrefix(db, prefix, page, size, func(key, val []byte) error {
+		id, err := internalTxIDFromBytes(key[len(prefix):])
+		if err != nil {
+			return err
+		}
+		if len(val) < 1 {
+			return errors.New("val size not expected")
+		}
+		itx, err := readInternalTx(db, id)
+		if err != nil {
+			return err
+		}
+		itxs = append(itxs, itx)
+		txTypes = append(txTypes, TxType(val[0]))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return itxs, txTypes, nil
+}
+
+// getContractCreationKey return the contract creation key. It's a prefix with the
+// contract address.
+func getContractCreationKey(addr oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(contractCreationPrefix)
+	_, _ = b.Write([]byte(addr))
+	return common.CopyBytes(b.Bytes())
+}
+
+func readContractCreation(db databaseReader, addr oneAddress) (*ContractCreation, error) {
+	key := getContractCreationKey(addr)
+	b, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var cc *ContractCreation
+	if err := rlp.DecodeBytes(b, &cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+func writeContractCreation(db databaseWriter, cc *ContractCreation) error {
+	key := getContractCreationKey(ethToOneAddress(cc.Address))
+	bs, err := rlp.EncodeToBytes(cc)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, bs)
+}
+
+// getVerifiedContractKey return the verified contract key. It's a prefix with the
+// contract address.
+func getVerifiedContractKey(addr oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(verifiedContractPrefix)
+	_, _ = b.Write([]byte(addr))
+	return common.CopyBytes(b.Bytes())
+}
+
+func readVerifiedContract(db databaseReader, addr oneAddress) (*VerifiedContract, error) {
+	key := getVerifiedContractKey(addr)
+	b, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var vc *VerifiedContract
+	if err := rlp.DecodeBytes(b, &vc); err != nil {
+		return nil, err
+	}
+	return vc, nil
+}
+
+func writeVerifiedContract(db databaseWriter, vc *VerifiedContract) error {
+	key := getVerifiedContractKey(ethToOneAddress(vc.Address))
+	bs, err := rlp.EncodeToBytes(vc)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, bs)
+}
+
+// getBalanceChangeKey return the balance change key. It's a combination of the
+// prefix, account address and block number.
+func getBalanceChangeKey(addr oneAddress, blockNumber uint64) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(balanceChangePrefix)
+	_, _ = b.Write([]byte(addr))
+	_ = binary.Write(b, binary.BigEndian, blockNumber)
+	return common.CopyBytes(b.Bytes())
+}
+
+func balanceChangePrefixByAddr(addr oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(balanceChangePrefix)
+	_, _ = b.Write([]byte(addr))
+	return common.CopyBytes(b.Bytes())
+}
+
+func writeBalanceChange(db databaseWriter, bc *BalanceChange) error {
+	key := getBalanceChangeKey(ethToOneAddress(bc.Address), bc.BlockNumber)
+	bs, err := rlp.EncodeToBytes(bc)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, bs)
+}
+
+// getBalanceChangesByAccount returns a page of the balance changes of the account,
+// ordered by block number.
+func getBalanceChangesByAccount(db databaseReader, addr oneAddress, page, size int) ([]*BalanceChange, error) {
+	var changes []*BalanceChange
+	err := forEachPageAtPrefix(db, balanceChangePrefixByAddr(addr), page, size, func(key, val []byte) error {
+		var bc *BalanceChange
+		if err := rlp.DecodeBytes(val, &bc); err != nil {
+			return err
+		}
+		changes = append(changes, bc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// getRichlistKey return the richlist snapshot key. It's a prefix with the epoch.
+func getRichlistKey(epoch uint64) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(richlistPrefix)
+	_ = binary.Write(b, binary.BigEndian, epoch)
+	return common.CopyBytes(b.Bytes())
+}
+
+func readRichlist(db databaseReader, epoch uint64) (*RichlistSnapshot, error) {
+	key := getRichlistKey(epoch)
+	b, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot *RichlistSnapshot
+	if err := rlp.DecodeBytes(b, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// writeRichlist writes the richlist snapshot, and makes it the latest one.
+func writeRichlist(db databaseWriter, snapshot *RichlistSnapshot) error {
+	key := getRichlistKey(snapshot.Epoch)
+	bs, err := rlp.EncodeToBytes(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := db.Put(key, bs); err != nil {
+		return err
+	}
+	val := make([]byte, 8)
+	binary.BigEndian.PutUint64(val, snapshot.Epoch)
+	return db.Put(richlistLatestKey, val)
+}
+
+// readLatestRichlistEpoch returns the epoch of the latest richlist snapshot, and
+// false if there is none.
+func readLatestRichlistEpoch(db databaseReader) (uint64, bool, error) {
+	if ok, err := db.Has(richlistLatestKey); !ok || err != nil {
+		return 0, false, err
+	}
+	val, err := db.Get(richlistLatestKey)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(val) != 8 {
+		return 0, false, errors.New("val size not expected")
+	}
+	return binary.BigEndian.Uint64(val), true, nil
+}
+
 func forEachAtPrefix(db databaseReader, prefix []byte, f func(key, val []byte) error) error {
 	it := db.NewPrefixIterator(prefix)
 	defer it.Release()
@@ -353,6 +1073,23 @@ func forEachAtPrefix(db databaseReader, prefix []byte, f func(key, val []byte) e
 	return it.Error()
 }
 
+// forEachPageAtPrefix calls f on the entries of the page-th page of size entries
+// at the prefix.
+func forEachPageAtPrefix(db databaseReader, prefix []byte, page, size int, f func(key, val []byte) error) error {
+	it := db.NewPrefixIterator(prefix)
+	defer it.Release()
+
+	for skip := page * size; skip > 0 && it.Next(); skip-- {
+	}
+	for count := 0; count < size && it.Next(); count++ {
+		err := f(it.Key(), it.Value())
+		if err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 // Legacy Schema
 
 // LegGetAddressKey ...
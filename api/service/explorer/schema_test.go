@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	staking "github.com/harmony-one/harmony/staking/types"
 	goversion "github.com/hashicorp/go-version"
 )
 
@@ -183,6 +184,121 @@ func TestGetStakingTxnHashesByAccount(t *testing.T) {
 	}
 }
 
+func TestQueryTxnIndex(t *testing.T) {
+	db := newMemDB()
+	addr := makeOneAddress(1)
+	// Two transactions in every 1000th block, the odd blocks sent
+	var all []txnPosition
+	for bn := uint64(0); bn < 20000; bn += 1000 {
+		for index := uint64(0); index != 2; index++ {
+			tt := txReceived
+			if (bn/1000)%2 == 1 {
+				tt = txSent
+			}
+			entry := normalTxnIndex{
+				addr:        addr,
+				blockNumber: bn,
+				txnIndex:    index,
+				txnHash:     makeTestTxHash(int(bn + index)),
+			}
+			if err := writeNormalTxnIndex(db, entry, tt); err != nil {
+				t.Fatal(err)
+			}
+			all = append(all, txnPosition{bn, index})
+		}
+	}
+	// Another address is not read
+	_ = writeNormalTxnIndex(db, normalTxnIndex{addr: makeOneAddress(2), blockNumber: 500}, txSent)
+	reversed := make([]txnPosition, 0, len(all))
+	for i := len(all) - 1; i >= 0; i-- {
+		reversed = append(reversed, all[i])
+	}
+	sent := func(val []byte) bool { return TxType(val[0]) == txSent }
+
+	tests := []struct {
+		q   txnIndexQuery
+		exp []txnPosition
+	}{
+		{txnIndexQuery{toBlock: 19000, size: 3}, all[:3]},
+		{txnIndexQuery{toBlock: 19000, size: 100}, all},
+		{txnIndexQuery{toBlock: 19000, size: 3, offset: 38}, all[38:]},
+		{txnIndexQuery{toBlock: 19000, size: 3, cursor: &all[2]}, all[3:6]},
+		{txnIndexQuery{fromBlock: 2000, toBlock: 3000, size: 10}, all[4:8]},
+		{txnIndexQuery{fromBlock: 2500, toBlock: 2900, size: 10}, nil},
+		{txnIndexQuery{toBlock: 19000, size: 3, filter: sent}, []txnPosition{all[2], all[3], all[6]}},
+		{txnIndexQuery{toBlock: 19000, size: 3, desc: true}, reversed[:3]},
+		{txnIndexQuery{toBlock: 19000, size: 100, desc: true}, reversed},
+		{txnIndexQuery{toBlock: 19000, size: 3, desc: true, offset: 38}, reversed[38:]},
+		{txnIndexQuery{toBlock: 19000, size: 3, desc: true, cursor: &reversed[2]}, reversed[3:6]},
+		{txnIndexQuery{toBlock: 1000000, size: 2, desc: true}, reversed[:2]},
+		{txnIndexQuery{fromBlock: 2000, toBlock: 3000, size: 10, desc: true}, reversed[32:36]},
+		{txnIndexQuery{fromBlock: 5000, toBlock: 19000, size: 3, desc: true, cursor: &all[9]}, nil},
+		{txnIndexQuery{toBlock: 19000, size: 3, desc: true, filter: sent}, []txnPosition{all[39], all[38], all[35]}},
+	}
+	for i, test := range tests {
+		entries, err := queryTxnIndex(db, normalTxnIndexPrefixByAddr(addr), test.q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != len(test.exp) {
+			t.Fatalf("Test %v: unexpected entry count %v / %v", i, len(entries), len(test.exp))
+		}
+		for j, entry := range entries {
+			exp := test.exp[j]
+			if entry.position != exp || entry.txnHash != makeTestTxHash(int(exp.blockNumber+exp.txnIndex)) {
+				t.Errorf("Test %v: unexpected entry %+v / %+v", i, entry, exp)
+			}
+		}
+	}
+}
+
+func TestQueryStakingTxnIndexDirective(t *testing.T) {
+	db := newMemDB()
+	addr := makeOneAddress(1)
+	directives := []staking.Directive{staking.DirectiveDelegate, staking.DirectiveUndelegate, staking.DirectiveDelegate}
+	for i, directive := range directives {
+		entry := stakingTxnIndex{
+			addr:        addr,
+			blockNumber: uint64(i),
+			txnHash:     makeTestTxHash(i),
+		}
+		if err := writeStakingTxnIndexWithDirective(db, entry, txSent, directive); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Entry written without directive
+	_ = writeStakingTxnIndex(db, stakingTxnIndex{addr: addr, blockNumber: 3, txnHash: makeTestTxHash(3)}, txSent)
+
+	entries, err := queryTxnIndex(db, stakingTxnIndexPrefixByAddr(addr), txnIndexQuery{
+		toBlock: 3,
+		size:    10,
+		filter: func(val []byte) bool {
+			return len(val) > 1 && staking.Directive(val[1]) == staking.DirectiveDelegate
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].txnHash != makeTestTxHash(0) || entries[1].txnHash != makeTestTxHash(2) {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	tests := []struct {
+		prefix, exp []byte
+	}{
+		{[]byte{1, 2}, []byte{1, 3}},
+		{[]byte{1, 0xff}, []byte{2}},
+		{[]byte{0xff, 0xff}, nil},
+	}
+	for i, test := range tests {
+		if got := prefixEnd(test.prefix); !bytes.Equal(got, test.exp) {
+			t.Errorf("Test %v: unexpected end %x / %x", i, got, test.exp)
+		}
+	}
+}
+
 func TestGetAllAddresses(t *testing.T) {
 	db := newMemDB()
 	addrs := makeAddresses(10)
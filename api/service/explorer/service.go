@@ -3,18 +3,22 @@ package explorer
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net"
 	"net/http"
 	"path"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/RoaringBitmap/roaring/roaring64"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 
 	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
 	msg_pb "github.com/harmony-one/harmony/api/proto/message"
 	"github.com/harmony-one/harmony/core"
@@ -22,11 +26,13 @@ import (
 	"github.com/harmony-one/harmony/hmy"
 	"github.com/harmony-one/harmony/hmy/tracers"
 	"github.com/harmony-one/harmony/internal/chain"
+	common2 "github.com/harmony-one/harmony/internal/common"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/numeric"
 	"github.com/harmony-one/harmony/p2p"
 	stakingReward "github.com/harmony-one/harmony/staking/reward"
+	staking "github.com/harmony-one/harmony/staking/types"
 )
 
 // Constants for explorer service.
@@ -34,6 +40,7 @@ const (
 	explorerPortDifference = 4000
 	defaultPageSize        = "1000"
 	maxAddresses           = 100000
+	maxPageSize            = 1000
 	nodeSyncTolerance      = 5
 )
 
@@ -54,6 +61,8 @@ type Service struct {
 	blockchain    core.BlockChain
 	backend       hmy.NodeAPI
 	harmonyConfig *harmonyconfig.HarmonyConfig
+	solc          *solcCompiler // nil if the contract verification is disabled
+	verifyLock    sync.Mutex    // serializes the compilations of the verified contracts
 }
 
 // New returns explorer service.
@@ -63,12 +72,17 @@ func New(harmonyConfig *harmonyconfig.HarmonyConfig, selfPeer *p2p.Peer, bc core
 	if err != nil {
 		utils.Logger().Fatal().Err(err).Msg("cannot open explorer DB")
 	}
+	var solc *solcCompiler
+	if harmonyConfig != nil && harmonyConfig.Explorer != nil && harmonyConfig.Explorer.SolcPath != "" {
+		solc = &solcCompiler{path: harmonyConfig.Explorer.SolcPath}
+	}
 	return &Service{
 		IP:         selfPeer.IP,
 		Port:       selfPeer.Port,
 		blockchain: bc,
 		backend:    backend,
 		storage:    storage,
+		solc:       solc,
 	}
 }
 
@@ -120,6 +134,30 @@ func (s *Service) Run() *http.Server {
 	s.router.Path("/addresses").HandlerFunc(s.GetAddresses)
 	s.router.Path("/height").HandlerFunc(s.GetHeight)
 
+	// Set up router for token transfers.
+	// Fetch token transfers request, accepts parameter address or token: whose
+	// transfers to read, parameters page and size: which page of transfers to read
+	s.router.Path("/token-transfers").HandlerFunc(s.GetTokenTransfers).Methods("GET")
+
+	// Set up router for internal transactions.
+	// Fetch internal transactions request, accepts parameter address: whose internal
+	// transactions to read, parameters page and size: which page of them to read
+	s.router.Path("/internal-txs").HandlerFunc(s.GetInternalTxs).Methods("GET")
+
+	// Set up router for contracts.
+	// Fetch contract request, accepts parameter address: which contract to read.
+	// Verify contract request, accepts a VerifyRequest JSON body
+	s.router.Path("/contracts").HandlerFunc(s.GetContract).Methods("GET")
+	s.router.Path("/contracts/verify").HandlerFunc(s.VerifyContract).Methods("POST")
+
+	// Set up router for balances.
+	// Fetch balance changes request, accepts parameter address: whose balance
+	// changes to read, parameters page and size: which page of them to read.
+	// Fetch richlist request, accepts parameter epoch: which snapshot to read,
+	// the latest one by default, and parameter size: how many accounts to read
+	s.router.Path("/balance-changes").HandlerFunc(s.GetBalanceChanges).Methods("GET")
+	s.router.Path("/richlist").HandlerFunc(s.GetRichlist).Methods("GET")
+
 	// Set up router for supply info
 	s.router.Path("/burn-addresses").Queries().HandlerFunc(s.GetInaccessibleAddressInfo).Methods("GET")
 	s.router.Path("/burn-addresses").HandlerFunc(s.GetInaccessibleAddressInfo)
@@ -179,6 +217,394 @@ func (s *Service) GetAddresses(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// TokenTransferInfo is a token transfer served by the /token-transfers end-point.
+type TokenTransferInfo struct {
+	TxHash      ethCommon.Hash `json:"txHash"`
+	BlockNumber uint64         `json:"blockNumber"`
+	LogIndex    uint64         `json:"logIndex"`
+	BatchIndex  uint64         `json:"batchIndex"`
+	Token       string         `json:"token"`
+	Standard    string         `json:"standard"`
+	From        string         `json:"from"`
+	To          string         `json:"to"`
+	TokenID     *big.Int       `json:"tokenId,omitempty"`
+	Value       *big.Int       `json:"value"`
+	Timestamp   uint64         `json:"timestamp"`
+	Type        string         `json:"type,omitempty"`
+}
+
+func newTokenTransferInfo(tt *TokenTransfer) *TokenTransferInfo {
+	info := &TokenTransferInfo{
+		TxHash:      tt.TxHash,
+		BlockNumber: tt.BlockNumber,
+		LogIndex:    tt.LogIndex,
+		BatchIndex:  tt.BatchIndex,
+		Token:       string(ethToOneAddress(tt.Token)),
+		Standard:    tt.Standard.String(),
+		From:        string(ethToOneAddress(tt.From)),
+		To:          string(ethToOneAddress(tt.To)),
+		Value:       tt.Value,
+		Timestamp:   tt.Timestamp,
+	}
+	if tt.Standard != tokenERC20 {
+		info.TokenID = tt.TokenID
+	}
+	return info
+}
+
+// GetTokenTransfers serves end-point /token-transfers, returns a page of the token
+// transfers sent or received by the address parameter, or of the token parameter.
+func (s *Service) GetTokenTransfers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	page, size, err := parsePageParams(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var (
+		transfers []*TokenTransfer
+		txTypes   []TxType
+	)
+	switch {
+	case r.FormValue("address") != "":
+		addr, parseErr := common2.ParseAddr(r.FormValue("address"))
+		if parseErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		transfers, txTypes, err = s.storage.GetTokenTransfersByAddress(string(ethToOneAddress(addr)), page, size)
+	case r.FormValue("token") != "":
+		token, parseErr := common2.ParseAddr(r.FormValue("token"))
+		if parseErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		transfers, err = s.storage.GetTokenTransfersByToken(string(ethToOneAddress(token)), page, size)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		utils.Logger().Warn().Err(err).Msg("wasn't able to fetch token transfers from storage")
+		return
+	}
+	display := make([]*TokenTransferInfo, 0, len(transfers))
+	for i, tt := range transfers {
+		info := newTokenTransferInfo(tt)
+		if i < len(txTypes) {
+			info.Type = txTypes[i].String()
+		}
+		display = append(display, info)
+	}
+	if err := json.NewEncoder(w).Encode(display); err != nil {
+		utils.Logger().Warn().Err(err).Msg("cannot JSON-encode token transfers")
+	}
+}
+
+// InternalTxInfo is an internal transaction served by the /internal-txs end-point.
+type InternalTxInfo struct {
+	TxHash       ethCommon.Hash `json:"txHash"`
+	BlockNumber  uint64         `json:"blockNumber"`
+	TxIndex      uint64         `json:"transactionPosition"`
+	TraceAddress []uint         `json:"traceAddress"`
+	CallType     string         `json:"callType"`
+	From         string         `json:"from"`
+	To           string         `json:"to"`
+	Value        *big.Int       `json:"value"`
+	Reverted     bool           `json:"reverted"`
+	Type         string         `json:"type"`
+}
+
+// GetInternalTxs serves end-point /internal-txs, returns a page of the internal
+// transactions sent or received by the address parameter.
+func (s *Service) GetInternalTxs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	page, size, err := parsePageParams(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	addr, err := common2.ParseAddr(r.FormValue("address"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	itxs, txTypes, err := s.storage.GetInternalTxsByAddress(string(ethToOneAddress(addr)), page, size)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		utils.Logger().Warn().Err(err).Msg("wasn't able to fetch internal transactions from storage")
+		return
+	}
+	display := make([]*InternalTxInfo, 0, len(itxs))
+	for i, itx := range itxs {
+		display = append(display, &InternalTxInfo{
+			TxHash:       itx.TxHash,
+			BlockNumber:  itx.BlockNumber,
+			TxIndex:      itx.TxIndex,
+			TraceAddress: itx.TraceAddress,
+			CallType:     itx.Type,
+			From:         string(ethToOneAddress(itx.From)),
+			To:           string(ethToOneAddress(itx.To)),
+			Value:        itx.Value,
+			Reverted:     itx.Reverted,
+			Type:         txTypes[i].String(),
+		})
+	}
+	if err := json.NewEncoder(w).Encode(display); err != nil {
+		utils.Logger().Warn().Err(err).Msg("cannot JSON-encode internal transactions")
+	}
+}
+
+// ContractInfo is a contract served by the /contracts end-point.
+type ContractInfo struct {
+	Address     string         `json:"address"`
+	Creator     string         `json:"creator,omitempty"`
+	TxHash      ethCommon.Hash `json:"txHash,omitempty"`
+	BlockNumber uint64         `json:"blockNumber,omitempty"`
+	CodeHash    ethCommon.Hash `json:"codeHash,omitempty"`
+	Verified    bool           `json:"verified"`
+
+	ContractName    string          `json:"contractName,omitempty"`
+	CompilerVersion string          `json:"compilerVersion,omitempty"`
+	ABI             json.RawMessage `json:"abi,omitempty"`
+	Metadata        json.RawMessage `json:"metadata,omitempty"`
+	Input           json.RawMessage `json:"input,omitempty"`
+	VerifiedAt      uint64          `json:"verifiedAt,omitempty"`
+}
+
+func newContractInfo(addr ethCommon.Address, cc *ContractCreation, vc *VerifiedContract) *ContractInfo {
+	info := &ContractInfo{
+		Address: string(ethToOneAddress(addr)),
+	}
+	if cc != nil {
+		info.Creator = string(ethToOneAddress(cc.Creator))
+		info.TxHash = cc.TxHash
+		info.BlockNumber = cc.BlockNumber
+		info.CodeHash = cc.CodeHash
+	}
+	if vc != nil {
+		info.Verified = true
+		info.ContractName = vc.ContractName
+		info.CompilerVersion = vc.CompilerVersion
+		info.ABI = vc.ABI
+		info.Metadata = vc.Metadata
+		info.Input = vc.Input
+		info.VerifiedAt = vc.VerifiedAt
+	}
+	return info
+}
+
+// GetContract serves end-point /contracts, returns the creation and the verified
+// source of the contract of the address parameter.
+func (s *Service) GetContract(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	addr, err := common2.ParseAddr(r.FormValue("address"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	cc, vc, err := s.storage.GetContract(string(ethToOneAddress(addr)))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		utils.Logger().Warn().Err(err).Msg("wasn't able to fetch contract from storage")
+		return
+	}
+	if cc == nil && vc == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(newContractInfo(addr, cc, vc)); err != nil {
+		utils.Logger().Warn().Err(err).Msg("cannot JSON-encode contract")
+	}
+}
+
+// VerifyContract serves end-point /contracts/verify, compiles the source of the
+// VerifyRequest body with the local solc and stores it as the verified source of
+// the contract if the compiled code is the deployed one. The verified contract is
+// stored even if the compilation outlasts the write timeout of the response.
+func (s *Service) VerifyContract(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if s.solc == nil {
+		writeHTTPError(w, http.StatusNotImplemented, "contract verification is disabled")
+		return
+	}
+	var req VerifyRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxVerifyRequestSize)).Decode(&req); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	addr, err := common2.ParseAddr(req.Address)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "invalid address: "+req.Address)
+		return
+	}
+	state, err := s.blockchain.State()
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, "cannot read state: "+err.Error())
+		return
+	}
+	s.verifyLock.Lock()
+	vc, err := s.solc.verify(r.Context(), addr, state.GetCode(addr), &req)
+	s.verifyLock.Unlock()
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.storage.WriteVerifiedContract(vc); err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, "cannot write verified contract: "+err.Error())
+		return
+	}
+	cc, _, err := s.storage.GetContract(string(ethToOneAddress(addr)))
+	if err != nil {
+		utils.Logger().Warn().Err(err).Msg("wasn't able to fetch contract from storage")
+	}
+	if err := json.NewEncoder(w).Encode(newContractInfo(addr, cc, vc)); err != nil {
+		utils.Logger().Warn().Err(err).Msg("cannot JSON-encode contract")
+	}
+}
+
+// writeHTTPError writes the status code and the JSON encoded HTTPError.
+func writeHTTPError(w http.ResponseWriter, code int, msg string) {
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(&HTTPError{Code: code, Msg: msg}); err != nil {
+		utils.Logger().Warn().Err(err).Msg("cannot JSON-encode error")
+	}
+}
+
+// BalanceChangeInfo is a balance change served by the /balance-changes end-point.
+type BalanceChangeInfo struct {
+	BlockNumber uint64   `json:"blockNumber"`
+	Timestamp   uint64   `json:"timestamp"`
+	Previous    *big.Int `json:"previous"`
+	Balance     *big.Int `json:"balance"`
+	Change      *big.Int `json:"change"`
+	Sources     []string `json:"sources"`
+}
+
+// GetBalanceChanges serves end-point /balance-changes, returns a page of the
+// balance changes of the address parameter.
+func (s *Service) GetBalanceChanges(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	page, size, err := parsePageParams(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	addr, err := common2.ParseAddr(r.FormValue("address"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	changes, err := s.storage.GetBalanceChangesByAddress(string(ethToOneAddress(addr)), page, size)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		utils.Logger().Warn().Err(err).Msg("wasn't able to fetch balance changes from storage")
+		return
+	}
+	display := make([]*BalanceChangeInfo, 0, len(changes))
+	for _, change := range changes {
+		display = append(display, &BalanceChangeInfo{
+			BlockNumber: change.BlockNumber,
+			Timestamp:   change.Timestamp,
+			Previous:    change.Previous,
+			Balance:     change.Balance,
+			Change:      change.Change(),
+			Sources:     change.Sources.Names(),
+		})
+	}
+	if err := json.NewEncoder(w).Encode(display); err != nil {
+		utils.Logger().Warn().Err(err).Msg("cannot JSON-encode balance changes")
+	}
+}
+
+// RichlistInfo is a richlist snapshot served by the /richlist end-point.
+type RichlistInfo struct {
+	Epoch       uint64                 `json:"epoch"`
+	BlockNumber uint64                 `json:"blockNumber"`
+	Timestamp   uint64                 `json:"timestamp"`
+	Accounts    []*RichlistAccountInfo `json:"accounts"`
+}
+
+// RichlistAccountInfo is an account of a richlist snapshot.
+type RichlistAccountInfo struct {
+	Address string   `json:"address"`
+	Balance *big.Int `json:"balance"`
+}
+
+// GetRichlist serves end-point /richlist, returns the accounts with the largest
+// balances at the end of the epoch parameter, or of the latest snapshot epoch.
+func (s *Service) GetRichlist(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var (
+		epoch  uint64
+		latest = r.FormValue("epoch") == ""
+		size   = richlistSize
+		err    error
+	)
+	if !latest {
+		if epoch, err = strconv.ParseUint(r.FormValue("epoch"), 10, 64); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	if sizeStr := r.FormValue("size"); sizeStr != "" {
+		if size, err = strconv.Atoi(sizeStr); err != nil || size <= 0 || size > richlistSize {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	snapshot, err := s.storage.GetRichlist(epoch, latest)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		utils.Logger().Warn().Err(err).Msg("wasn't able to fetch richlist from storage")
+		return
+	}
+	if snapshot == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	accounts := snapshot.Accounts
+	if len(accounts) > size {
+		accounts = accounts[:size]
+	}
+	display := &RichlistInfo{
+		Epoch:       snapshot.Epoch,
+		BlockNumber: snapshot.BlockNumber,
+		Timestamp:   snapshot.Timestamp,
+		Accounts:    make([]*RichlistAccountInfo, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		display.Accounts = append(display.Accounts, &RichlistAccountInfo{
+			Address: string(ethToOneAddress(account.Address)),
+			Balance: account.Balance,
+		})
+	}
+	if err := json.NewEncoder(w).Encode(display); err != nil {
+		utils.Logger().Warn().Err(err).Msg("cannot JSON-encode richlist")
+	}
+}
+
+// parsePageParams parses the page and size parameters of a paged end-point.
+func parsePageParams(r *http.Request) (int, int, error) {
+	pageStr, sizeStr := r.FormValue("page"), r.FormValue("size")
+	if pageStr == "" {
+		pageStr = "0"
+	}
+	if sizeStr == "" {
+		sizeStr = defaultPageSize
+	}
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 0 {
+		return 0, 0, fmt.Errorf("invalid page: %v", pageStr)
+	}
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size <= 0 || size > maxPageSize {
+		return 0, 0, fmt.Errorf("invalid size: %v", sizeStr)
+	}
+	return page, size, nil
+}
+
 type HeightResponse struct {
 	S0 uint64 `json:"0,omitempty"`
 	S1 uint64 `json:"1,omitempty"`
@@ -221,6 +647,92 @@ func (s *Service) GetStakingTxHashesByAccount(address string) ([]ethCommon.Hash,
 	return s.storage.GetStakingTxsByAddress(address)
 }
 
+// GetNormalTxHashesPage returns a page of the normal transaction hashes of the
+// query, and the cursor of the next page if the page is full.
+func (s *Service) GetNormalTxHashesPage(query hmy.TxHistoryQuery) ([]ethCommon.Hash, string, error) {
+	q, err := s.newTxnIndexQuery(query)
+	if err != nil {
+		return nil, "", err
+	}
+	entries, err := s.storage.GetNormalTxsPage(query.Address, q)
+	if err != nil {
+		return nil, "", err
+	}
+	return txHashesPage(entries, q.size)
+}
+
+// GetStakingTxHashesPage returns a page of the staking transaction hashes of the
+// query, and the cursor of the next page if the page is full.
+func (s *Service) GetStakingTxHashesPage(query hmy.TxHistoryQuery) ([]ethCommon.Hash, string, error) {
+	q, err := s.newTxnIndexQuery(query)
+	if err != nil {
+		return nil, "", err
+	}
+	entries, err := s.storage.GetStakingTxsPage(query.Address, q)
+	if err != nil {
+		return nil, "", err
+	}
+	return txHashesPage(entries, q.size)
+}
+
+func (s *Service) newTxnIndexQuery(query hmy.TxHistoryQuery) (txnIndexQuery, error) {
+	q := txnIndexQuery{
+		fromBlock: query.FromBlock,
+		toBlock:   query.ToBlock,
+		desc:      query.Order == "DESC",
+		size:      int(query.PageSize),
+	}
+	if q.toBlock == 0 {
+		q.toBlock = s.blockchain.CurrentBlock().NumberU64()
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeTxnCursor(query.Cursor)
+		if err != nil {
+			return txnIndexQuery{}, err
+		}
+		q.cursor = &cursor
+	} else {
+		q.offset = int(query.PageIndex) * q.size
+	}
+	txType, directive := query.TxType, query.Directive
+	q.filter = func(val []byte) bool {
+		if txType != "" && txType != "ALL" && txType != TxType(val[0]).String() {
+			return false
+		}
+		return directive == nil || (len(val) > 1 && staking.Directive(val[1]) == *directive)
+	}
+	return q, nil
+}
+
+func txHashesPage(entries []txnIndexEntry, size int) ([]ethCommon.Hash, string, error) {
+	hashes := make([]ethCommon.Hash, 0, len(entries))
+	for _, entry := range entries {
+		hashes = append(hashes, entry.txnHash)
+	}
+	var cursor string
+	if len(entries) == size {
+		cursor = encodeTxnCursor(entries[len(entries)-1].position)
+	}
+	return hashes, cursor, nil
+}
+
+// encodeTxnCursor encodes the position of the last transaction of a page as the
+// cursor of the next page.
+func encodeTxnCursor(pos txnPosition) string {
+	b := make([]byte, txnPositionByteLen)
+	binary.BigEndian.PutUint64(b[:8], pos.blockNumber)
+	binary.BigEndian.PutUint64(b[8:], pos.txnIndex)
+	return hexutil.Encode(b)
+}
+
+func decodeTxnCursor(cursor string) (txnPosition, error) {
+	b, err := hexutil.Decode(cursor)
+	if err != nil || len(b) != txnPositionByteLen {
+		return txnPosition{}, fmt.Errorf("invalid cursor: %v", cursor)
+	}
+	return txnPositionFromBytes(b)
+}
+
 func (s *Service) GetTraceResultByHash(hash ethCommon.Hash) (json.RawMessage, error) {
 	return s.storage.GetTraceResultByHash(hash)
 }
@@ -45,6 +45,10 @@ type (
 		resultC chan blockResult
 		resultT chan *traceResult
 
+		// richlistC receives the last blocks of the epochs whose richlist
+		// snapshots are to be taken
+		richlistC chan *types.Block
+
 		available *abool.AtomicBool
 		closeC    chan struct{}
 		log       zerolog.Logger
@@ -53,6 +57,7 @@ type (
 	blockResult struct {
 		btc batch
 		bn  uint64
+		b   *types.Block
 	}
 
 	traceResult struct {
@@ -96,6 +101,7 @@ func newStorage(hc *harmonyconfig.HarmonyConfig, bc core.BlockChain, dbPath stri
 		tm:        newTaskManager(bitmap),
 		resultC:   make(chan blockResult, numWorker),
 		resultT:   make(chan *traceResult, numWorker),
+		richlistC: make(chan *types.Block, 1),
 		available: abool.New(),
 		closeC:    make(chan struct{}),
 		log:       utils.Logger().With().Str("module", "explorer storage").Logger(),
@@ -155,6 +161,107 @@ func (s *storage) GetStakingTxsByAddress(addr string) ([]common.Hash, []TxType,
 	return getStakingTxnHashesByAccount(s.db, oneAddress(addr))
 }
 
+// GetNormalTxsPage returns the entries of the normal transaction index of the
+// address selected by the query.
+func (s *storage) GetNormalTxsPage(addr string, q txnIndexQuery) ([]txnIndexEntry, error) {
+	if !s.available.IsSet() {
+		return nil, ErrExplorerNotReady
+	}
+	return queryTxnIndex(s.db, normalTxnIndexPrefixByAddr(oneAddress(addr)), q)
+}
+
+// GetStakingTxsPage returns the entries of the staking transaction index of the
+// address selected by the query.
+func (s *storage) GetStakingTxsPage(addr string, q txnIndexQuery) ([]txnIndexEntry, error) {
+	if !s.available.IsSet() {
+		return nil, ErrExplorerNotReady
+	}
+	return queryTxnIndex(s.db, stakingTxnIndexPrefixByAddr(oneAddress(addr)), q)
+}
+
+func (s *storage) GetTokenTransfersByAddress(addr string, page, size int) ([]*TokenTransfer, []TxType, error) {
+	if !s.available.IsSet() {
+		return nil, nil, ErrExplorerNotReady
+	}
+	return getTokenTransfersByAccount(s.db, oneAddress(addr), page, size)
+}
+
+func (s *storage) GetTokenTransfersByToken(token string, page, size int) ([]*TokenTransfer, error) {
+	if !s.available.IsSet() {
+		return nil, ErrExplorerNotReady
+	}
+	return getTokenTransfersByToken(s.db, oneAddress(token), page, size)
+}
+
+func (s *storage) GetInternalTxsByAddress(addr string, page, size int) ([]*InternalTx, []TxType, error) {
+	if !s.available.IsSet() {
+		return nil, nil, ErrExplorerNotReady
+	}
+	return getInternalTxsByAccount(s.db, oneAddress(addr), page, size)
+}
+
+// GetContract returns the creation and the verified source of the contract, nil
+// for the ones not in the db.
+func (s *storage) GetContract(addr string) (*ContractCreation, *VerifiedContract, error) {
+	if !s.available.IsSet() {
+		return nil, nil, ErrExplorerNotReady
+	}
+	var (
+		cc *ContractCreation
+		vc *VerifiedContract
+	)
+	if ok, err := s.db.Has(getContractCreationKey(oneAddress(addr))); err != nil {
+		return nil, nil, err
+	} else if ok {
+		if cc, err = readContractCreation(s.db, oneAddress(addr)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if ok, err := s.db.Has(getVerifiedContractKey(oneAddress(addr))); err != nil {
+		return nil, nil, err
+	} else if ok {
+		if vc, err = readVerifiedContract(s.db, oneAddress(addr)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return cc, vc, nil
+}
+
+// WriteVerifiedContract writes the verified source of a contract.
+func (s *storage) WriteVerifiedContract(vc *VerifiedContract) error {
+	if !s.available.IsSet() {
+		return ErrExplorerNotReady
+	}
+	return writeVerifiedContract(s.db, vc)
+}
+
+// GetBalanceChangesByAddress returns a page of the balance changes of the address.
+func (s *storage) GetBalanceChangesByAddress(addr string, page, size int) ([]*BalanceChange, error) {
+	if !s.available.IsSet() {
+		return nil, ErrExplorerNotReady
+	}
+	return getBalanceChangesByAccount(s.db, oneAddress(addr), page, size)
+}
+
+// GetRichlist returns the richlist snapshot of the epoch, or the latest one if
+// latest is set. Nil is returned if there is no such snapshot.
+func (s *storage) GetRichlist(epoch uint64, latest bool) (*RichlistSnapshot, error) {
+	if !s.available.IsSet() {
+		return nil, ErrExplorerNotReady
+	}
+	if latest {
+		latestEpoch, ok, err := readLatestRichlistEpoch(s.db)
+		if !ok || err != nil {
+			return nil, err
+		}
+		epoch = latestEpoch
+	}
+	if ok, err := s.db.Has(getRichlistKey(epoch)); !ok || err != nil {
+		return nil, err
+	}
+	return readRichlist(s.db, epoch)
+}
+
 func (s *storage) GetTraceResultByHash(hash common.Hash) (json.RawMessage, error) {
 	if !s.available.IsSet() {
 		return nil, ErrExplorerNotReady
@@ -181,18 +288,85 @@ func (s *storage) run() {
 			os.Exit(1)
 		}
 	}
+	if is, err := isVersionV110(s.db); !is || err != nil {
+		s.available.UnSet()
+		err := s.migrateToV110()
+		if errors.Is(err, errInterrupted) {
+			return
+		}
+		if err != nil {
+			s.log.Error().Err(err).Msg("Failed to migrate explorer DB!")
+			fmt.Println("Failed to migrate explorer DB:", err)
+			os.Exit(1)
+		}
+	}
+	if is, err := isVersionV120(s.db); !is || err != nil {
+		s.available.UnSet()
+		err := s.migrateToV120()
+		if errors.Is(err, errInterrupted) {
+			return
+		}
+		if err != nil {
+			s.log.Error().Err(err).Msg("Failed to migrate explorer DB!")
+			fmt.Println("Failed to migrate explorer DB:", err)
+			os.Exit(1)
+		}
+	}
+	if is, err := isVersionV130(s.db); !is || err != nil {
+		s.available.UnSet()
+		err := s.migrateToV130()
+		if errors.Is(err, errInterrupted) {
+			return
+		}
+		if err != nil {
+			s.log.Error().Err(err).Msg("Failed to migrate explorer DB!")
+			fmt.Println("Failed to migrate explorer DB:", err)
+			os.Exit(1)
+		}
+	}
+	if is, err := isVersionV140(s.db); !is || err != nil {
+		s.available.UnSet()
+		err := s.migrateToV140()
+		if errors.Is(err, errInterrupted) {
+			return
+		}
+		if err != nil {
+			s.log.Error().Err(err).Msg("Failed to migrate explorer DB!")
+			fmt.Println("Failed to migrate explorer DB:", err)
+			os.Exit(1)
+		}
+	}
+	if is, err := isVersionV150(s.db); !is || err != nil {
+		s.available.UnSet()
+		err := s.migrateToV150()
+		if errors.Is(err, errInterrupted) {
+			return
+		}
+		if err != nil {
+			s.log.Error().Err(err).Msg("Failed to migrate explorer DB!")
+			fmt.Println("Failed to migrate explorer DB:", err)
+			os.Exit(1)
+		}
+	}
 	s.available.Set()
 	go s.loop()
 }
 
 func (s *storage) loop() {
 	s.makeWorkersAndStart()
+	go s.richlistLoop()
 	for {
 		select {
 		case res := <-s.resultC:
 			s.log.Info().Uint64("block number", res.bn).Msg("writing explorer DB")
 			if err := res.btc.Write(); err != nil {
 				s.log.Error().Err(err).Msg("explorer db failed to write")
+			} else if res.b.IsLastBlockInEpoch() {
+				// skipped if the previous snapshot is still being taken
+				select {
+				case s.richlistC <- res.b:
+				default:
+				}
 			}
 		case res := <-s.resultT:
 			s.log.Info().Str("block hash", res.data.Hash.Hex()).Msg("writing trace into explorer DB")
@@ -206,6 +380,48 @@ func (s *storage) loop() {
 	}
 }
 
+// richlistLoop takes the richlist snapshots of the epochs whose last blocks are
+// written to db.
+func (s *storage) richlistLoop() {
+	for {
+		select {
+		case b := <-s.richlistC:
+			if err := s.takeRichlistSnapshot(b); err != nil {
+				s.log.Error().Err(err).Uint64("epoch", b.Epoch().Uint64()).Msg("explorer failed to take richlist snapshot")
+			}
+		case <-s.closeC:
+			return
+		}
+	}
+}
+
+// takeRichlistSnapshot writes the richlist snapshot of the epoch of the block, the
+// last one of the epoch. The epochs older than the latest snapshot are skipped.
+func (s *storage) takeRichlistSnapshot(b *types.Block) error {
+	latest, ok, err := readLatestRichlistEpoch(s.db)
+	if err != nil {
+		return err
+	}
+	if ok && latest >= b.Epoch().Uint64() {
+		return nil
+	}
+	st, err := s.bc.StateAt(b.Root())
+	if err != nil {
+		return err
+	}
+	accounts, err := computeRichlist(s.db, st, richlistSize)
+	if err != nil {
+		return err
+	}
+	s.log.Info().Uint64("epoch", b.Epoch().Uint64()).Int("accounts", len(accounts)).Msg("writing richlist snapshot")
+	return writeRichlist(s.db, &RichlistSnapshot{
+		Epoch:       b.Epoch().Uint64(),
+		BlockNumber: b.NumberU64(),
+		Timestamp:   b.Time().Uint64(),
+		Accounts:    accounts,
+	})
+}
+
 type taskManager struct {
 	blocksHP []*types.Block // blocks with high priorities
 	blocksLP []*types.Block // blocks with low priorities
@@ -366,6 +582,10 @@ LOOP:
 					_ = writeTraceResult(traceResult.btc, key, value)
 				}
 			})
+			if err := computeInternalTxs(traceResult.btc, traceResult.data); err != nil {
+				bc.log.Error().Err(err).Str("hash", traceResult.data.Hash.String()).
+					Msg("explorer failed to index internal transactions")
+			}
 			select {
 			case bc.resultT <- traceResult:
 			case <-bc.closeC:
@@ -389,10 +609,15 @@ func (bc *blockComputer) computeBlock(b *types.Block) (*blockResult, error) {
 	for _, stk := range b.StakingTransactions() {
 		bc.computeStakingTx(btc, b, stk)
 	}
+	receipts := bc.bc.GetReceiptsByHash(b.Hash())
+	computeTokenTransfers(btc, b, receipts)
+	computeContractCreations(btc, bc.bc, b, receipts)
+	computeBalanceChanges(btc, bc.bc, b, receipts)
 	bc.tm.markBlockDone(btc, b.NumberU64())
 	return &blockResult{
 		btc: btc,
 		bn:  b.NumberU64(),
+		b:   b,
 	}, nil
 }
 
@@ -432,12 +657,12 @@ func (bc *blockComputer) computeStakingTx(btc batch, b *types.Block, tx *staking
 	from := ethToOneAddress(ethFrom)
 	_ = writeAddressEntry(btc, from)
 	_, bn, index := bc.bc.ReadTxLookupEntry(tx.Hash())
-	_ = writeStakingTxnIndex(btc, stakingTxnIndex{
+	_ = writeStakingTxnIndexWithDirective(btc, stakingTxnIndex{
 		addr:        from,
 		blockNumber: bn,
 		txnIndex:    index,
 		txnHash:     tx.Hash(),
-	}, txSent)
+	}, txSent, tx.StakingType())
 	t := b.Time().Uint64() * 1000
 	tr := &TxRecord{tx.Hash(), time.Unix(int64(t), 0)}
 	_ = writeTxn(btc, tx.Hash(), tr)
@@ -448,12 +673,12 @@ func (bc *blockComputer) computeStakingTx(btc batch, b *types.Block, tx *staking
 	}
 	to := ethToOneAddress(ethTo)
 	_ = writeAddressEntry(btc, to)
-	_ = writeStakingTxnIndex(btc, stakingTxnIndex{
+	_ = writeStakingTxnIndexWithDirective(btc, stakingTxnIndex{
 		addr:        to,
 		blockNumber: bn,
 		txnIndex:    index,
 		txnHash:     tx.Hash(),
-	}, txReceived)
+	}, txReceived, tx.StakingType())
 }
 
 func ethToOneAddress(ethAddr common.Address) oneAddress {
@@ -0,0 +1,192 @@
+package explorer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// TokenStandard is the standard of the token of a token transfer. The HRC20
+// and HRC721 tokens emit the same events as their ERC counterparts.
+type TokenStandard byte
+
+const (
+	tokenUnknown TokenStandard = iota
+	tokenERC20
+	tokenERC721
+	tokenERC1155
+)
+
+func (ts TokenStandard) String() string {
+	switch ts {
+	case tokenERC20:
+		return "ERC20"
+	case tokenERC721:
+		return "ERC721"
+	case tokenERC1155:
+		return "ERC1155"
+	}
+	return "UNKNOWN"
+}
+
+var (
+	// Transfer(address indexed from, address indexed to, uint256 value) of ERC20,
+	// the token ID is indexed in place of the value for ERC721
+	transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value)
+	transferSingleEventTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	// TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values)
+	transferBatchEventTopic = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+)
+
+// TokenTransfer is a transfer of tokens decoded from a transaction log, stored
+// in explorer db.
+type TokenTransfer struct {
+	TxHash      common.Hash
+	BlockNumber uint64
+	LogIndex    uint64 // index of the log in the block
+	BatchIndex  uint64 // index of the transfer in an ERC1155 batch
+	Token       common.Address
+	Standard    TokenStandard
+	From        common.Address
+	To          common.Address
+	TokenID     *big.Int // zero for ERC20 transfers
+	Value       *big.Int // one for ERC721 transfers
+	Timestamp   uint64
+}
+
+func (tt *TokenTransfer) id() tokenTransferID {
+	return tokenTransferID{
+		blockNumber: tt.BlockNumber,
+		logIndex:    tt.LogIndex,
+		batchIndex:  tt.BatchIndex,
+	}
+}
+
+// decodeTokenTransfers decodes the token transfers of a Transfer, TransferSingle
+// or TransferBatch log. Nil is returned for the other logs and the malformed ones.
+func decodeTokenTransfers(log *types.Log) []*TokenTransfer {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	newTransfer := func(standard TokenStandard, from, to common.Hash, id, value *big.Int) *TokenTransfer {
+		return &TokenTransfer{
+			Token:    log.Address,
+			Standard: standard,
+			From:     common.BytesToAddress(from.Bytes()),
+			To:       common.BytesToAddress(to.Bytes()),
+			TokenID:  id,
+			Value:    value,
+		}
+	}
+	switch log.Topics[0] {
+	case transferEventTopic:
+		switch {
+		case len(log.Topics) == 3 && len(log.Data) == 32:
+			value := new(big.Int).SetBytes(log.Data)
+			return []*TokenTransfer{newTransfer(tokenERC20, log.Topics[1], log.Topics[2], new(big.Int), value)}
+		case len(log.Topics) == 4 && len(log.Data) == 0:
+			id := log.Topics[3].Big()
+			return []*TokenTransfer{newTransfer(tokenERC721, log.Topics[1], log.Topics[2], id, big.NewInt(1))}
+		}
+
+	case transferSingleEventTopic:
+		if len(log.Topics) == 4 && len(log.Data) == 64 {
+			id := new(big.Int).SetBytes(log.Data[:32])
+			value := new(big.Int).SetBytes(log.Data[32:])
+			return []*TokenTransfer{newTransfer(tokenERC1155, log.Topics[2], log.Topics[3], id, value)}
+		}
+
+	case transferBatchEventTopic:
+		if len(log.Topics) != 4 {
+			return nil
+		}
+		ids, ok := decodeUint256Array(log.Data, 0)
+		if !ok {
+			return nil
+		}
+		values, ok := decodeUint256Array(log.Data, 1)
+		if !ok || len(ids) != len(values) {
+			return nil
+		}
+		transfers := make([]*TokenTransfer, 0, len(ids))
+		for i := range ids {
+			tt := newTransfer(tokenERC1155, log.Topics[2], log.Topics[3], ids[i], values[i])
+			tt.BatchIndex = uint64(i)
+			transfers = append(transfers, tt)
+		}
+		return transfers
+	}
+	return nil
+}
+
+// decodeUint256Array decodes the ABI encoded uint256[] argument at the given
+// position of the log data.
+func decodeUint256Array(data []byte, position int) ([]*big.Int, bool) {
+	if len(data) < (position+1)*32 {
+		return nil, false
+	}
+	offset := new(big.Int).SetBytes(data[position*32 : (position+1)*32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data))-32 {
+		return nil, false
+	}
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(data[start-32 : start])
+	if !length.IsUint64() || length.Uint64() > (uint64(len(data))-start)/32 {
+		return nil, false
+	}
+	items := make([]*big.Int, 0, length.Uint64())
+	for pos := start; pos < start+length.Uint64()*32; pos += 32 {
+		items = append(items, new(big.Int).SetBytes(data[pos:pos+32]))
+	}
+	return items, true
+}
+
+// computeTokenTransfers writes the token transfers of the block along with their
+// address and token indexes. The receipts are the ones of the block.
+func computeTokenTransfers(btc batch, b *types.Block, receipts types.Receipts) {
+	var (
+		txs      = b.Transactions()
+		logIndex uint64
+	)
+	for i, receipt := range receipts {
+		txHash := receipt.TxHash
+		if i < len(txs) {
+			txHash = txs[i].HashByType()
+		}
+		for _, log := range receipt.Logs {
+			for _, tt := range decodeTokenTransfers(log) {
+				tt.TxHash = txHash
+				tt.BlockNumber = b.NumberU64()
+				tt.LogIndex = logIndex
+				tt.Timestamp = b.Time().Uint64()
+				writeTokenTransferWithIndexes(btc, tt)
+			}
+			logIndex++
+		}
+	}
+}
+
+// writeTokenTransferWithIndexes writes the token transfer and its indexes. The
+// zero address of mints and burns is not indexed.
+func writeTokenTransferWithIndexes(btc batch, tt *TokenTransfer) {
+	_ = writeTokenTransfer(btc, tt)
+	_ = writeTokenTransferIndex(btc, tokenTransferIndex{
+		token: ethToOneAddress(tt.Token),
+		id:    tt.id(),
+	})
+	if tt.From != (common.Address{}) {
+		_ = writeAddrTokenTransferIndex(btc, addrTokenTransferIndex{
+			addr: ethToOneAddress(tt.From),
+			id:   tt.id(),
+		}, txSent)
+	}
+	if tt.To != (common.Address{}) && tt.To != tt.From {
+		_ = writeAddrTokenTransferIndex(btc, addrTokenTransferIndex{
+			addr: ethToOneAddress(tt.To),
+			id:   tt.id(),
+		}, txReceived)
+	}
+}
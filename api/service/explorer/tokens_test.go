@@ -0,0 +1,185 @@
+package explorer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+var (
+	testToken = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testFrom  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testTo    = common.HexToAddress("0x3333333333333333333333333333333333333333")
+)
+
+func addressTopic(addr common.Address) common.Hash {
+	return common.BytesToHash(addr.Bytes())
+}
+
+func uint256Words(values ...int64) []byte {
+	var data []byte
+	for _, v := range values {
+		data = append(data, common.BigToHash(big.NewInt(v)).Bytes()...)
+	}
+	return data
+}
+
+func TestDecodeTokenTransfers(t *testing.T) {
+	tests := []struct {
+		log *types.Log
+		exp []*TokenTransfer
+	}{
+		{
+			// ERC20 transfer
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferEventTopic, addressTopic(testFrom), addressTopic(testTo)},
+				Data:    uint256Words(100),
+			},
+			exp: []*TokenTransfer{{Standard: tokenERC20, TokenID: big.NewInt(0), Value: big.NewInt(100)}},
+		},
+		{
+			// ERC721 transfer
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferEventTopic, addressTopic(testFrom), addressTopic(testTo), common.BigToHash(big.NewInt(7))},
+			},
+			exp: []*TokenTransfer{{Standard: tokenERC721, TokenID: big.NewInt(7), Value: big.NewInt(1)}},
+		},
+		{
+			// ERC1155 single transfer
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferSingleEventTopic, addressTopic(testTo), addressTopic(testFrom), addressTopic(testTo)},
+				Data:    uint256Words(7, 100),
+			},
+			exp: []*TokenTransfer{{Standard: tokenERC1155, TokenID: big.NewInt(7), Value: big.NewInt(100)}},
+		},
+		{
+			// ERC1155 batch transfer
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferBatchEventTopic, addressTopic(testTo), addressTopic(testFrom), addressTopic(testTo)},
+				Data:    uint256Words(64, 160, 2, 7, 8, 2, 100, 200),
+			},
+			exp: []*TokenTransfer{
+				{Standard: tokenERC1155, TokenID: big.NewInt(7), Value: big.NewInt(100)},
+				{Standard: tokenERC1155, TokenID: big.NewInt(8), Value: big.NewInt(200), BatchIndex: 1},
+			},
+		},
+		{
+			// ERC1155 batch transfer with out of range arrays
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferBatchEventTopic, addressTopic(testTo), addressTopic(testFrom), addressTopic(testTo)},
+				Data:    uint256Words(64, 160, 2, 7, 8, 3, 100, 200),
+			},
+		},
+		{
+			// ERC20 transfer without value
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferEventTopic, addressTopic(testFrom), addressTopic(testTo)},
+			},
+		},
+		{
+			// Other event
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{common.HexToHash("0x01")},
+			},
+		},
+	}
+	for i, test := range tests {
+		got := decodeTokenTransfers(test.log)
+		if len(got) != len(test.exp) {
+			t.Fatalf("Test %v: unexpected transfer count %v / %v", i, len(got), len(test.exp))
+		}
+		for j, tt := range got {
+			exp := test.exp[j]
+			if tt.Token != testToken || tt.From != testFrom || tt.To != testTo {
+				t.Errorf("Test %v: unexpected addresses %x %x %x", i, tt.Token, tt.From, tt.To)
+			}
+			if tt.Standard != exp.Standard || tt.BatchIndex != exp.BatchIndex {
+				t.Errorf("Test %v: unexpected transfer %v %v", i, tt.Standard, tt.BatchIndex)
+			}
+			if tt.TokenID.Cmp(exp.TokenID) != 0 || tt.Value.Cmp(exp.Value) != 0 {
+				t.Errorf("Test %v: unexpected amount %v %v", i, tt.TokenID, tt.Value)
+			}
+		}
+	}
+}
+
+func TestComputeTokenTransfers(t *testing.T) {
+	db := newMemDB()
+	btc := db.NewBatch()
+	for bn := int64(1); bn <= 3; bn++ {
+		b := types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+			Number(big.NewInt(bn)).
+			Time(big.NewInt(100 * bn)).
+			Header())
+		receipts := types.Receipts{
+			{
+				TxHash: makeTestTxHash(int(bn)),
+				Logs: []*types.Log{
+					{Address: testFrom, Topics: []common.Hash{common.HexToHash("0x01")}},
+					{
+						Address: testToken,
+						Topics:  []common.Hash{transferEventTopic, addressTopic(testFrom), addressTopic(testTo)},
+						Data:    uint256Words(bn),
+					},
+				},
+			},
+		}
+		computeTokenTransfers(btc, b, receipts)
+	}
+	if err := btc.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	transfers, tts, err := getTokenTransfersByAccount(db, ethToOneAddress(testTo), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 3 || len(tts) != 3 {
+		t.Fatalf("unexpected transfer count: %v / %v", len(transfers), 3)
+	}
+	for i, tt := range transfers {
+		bn := uint64(i + 1)
+		if tt.BlockNumber != bn || tt.LogIndex != 1 || tt.Timestamp != 100*bn || tt.TxHash != makeTestTxHash(i+1) {
+			t.Errorf("unexpected transfer: %+v", tt)
+		}
+		if tt.Value.Uint64() != bn {
+			t.Errorf("unexpected value: %v / %v", tt.Value, bn)
+		}
+		if tts[i] != txReceived {
+			t.Errorf("unexpected type: %v", tts[i])
+		}
+	}
+
+	// Pages are ordered by block number
+	transfers, tts, err = getTokenTransfersByAccount(db, ethToOneAddress(testFrom), 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 1 || transfers[0].BlockNumber != 3 || tts[0] != txSent {
+		t.Errorf("unexpected page: %v", transfers)
+	}
+	transfers, err = getTokenTransfersByToken(db, ethToOneAddress(testToken), 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 2 || transfers[0].BlockNumber != 1 || transfers[1].BlockNumber != 2 {
+		t.Errorf("unexpected page: %v", transfers)
+	}
+	transfers, err = getTokenTransfersByToken(db, ethToOneAddress(testFrom), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 0 {
+		t.Errorf("unexpected transfers of a non token: %v", transfers)
+	}
+}
@@ -23,6 +23,7 @@ const (
 	Prometheus
 	Synchronize
 	CrosslinkSending
+	DevSealer
 )
 
 func (t Type) String() string {
@@ -45,6 +46,8 @@ func (t Type) String() string {
 		return "Synchronize"
 	case CrosslinkSending:
 		return "CrosslinkSending"
+	case DevSealer:
+		return "DevSealer"
 	default:
 		return "Unknown"
 	}
@@ -9,6 +9,7 @@ type RevertOrder []SyncStageID
 type CleanUpOrder []SyncStageID
 
 var DefaultForwardOrder = ForwardOrder{
+	StateSync,
 	Heads,
 	BlockHashes,
 	BlockBodies,
@@ -25,6 +26,7 @@ var DefaultRevertOrder = RevertOrder{
 	BlockBodies,
 	BlockHashes,
 	Heads,
+	StateSync,
 }
 
 var DefaultCleanUpOrder = CleanUpOrder{
@@ -34,9 +36,11 @@ var DefaultCleanUpOrder = CleanUpOrder{
 	BlockBodies,
 	BlockHashes,
 	Heads,
+	StateSync,
 }
 
 func DefaultStages(ctx context.Context,
+	stateSyncCfg StageStateSyncCfg,
 	headsCfg StageHeadsCfg,
 	blockHashesCfg StageBlockHashesCfg,
 	bodiesCfg StageBodiesCfg,
@@ -44,6 +48,7 @@ func DefaultStages(ctx context.Context,
 	lastMileCfg StageLastMileCfg,
 	finishCfg StageFinishCfg) []*Stage {
 
+	handlerStageStateSync := NewStageStateSync(stateSyncCfg)
 	handlerStageHeads := NewStageHeads(headsCfg)
 	handlerStageBlockHashes := NewStageBlockHashes(blockHashesCfg)
 	handlerStageBodies := NewStageBodies(bodiesCfg)
@@ -52,6 +57,11 @@ func DefaultStages(ctx context.Context,
 	handlerStageFinish := NewStageFinish(finishCfg)
 
 	return []*Stage{
+		{
+			ID:          StateSync,
+			Description: "Download the state of a pivot block",
+			Handler:     handlerStageStateSync,
+		},
 		{
 			ID:          Heads,
 			Description: "Retrieve Chain Heads",
@@ -43,6 +43,9 @@ var (
 	ErrSomeNodesNotReady                  = WrapStagedSyncError("some nodes are not ready")
 	ErrSomeNodesBlockHashFail             = WrapStagedSyncError("some nodes failed to download block hashes")
 	ErrMaxPeerHeightFail                  = WrapStagedSyncError("get max peer height failed")
+	ErrStateSyncPivotFail                 = WrapStagedSyncError("select state sync pivot failed")
+	ErrInvalidStateRoot                   = WrapStagedSyncError("downloaded state doesn't match the pivot state root")
+	ErrSaveStateSyncProgressFail          = WrapStagedSyncError("saving progress for state sync stage failed")
 )
 
 // WrapStagedSyncError wraps errors for staged sync and returns error object
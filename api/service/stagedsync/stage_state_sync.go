@@ -0,0 +1,464 @@
+package stagedsync
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/utils"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/pkg/errors"
+)
+
+const (
+	// stateSyncBatchSize is the number of state entries requested from a stream at once.
+	stateSyncBatchSize = syncproto.GetNodeDataCap
+	// stateSyncConcurrency is the maximum number of state requests in flight.
+	stateSyncConcurrency = 8
+	// stateSyncBloomSize is the memory allowance (in MB) of the bloom filter used to
+	// skip the state entries which are already in the database.
+	stateSyncBloomSize = 256
+)
+
+// StateSyncProtocol is the stream sync protocol which the state sync stage
+// downloads the pivot block and its state with.
+type StateSyncProtocol interface {
+	GetCurrentBlockNumber(ctx context.Context, opts ...syncproto.Option) (uint64, sttypes.StreamID, error)
+	GetBlocksByNumber(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error)
+	GetNodeData(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error)
+
+	RemoveStream(stID sttypes.StreamID) // If a stream delivers invalid data, remove the stream
+	NumStreams() int
+}
+
+type StageStateSync struct {
+	configs StageStateSyncCfg
+}
+
+type StageStateSyncCfg struct {
+	ctx         context.Context
+	bc          core.BlockChain
+	beacon      core.BlockChain
+	protocol    StateSyncProtocol
+	db          kv.RwDB
+	enabled     bool
+	logProgress bool
+}
+
+func NewStageStateSync(cfg StageStateSyncCfg) *StageStateSync {
+	return &StageStateSync{
+		configs: cfg,
+	}
+}
+
+// NewStageStateSyncCfg creates the config of the state sync stage. The stage is
+// disabled without the stream sync protocol or the beacon chain.
+func NewStageStateSyncCfg(ctx context.Context, bc core.BlockChain, beacon core.BlockChain, protocol StateSyncProtocol, db kv.RwDB, enabled bool, logProgress bool) StageStateSyncCfg {
+	return StageStateSyncCfg{
+		ctx:         ctx,
+		bc:          bc,
+		beacon:      beacon,
+		protocol:    protocol,
+		db:          db,
+		enabled:     enabled && protocol != nil && beacon != nil,
+		logProgress: logProgress,
+	}
+}
+
+// Exec downloads the state of a pivot block from sync streams and moves the chain head to the
+// pivot, so that the following stages only execute the blocks after it. The pivot is the last
+// block of the latest finished epoch, as peers persist the state of every epoch's last block.
+// It is trusted only once its commit signature is verified against the epoch committee.
+// The stage only bootstraps a shard chain which hasn't executed any block yet. The beacon chain
+// keeps validator snapshots and other staking records off-chain, and those can only be built by
+// executing its blocks.
+func (stg *StageStateSync) Exec(firstCycle bool, invalidBlockRevert bool, s *StageState, reverter Reverter, tx kv.RwTx) (err error) {
+	if !stg.configs.enabled || s.state.isBeacon || invalidBlockRevert {
+		return nil
+	}
+	bc := stg.configs.bc
+	if bc.CurrentBlock().NumberU64() != 0 {
+		return nil
+	}
+	if stg.configs.protocol.NumStreams() < NumPeersLowBound {
+		return ErrNotEnoughConnectedPeers
+	}
+
+	// the pivot of an interrupted state sync is reused, so the state entries
+	// which are already downloaded don't need to be fetched again
+	pivotHeight := uint64(0)
+	if errV := CreateView(stg.configs.ctx, stg.configs.db, tx, func(etx kv.Tx) (err error) {
+		if pivotHeight, err = s.CurrentStageProgress(etx); err != nil {
+			return err
+		}
+		return nil
+	}); errV != nil {
+		return errV
+	}
+	resumed := pivotHeight != 0
+	if !resumed {
+		if pivotHeight, err = stg.findPivotHeight(); err != nil {
+			return err
+		}
+		// no epoch is finished yet, all blocks are executed from genesis
+		if pivotHeight == 0 {
+			return nil
+		}
+	}
+
+	pivot, err := stg.getPivotBlock(pivotHeight)
+	if err != nil {
+		return err
+	}
+	// the pivot height is saved only once a verified pivot is found at it
+	if !resumed {
+		if err = stg.saveProgress(s, pivotHeight, tx); err != nil {
+			return err
+		}
+	}
+	utils.Logger().Info().
+		Uint64("pivot", pivot.NumberU64()).
+		Uint64("epoch", pivot.Epoch().Uint64()).
+		Str("root", pivot.Root().Hex()).
+		Msg("[STAGED_SYNC] state sync started")
+
+	startTime := time.Now()
+	if err = stg.downloadState(pivot.Root()); err != nil {
+		return err
+	}
+	// all entries are verified against their hashes while downloading, check that
+	// the state is complete and opens at the root of the pivot header
+	if _, err = state.New(pivot.Root(), bc.StateCache()); err != nil {
+		return errors.Wrap(ErrInvalidStateRoot, err.Error())
+	}
+	if err = stg.commitPivot(pivot); err != nil {
+		return err
+	}
+
+	utils.Logger().Info().
+		Uint64("pivot", pivot.NumberU64()).
+		Str("root", pivot.Root().Hex()).
+		Str("elapsed", common.PrettyDuration(time.Since(startTime)).String()).
+		Msg("[STAGED_SYNC] state sync finished")
+	return nil
+}
+
+// findPivotHeight looks up the last block of the epoch before the one the streams are in.
+// Epochs of shard chains don't have a fixed length, so the epoch boundary is found by a
+// binary search over the block epochs. A wrong answer from a stream only leads to a pivot
+// which fails the verification.
+func (stg *StageStateSync) findPivotHeight() (uint64, error) {
+	maxHeight, _, err := stg.configs.protocol.GetCurrentBlockNumber(stg.configs.ctx)
+	if err != nil {
+		return 0, errors.Wrap(ErrMaxPeerHeightFail, err.Error())
+	}
+	head, _, err := stg.getBlockByNumber(maxHeight)
+	if err != nil {
+		return 0, err
+	}
+	epoch := head.Epoch()
+	if epoch.Sign() == 0 {
+		return 0, nil
+	}
+	// blocks at lo are in an older epoch, blocks at hi are in the current one
+	lo, hi := uint64(0), maxHeight
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		block, _, err := stg.getBlockByNumber(mid)
+		if err != nil {
+			return 0, err
+		}
+		if block.Epoch().Cmp(epoch) < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// getBlockByNumber gets the block at the given height along with its commit signature
+// from a stream.
+func (stg *StageStateSync) getBlockByNumber(height uint64) (*types.Block, sttypes.StreamID, error) {
+	blocks, stid, err := stg.configs.protocol.GetBlocksByNumber(stg.configs.ctx, []uint64{height})
+	if err != nil {
+		utils.Logger().Warn().
+			Err(err).
+			Str("stream", string(stid)).
+			Uint64("height", height).
+			Msg("[STAGED_SYNC] state sync failed to get block")
+		return nil, stid, ErrGetBlock
+	}
+	if len(blocks) != 1 || blocks[0] == nil {
+		return nil, stid, ErrGetBlock
+	}
+	if blocks[0].NumberU64() != height {
+		stg.configs.protocol.RemoveStream(stid)
+		return nil, stid, ErrInvalidBlockNumber
+	}
+	return blocks[0], stid, nil
+}
+
+// getPivotBlock gets the pivot block from a stream, and accepts it only if its commit
+// signature is verified. The stream which delivers an invalid pivot is removed.
+func (stg *StageStateSync) getPivotBlock(height uint64) (*types.Block, error) {
+	pivot, stid, err := stg.getBlockByNumber(height)
+	if err != nil {
+		return nil, err
+	}
+	if err := stg.verifyPivot(pivot); err != nil {
+		stg.configs.protocol.RemoveStream(stid)
+		return nil, errors.Wrapf(ErrStateSyncPivotFail, "block %d: %v", height, err)
+	}
+	return pivot, nil
+}
+
+// verifyPivot checks that the pivot is the last block of an epoch of the shard, and
+// verifies its commit signature and bitmap against the committee of its epoch. The
+// shard chain has no committee before it is synced, so the committee is read from the
+// beacon chain, which verifies the signature the same way as the one of a cross link.
+func (stg *StageStateSync) verifyPivot(pivot *types.Block) error {
+	if pivot.ShardID() != stg.configs.bc.ShardID() {
+		return errors.Errorf("block of shard %d", pivot.ShardID())
+	}
+	if !pivot.IsLastBlockInEpoch() {
+		return errors.New("not the last block of an epoch")
+	}
+	sig, bitmap, err := chain.ParseCommitSigAndBitmap(pivot.GetCurrentCommitSig())
+	if err != nil {
+		return errors.Wrap(err, "parse commit signature")
+	}
+	cl := types.CrossLink{
+		HashF:        pivot.Hash(),
+		BlockNumberF: pivot.Number(),
+		ViewIDF:      pivot.Header().ViewID(),
+		SignatureF:   sig,
+		BitmapF:      bitmap,
+		ShardIDF:     pivot.ShardID(),
+		EpochF:       pivot.Epoch(),
+	}
+	beacon := stg.configs.beacon
+	if err := beacon.Engine().VerifyCrossLink(beacon, cl); err != nil {
+		return errors.Wrap(err, "verify commit signature")
+	}
+	return nil
+}
+
+// downloadState fetches all state entries under the given root from the sync streams
+// and stores them into the chain database.
+func (stg *StageStateSync) downloadState(root common.Hash) error {
+	chainDb := stg.configs.bc.ChainDb()
+	bloom := trie.NewSyncBloom(stateSyncBloomSize, chainDb)
+	defer bloom.Close()
+	sched := state.NewStateSync(root, chainDb, bloom)
+
+	var (
+		lock      sync.Mutex
+		retries   []common.Hash
+		failures  = make(map[sttypes.StreamID]int)
+		batch     = chainDb.NewBatch()
+		processed uint64
+		startTime = time.Now()
+	)
+	if stg.configs.logProgress {
+		fmt.Print("\033[s") // save the cursor position
+	}
+
+	for sched.Pending() > 0 {
+		numStreams := stg.configs.protocol.NumStreams()
+		if numStreams == 0 {
+			return ErrNotEnoughConnectedPeers
+		}
+		if numStreams > stateSyncConcurrency {
+			numStreams = stateSyncConcurrency
+		}
+		var (
+			wg         sync.WaitGroup
+			processErr error
+		)
+		for w := 0; w < numStreams; w++ {
+			lock.Lock()
+			hashes := retries
+			if len(hashes) > stateSyncBatchSize {
+				hashes = hashes[:stateSyncBatchSize]
+			}
+			retries = retries[len(hashes):]
+			hashes = append(hashes[:len(hashes):len(hashes)], sched.Missing(stateSyncBatchSize-len(hashes))...)
+			lock.Unlock()
+			if len(hashes) == 0 {
+				break
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// the entries are verified against the requested hashes by the protocol,
+				// which removes the stream delivering a mismatching entry
+				payload, stid, err := stg.configs.protocol.GetNodeData(stg.configs.ctx, hashes)
+
+				lock.Lock()
+				defer lock.Unlock()
+				if err != nil {
+					payload = nil
+				}
+				delivered := 0
+				for i, hash := range hashes {
+					// entries which are missing are asked again
+					if i >= len(payload) || len(payload[i]) == 0 {
+						retries = append(retries, hash)
+						continue
+					}
+					_, _, err := sched.Process([]trie.SyncResult{{Hash: hash, Data: payload[i]}})
+					if err != nil && err != trie.ErrNotRequested && err != trie.ErrAlreadyProcessed {
+						processErr = err
+						return
+					}
+					delivered++
+				}
+				processed += uint64(delivered)
+				if delivered == 0 && stid != "" {
+					if failures[stid]++; failures[stid] >= downloadBlocksRetryLimit {
+						stg.configs.protocol.RemoveStream(stid)
+						delete(failures, stid)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if processErr != nil {
+			return processErr
+		}
+
+		if err := sched.Commit(batch); err != nil {
+			return err
+		}
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+
+		// log the stage progress in console
+		if stg.configs.logProgress {
+			dt := time.Now().Sub(startTime).Seconds()
+			speed := float64(0)
+			if dt > 0 {
+				speed = float64(processed) / dt
+			}
+			entrySpeed := fmt.Sprintf("%.2f", speed)
+			fmt.Print("\033[u\033[K") // restore the cursor position and clear the line
+			fmt.Println("downloading state progress:", processed, "entries,", sched.Pending(), "pending (", entrySpeed, "entries/s", ")")
+		}
+	}
+
+	if err := sched.Commit(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// commitPivot writes the pivot block along with the off-chain data needed to execute
+// the next epoch, and sets it as the chain head.
+func (stg *StageStateSync) commitPivot(pivot *types.Block) error {
+	bc := stg.configs.bc
+	if err := bc.WriteBlockWithoutState(pivot, big.NewInt(0)); err != nil {
+		return err
+	}
+	if err := bc.WriteCommitSig(pivot.NumberU64(), pivot.GetCurrentCommitSig()); err != nil {
+		return err
+	}
+	shardState, err := shard.DecodeWrapper(pivot.Header().ShardState())
+	if err != nil {
+		return err
+	}
+	nextEpoch := new(big.Int).Add(pivot.Epoch(), common.Big1)
+	if shardState.Epoch != nil && bc.Config().IsStaking(shardState.Epoch) {
+		// after staking, the next epoch is decided by the epoch in the shard state
+		nextEpoch = new(big.Int).Set(shardState.Epoch)
+	}
+	if _, err := bc.WriteShardStateBytes(bc.ChainDb(), nextEpoch, pivot.Header().ShardState()); err != nil {
+		return err
+	}
+	return bc.WriteHeadBlock(pivot)
+}
+
+// saveProgress saves the pivot height as the stage progress
+func (stg *StageStateSync) saveProgress(s *StageState, pivotHeight uint64, tx kv.RwTx) (err error) {
+	useInternalTx := tx == nil
+	if useInternalTx {
+		var err error
+		tx, err = stg.configs.db.BeginRw(context.Background())
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+
+	// save progress
+	if err = s.Update(tx, pivotHeight); err != nil {
+		utils.Logger().Error().
+			Err(err).
+			Msgf("[STAGED_SYNC] saving progress for state sync stage failed")
+		return ErrSaveStateSyncProgressFail
+	}
+
+	if useInternalTx {
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (stg *StageStateSync) Revert(firstCycle bool, u *RevertState, s *StageState, tx kv.RwTx) (err error) {
+	useInternalTx := tx == nil
+	if useInternalTx {
+		tx, err = stg.configs.db.BeginRw(stg.configs.ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+
+	if err = u.Done(tx); err != nil {
+		return err
+	}
+
+	if useInternalTx {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (stg *StageStateSync) CleanUp(firstCycle bool, p *CleanUpState, tx kv.RwTx) (err error) {
+	useInternalTx := tx == nil
+	if useInternalTx {
+		tx, err = stg.configs.db.BeginRw(stg.configs.ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+
+	if useInternalTx {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 type SyncStageID string
 
 const (
+	StateSync   SyncStageID = "StateSync"   // state of a pivot block is downloaded to skip executing the blocks before it
 	Heads       SyncStageID = "Heads"       // Heads are downloaded
 	BlockHashes SyncStageID = "BlockHashes" // block hashes are downloaded from peers
 	BlockBodies SyncStageID = "BlockBodies" // Block bodies are downloaded, TxHash and UncleHash are getting verified
@@ -54,6 +54,9 @@ func CreateStagedSync(
 	isExplorer bool,
 	TurboMode bool,
 	UseMemDB bool,
+	stateSync bool,
+	stateSyncProtocol StateSyncProtocol,
+	beacon core.BlockChain,
 	doubleCheckBlockHashes bool,
 	maxBlocksPerCycle uint64,
 	maxBackgroundBlocks uint64,
@@ -92,6 +95,7 @@ func CreateStagedSync(
 		return nil, errInitDB
 	}
 
+	stateSyncCfg := NewStageStateSyncCfg(ctx, bc, beacon, stateSyncProtocol, db, stateSync, logProgress)
 	headsCfg := NewStageHeadersCfg(ctx, bc, db)
 	blockHashesCfg := NewStageBlockHashesCfg(ctx, bc, db, isBeacon, TurboMode, logProgress)
 	bodiesCfg := NewStageBodiesCfg(ctx, bc, db, isBeacon, TurboMode, logProgress)
@@ -100,6 +104,7 @@ func CreateStagedSync(
 	finishCfg := NewStageFinishCfg(ctx, db)
 
 	stages := DefaultStages(ctx,
+		stateSyncCfg,
 		headsCfg,
 		blockHashesCfg,
 		bodiesCfg,
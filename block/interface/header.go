@@ -236,4 +236,13 @@ type Header interface {
 	// SetSlashes sets the RLP-encoded form of slashes
 	// It stores a copy; the caller may freely modify the original.
 	SetSlashes(newSlashes []byte)
+
+	// BaseFee is the EIP-1559 base fee per gas of the block, or nil if the
+	// header predates EIP-1559.
+	// The returned value is a copy; the caller may do anything with it.
+	BaseFee() *big.Int
+
+	// SetBaseFee sets the EIP-1559 base fee per gas of the block.
+	// It stores a copy; the caller may freely modify the original.
+	SetBaseFee(newBaseFee *big.Int)
 }
@@ -395,6 +395,21 @@ func (h *Header) SetSlashes(newSlashes []byte) {
 		Msg("cannot store slashes in V0 header")
 }
 
+// BaseFee is the EIP-1559 base fee, which V0 header does not have.
+func (h *Header) BaseFee() *big.Int {
+	return nil
+}
+
+// SetBaseFee sets the EIP-1559 base fee, which V0 header cannot store.
+func (h *Header) SetBaseFee(newBaseFee *big.Int) {
+	if newBaseFee == nil {
+		return
+	}
+	h.Logger(utils.Logger()).Warn().
+		Str("baseFee", newBaseFee.String()).
+		Msg("cannot store BaseFee in V0 header")
+}
+
 // field type overrides for gencodec
 type headerMarshaling struct {
 	Difficulty *hexutil.Big
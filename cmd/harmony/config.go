@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/harmony-one/harmony/internal/cli"
+	"github.com/harmony-one/harmony/internal/common"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/shardchain/pebble_db"
 	"github.com/pelletier/go-toml"
 	"github.com/spf13/cobra"
 )
@@ -37,6 +39,15 @@ func validateHarmonyConfig(config harmonyconfig.HarmonyConfig) error {
 		return err
 	}
 
+	dbEngine := config.General.DBEngine
+	accepts = []string{dbEngineLevelDB, dbEnginePebble}
+	if err := checkStringAccepted("--run.db-engine", dbEngine, accepts); err != nil {
+		return err
+	}
+	if dbEngine == dbEnginePebble && !pebble_db.Supported {
+		return pebble_db.ErrNotSupported
+	}
+
 	kmsType := config.BLSKeys.KMSConfigSrcType
 	accepts = []string{kmsConfigTypeShared, kmsConfigTypePrompt, kmsConfigTypeFile}
 	if err := checkStringAccepted("--bls.kms.src", kmsType, accepts); err != nil {
@@ -51,7 +62,35 @@ func validateHarmonyConfig(config harmonyconfig.HarmonyConfig) error {
 		return fmt.Errorf("flag --run.offline must have p2p IP be %v", nodeconfig.DefaultLocalListenIP)
 	}
 
-	if !config.Sync.Downloader && !config.DNSSync.Client {
+	if config.Devnet != nil && config.Devnet.ForkURL != "" {
+		accepts = []string{string(nodeconfig.Devnet), string(nodeconfig.Localnet)}
+		if err := checkStringAccepted("--network", string(parsed), accepts); err != nil {
+			return fmt.Errorf("flag --devnet.fork-url: %v", err)
+		}
+		if config.General.RunElasticMode {
+			return errors.New("flag --devnet.fork-url is not supported in elastic mode")
+		}
+	}
+
+	if config.Dev != nil {
+		if config.General.RunElasticMode {
+			return errors.New("flag --dev is not supported in elastic mode")
+		}
+		if config.Dev.Period < 0 {
+			return errors.New("flag --dev.period must not be negative")
+		}
+		if config.Dev.Balance <= 0 {
+			return errors.New("flag --dev.balance must be positive")
+		}
+		for _, account := range config.Dev.Accounts {
+			if _, err := common.ParseAddr(account); err != nil {
+				return fmt.Errorf("flag --dev.accounts: %v", err)
+			}
+		}
+	}
+
+	// A dev node is the whole network, there is nothing to sync from
+	if config.Dev == nil && !config.Sync.Downloader && !config.DNSSync.Client {
 		// There is no module up for sync
 		return errors.New("either --sync.downloader or --sync.legacy.client shall be enabled")
 	}
@@ -316,6 +316,77 @@ func init() {
 		return confTree
 	}
 
+	migrations["2.5.10"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("GraphQL.Enabled") == nil {
+			confTree.Set("GraphQL.Enabled", defaultConfig.GraphQL.Enabled)
+		}
+		if confTree.Get("GraphQL.IP") == nil {
+			confTree.Set("GraphQL.IP", defaultConfig.GraphQL.IP)
+		}
+		if confTree.Get("GraphQL.Port") == nil {
+			confTree.Set("GraphQL.Port", defaultConfig.GraphQL.Port)
+		}
+		confTree.Set("Version", "2.5.11")
+		return confTree
+	}
+
+	migrations["2.5.11"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("IPC.Enabled") == nil {
+			confTree.Set("IPC.Enabled", defaultConfig.IPC.Enabled)
+		}
+		if confTree.Get("IPC.Path") == nil {
+			confTree.Set("IPC.Path", defaultConfig.IPC.Path)
+		}
+		confTree.Set("Version", "2.5.12")
+		return confTree
+	}
+
+	migrations["2.5.12"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RPCOpt.BatchRequestLimit") == nil {
+			confTree.Set("RPCOpt.BatchRequestLimit", defaultConfig.RPCOpt.BatchRequestLimit)
+		}
+		if confTree.Get("RPCOpt.BatchResponseMaxSize") == nil {
+			confTree.Set("RPCOpt.BatchResponseMaxSize", defaultConfig.RPCOpt.BatchResponseMaxSize)
+		}
+		if confTree.Get("RPCOpt.IPRateLimit") == nil {
+			confTree.Set("RPCOpt.IPRateLimit", defaultConfig.RPCOpt.IPRateLimit)
+		}
+		confTree.Set("Version", "2.5.13")
+		return confTree
+	}
+
+	migrations["2.5.13"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("Sync.StagedSyncCfg.StateSync") == nil {
+			confTree.Set("Sync.StagedSyncCfg.StateSync", defaultConfig.Sync.StagedSyncCfg.StateSync)
+		}
+		confTree.Set("Version", "2.5.14")
+		return confTree
+	}
+
+	migrations["2.5.14"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("General.EnablePruneState") == nil {
+			confTree.Set("General.EnablePruneState", defaultConfig.General.EnablePruneState)
+		}
+		confTree.Set("Version", "2.5.15")
+		return confTree
+	}
+
+	migrations["2.5.15"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("General.AncientThreshold") == nil {
+			confTree.Set("General.AncientThreshold", defaultConfig.General.AncientThreshold)
+		}
+		confTree.Set("Version", "2.5.16")
+		return confTree
+	}
+
+	migrations["2.5.16"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("General.DBEngine") == nil {
+			confTree.Set("General.DBEngine", defaultConfig.General.DBEngine)
+		}
+		confTree.Set("Version", "2.5.17")
+		return confTree
+	}
+
 	// check that the latest version here is the same as in default.go
 	largestKey := getNextVersion(migrations)
 	if largestKey != tomlConfigVersion {
@@ -328,15 +399,13 @@ func getNextVersion(x map[string]configMigrationFunc) string {
 	versionMap["Version"] = "FakeVersion"
 	tree, _ := toml.TreeFromMap(versionMap)
 
-	// needs to be sorted in case the order is incorrect
-	keys := make([]string, len(x))
-	i := 0
+	// needs to be sorted by version, "2.5.10" being after "2.5.9"
+	keys := make([]*goversion.Version, 0, len(x))
 	for k := range x {
-		keys[i] = k
-		i++
+		keys = append(keys, goversion.Must(goversion.NewVersion(k)))
 	}
-	sort.Strings(keys)
-	requiredFunc := x[keys[len(keys)-1]]
+	sort.Sort(goversion.Collection(keys))
+	requiredFunc := x[keys[len(keys)-1].Original()]
 	tree = requiredFunc(tree)
 	return tree.Get("Version").(string)
 }
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/pelletier/go-toml"
+
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
@@ -395,3 +397,45 @@ func Test_migrateConf(t *testing.T) {
 		})
 	}
 }
+
+func Test_migrateConfToLatestVersion(t *testing.T) {
+	defConf := getDefaultHmyConfigCopy(nodeconfig.Mainnet)
+	for _, confBytes := range [][]byte{V1_0_2ConfigDefault, V1_0_3ConfigDefault, V1_0_4ConfigDefault} {
+		got, migratedFrom, err := migrateConf(confBytes)
+		if err != nil {
+			t.Fatalf("migrateConf() from %s: %v", migratedFrom, err)
+		}
+		if got.Version != tomlConfigVersion {
+			t.Errorf("migrateConf() from %s: version %s, want %s", migratedFrom, got.Version, tomlConfigVersion)
+		}
+		if !reflect.DeepEqual(got.GraphQL, defConf.GraphQL) {
+			t.Errorf("migrateConf() from %s: GraphQL %+v, want %+v", migratedFrom, got.GraphQL, defConf.GraphQL)
+		}
+		if !reflect.DeepEqual(got.IPC, defConf.IPC) {
+			t.Errorf("migrateConf() from %s: IPC %+v, want %+v", migratedFrom, got.IPC, defConf.IPC)
+		}
+		if !reflect.DeepEqual(got.Sync.StagedSyncCfg, defConf.Sync.StagedSyncCfg) {
+			t.Errorf("migrateConf() from %s: StagedSyncCfg %+v, want %+v", migratedFrom, got.Sync.StagedSyncCfg, defConf.Sync.StagedSyncCfg)
+		}
+	}
+}
+
+func Test_getNextVersion(t *testing.T) {
+	setVersion := func(version string) configMigrationFunc {
+		return func(confTree *toml.Tree) *toml.Tree {
+			confTree.Set("Version", version)
+			return confTree
+		}
+	}
+	x := map[string]configMigrationFunc{
+		"2.5.8":  setVersion("2.5.9"),
+		"2.5.9":  setVersion("2.5.10"),
+		"2.5.10": setVersion("2.5.11"),
+	}
+	if got := getNextVersion(x); got != "2.5.11" {
+		t.Errorf("getNextVersion() = %s, want 2.5.11", got)
+	}
+	if got := getNextVersion(migrations); got != tomlConfigVersion {
+		t.Errorf("getNextVersion() = %s, want %s", got, tomlConfigVersion)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/internal/cli"
+	"github.com/harmony-one/harmony/internal/shardchain"
+	"github.com/harmony-one/harmony/internal/shardchain/pebble_db"
+)
+
+var dbConvertCmd = &cobra.Command{
+	Use:     "dbconvert srcdb destdb",
+	Short:   "convert a leveldb chain db into a pebble db.",
+	Long:    "copy all the entries of a stopped node's leveldb chain db and its ancient store into a new pebble db.",
+	Example: "harmony dbconvert /data/harmony_db_0 /data/harmony_pebble_0",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pebble_db.Supported {
+			fmt.Println(pebble_db.ErrNotSupported)
+			os.Exit(-1)
+		}
+		batchLimitMB := cli.GetIntFlagValue(cmd, batchFlag)
+		if err := convertDB(args[0], args[1], batchLimitMB*MB); err != nil {
+			fmt.Println("convert db error:", err)
+			os.Exit(-1)
+		}
+	},
+}
+
+func registerDBConvertFlags() error {
+	return cli.RegisterFlags(dbConvertCmd, []cli.Flag{batchFlag})
+}
+
+// convertDB copies the leveldb in srcDir into a new pebble db in destDir.
+func convertDB(srcDir, destDir string, batchLimit int) error {
+	srcDB, err := leveldb.New(srcDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+	if err != nil {
+		return fmt.Errorf("open src db: %v", err)
+	}
+	defer srcDB.Close()
+
+	destDB, err := pebble_db.New(destDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES)
+	if err != nil {
+		return fmt.Errorf("open dest db: %v", err)
+	}
+	defer destDB.Close()
+
+	if it := destDB.NewIterator(); it.Next() {
+		it.Release()
+		return fmt.Errorf("dest db %s is not empty", destDir)
+	} else {
+		it.Release()
+	}
+
+	start := time.Now()
+	count, size, err := copyKeyValues(srcDB, destDB, batchLimit)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("copied %d entries (%d bytes) in %v\n", count, size, time.Since(start))
+
+	srcAncient := filepath.Join(srcDir, shardchain.AncientDir)
+	if _, err := os.Stat(srcAncient); err == nil {
+		if err := copyDir(srcAncient, filepath.Join(destDir, shardchain.AncientDir)); err != nil {
+			return fmt.Errorf("copy ancient store: %v", err)
+		}
+		fmt.Println("copied ancient store")
+	}
+	return nil
+}
+
+// copyKeyValues copies all the entries of the src db into the dest db.
+func copyKeyValues(src ethdb.KeyValueStore, dest ethdb.KeyValueStore, batchLimit int) (count, size uint64, err error) {
+	var (
+		it     = src.NewIterator()
+		batch  = dest.NewBatch()
+		logged = time.Now()
+	)
+	defer it.Release()
+
+	for it.Next() {
+		if err := batch.Put(it.Key(), it.Value()); err != nil {
+			return count, size, err
+		}
+		count++
+		size += uint64(len(it.Key()) + len(it.Value()))
+
+		if batch.ValueSize() >= batchLimit {
+			if err := batch.Write(); err != nil {
+				return count, size, err
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > 8*time.Second {
+			fmt.Printf("copied %d entries (%d bytes), at key %x\n", count, size, it.Key())
+			logged = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return count, size, err
+	}
+	return count, size, batch.Write()
+}
+
+// copyDir copies the regular files of the src directory into the dest one.
+func copyDir(src, dest string) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		if err := copyFile(filepath.Join(src, file.Name()), filepath.Join(dest, file.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -5,7 +5,7 @@ import (
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 )
 
-const tomlConfigVersion = "2.5.10"
+const tomlConfigVersion = "2.5.17"
 
 const (
 	defNetworkType = nodeconfig.Mainnet
@@ -22,6 +22,9 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		IsOffline:        false,
 		DataDir:          "./",
 		TraceEnable:      false,
+		EnablePruneState: false,
+		AncientThreshold: 0,
+		DBEngine:         dbEngineLevelDB,
 	},
 	Network: getDefaultNetworkConfig(defNetworkType),
 	P2P: harmonyconfig.P2pConfig{
@@ -48,6 +51,15 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		Port:     nodeconfig.DefaultWSPort,
 		AuthPort: nodeconfig.DefaultAuthWSPort,
 	},
+	GraphQL: harmonyconfig.GraphQLConfig{
+		Enabled: false,
+		IP:      "127.0.0.1",
+		Port:    nodeconfig.DefaultGraphQLPort,
+	},
+	IPC: harmonyconfig.IpcConfig{
+		Enabled: false,
+		Path:    "harmony.ipc",
+	},
 	RPCOpt: harmonyconfig.RpcOptConfig{
 		DebugEnabled:       false,
 		EthRPCsEnabled:     true,
@@ -56,6 +68,10 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		RpcFilterFile:      "./.hmy/rpc_filter.txt",
 		RateLimterEnabled:  true,
 		RequestsPerSecond:  nodeconfig.DefaultRPCRateLimit,
+
+		BatchRequestLimit:    nodeconfig.DefaultRPCBatchRequestLimit,
+		BatchResponseMaxSize: nodeconfig.DefaultRPCBatchResponseMaxSize,
+		IPRateLimit:          0,
 	},
 	BLSKeys: harmonyconfig.BlsConfig{
 		KeyDir:   "./.hmy/blskeys",
@@ -120,6 +136,11 @@ var defaultDevnetConfig = harmonyconfig.DevnetConfig{
 	SlotsLimit:  0, // 0 means no limit
 }
 
+var defaultDevConfig = harmonyconfig.DevConfig{
+	Period:  0,
+	Balance: 10000,
+}
+
 var defaultRevertConfig = harmonyconfig.RevertConfig{
 	RevertBeacon: false,
 	RevertBefore: 0,
@@ -154,6 +175,7 @@ var defaultStagedSyncConfig = harmonyconfig.StagedSyncConfig{
 	VerifyHeaderBatchSize:  100,   // batch size to verify block header before insert to chain
 	MaxMemSyncCycleSize:    1024,  // max number of blocks to use a single transaction for staged sync
 	UseMemDB:               true,  // it uses memory by default. set it to false to use disk
+	StateSync:              false, // download the state of a recent epoch block instead of executing all blocks
 	LogProgress:            false, // log the full sync progress in console
 }
 
@@ -243,6 +265,11 @@ func getDefaultDevnetConfigCopy() harmonyconfig.DevnetConfig {
 	return config
 }
 
+func getDefaultDevConfigCopy() harmonyconfig.DevConfig {
+	config := defaultDevConfig
+	return config
+}
+
 func getDefaultRevertConfigCopy() harmonyconfig.RevertConfig {
 	config := defaultRevertConfig
 	return config
@@ -268,6 +295,11 @@ const (
 	nodeTypeExplorer  = "explorer"
 )
 
+const (
+	dbEngineLevelDB = "leveldb"
+	dbEnginePebble  = "pebble"
+)
+
 const (
 	blsPassTypeAuto   = "auto"
 	blsPassTypeFile   = "file"
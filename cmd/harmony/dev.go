@@ -0,0 +1,120 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/internal/common"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
+	"github.com/harmony-one/harmony/internal/genesis"
+	"github.com/harmony-one/harmony/multibls"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/harmony-one/harmony/shard"
+)
+
+// numDevAccounts is the number of default dev accounts, funded when no
+// account is configured.
+const numDevAccounts = 10
+
+// applyDevConfig overrides the config of a dev mode node: a single offline
+// archival validator of a localnet chain, which has nothing to sync from.
+func applyDevConfig(config *harmonyconfig.HarmonyConfig) {
+	if config.Dev == nil {
+		return
+	}
+	config.Network = getDefaultNetworkConfig(nodeconfig.Localnet)
+	config.Network.BootNodes = nil
+	config.General.NodeType = nodeTypeValidator
+	config.General.ShardID = int(shard.BeaconChainShardID)
+	config.General.IsOffline = true
+	config.General.IsArchival = true
+	config.General.IsBeaconArchival = true
+	config.P2P.IP = nodeconfig.DefaultLocalListenIP
+	config.Sync.Enabled = false
+	config.Sync.Downloader = false
+	config.DNSSync.Client = false
+	config.DNSSync.Server = false
+}
+
+// devBLSKey returns the deterministic consensus key of the dev mode node, the
+// only member of the committee.
+func devBLSKey() *bls_core.SecretKey {
+	key := &bls_core.SecretKey{}
+	if err := key.SetLittleEndian(crypto.Keccak256([]byte("harmony dev validator"))); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// devAccountKey returns the deterministic private key of the i-th default dev account.
+func devAccountKey(i int) *ecdsa.PrivateKey {
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("harmony dev account " + strconv.Itoa(i))))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// setupDevNode sets the consensus key and the sharding schedule of a dev mode
+// node. The schedule is fixed to a single shard with the dev key as its only
+// member, so the chain never leaves the genesis epoch and the localnet
+// features scheduled at later epochs stay disabled.
+func setupDevNode() error {
+	key := devBLSKey()
+	onceLoadBLSKey.Do(func() {
+		multiBLSPriKey = multibls.GetPrivateKeys(key)
+	})
+	pubKey := key.GetPublicKey()
+	account := genesis.DeployAccount{
+		Index:        "0",
+		Address:      common.MustAddressToBech32(pubKey.GetAddress()),
+		BLSPublicKey: pubKey.SerializeToHexStr(),
+		ShardID:      shard.BeaconChainShardID,
+	}
+	instance, err := shardingconfig.NewInstance(
+		1, 1, 1, 0, numeric.OneDec(), []genesis.DeployAccount{account}, nil, shardingconfig.Allowlist{}, ethCommon.Address{}, nil, shardingconfig.VLBPE)
+	if err != nil {
+		return err
+	}
+	shard.Schedule = shardingconfig.NewFixedSchedule(instance)
+	return nil
+}
+
+// devGenesisAlloc returns the accounts funded in the genesis block of a dev
+// mode node, printing the keys of the default dev accounts.
+func devGenesisAlloc(hc harmonyconfig.HarmonyConfig) (core.GenesisAlloc, error) {
+	if hc.Dev == nil {
+		return nil, nil
+	}
+	balance := new(big.Int).Mul(big.NewInt(int64(hc.Dev.Balance)), big.NewInt(denominations.One))
+	alloc := make(core.GenesisAlloc)
+	if len(hc.Dev.Accounts) == 0 {
+		fmt.Printf("Dev accounts (%v ONE each):\n", hc.Dev.Balance)
+		for i := 0; i < numDevAccounts; i++ {
+			key := devAccountKey(i)
+			addr := crypto.PubkeyToAddress(key.PublicKey)
+			fmt.Printf("(%d) %s %s private key 0x%x\n",
+				i, addr.Hex(), common.MustAddressToBech32(addr), crypto.FromECDSA(key))
+			alloc[addr] = core.GenesisAccount{Balance: balance}
+		}
+		return alloc, nil
+	}
+	for _, raw := range hc.Dev.Accounts {
+		addr, err := common.ParseAddr(raw)
+		if err != nil {
+			return nil, err
+		}
+		alloc[addr] = core.GenesisAccount{Balance: balance}
+	}
+	return alloc, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+)
+
+func TestDevConfig(t *testing.T) {
+	config := getDefaultHmyConfigCopy(nodeconfig.Mainnet)
+	dev := getDefaultDevConfigCopy()
+	config.Dev = &dev
+	applyDevConfig(&config)
+	if err := validateHarmonyConfig(config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Network.NetworkType != nodeconfig.Localnet || !config.General.IsOffline || config.Sync.Enabled {
+		t.Errorf("unexpected dev config: network %v, offline %v, sync %v",
+			config.Network.NetworkType, config.General.IsOffline, config.Sync.Enabled)
+	}
+
+	// The default dev accounts are funded
+	alloc, err := devGenesisAlloc(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alloc) != numDevAccounts {
+		t.Errorf("unexpected dev accounts: %v / %v", len(alloc), numDevAccounts)
+	}
+	balance := new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+	for addr, account := range alloc {
+		if account.Balance.Cmp(balance) != 0 {
+			t.Errorf("unexpected balance of %x: %v", addr, account.Balance)
+		}
+	}
+
+	// The configured accounts replace them
+	dev.Accounts = []string{"0x1111111111111111111111111111111111111111", "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"}
+	dev.Balance = 1
+	alloc, err = devGenesisAlloc(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alloc) != 2 || alloc[common.HexToAddress("0x1111111111111111111111111111111111111111")].Balance.Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("unexpected dev allocation: %v", alloc)
+	}
+
+	dev.Accounts = []string{"not an address"}
+	if err := validateHarmonyConfig(config); err == nil {
+		t.Errorf("invalid dev account accepted")
+	}
+}
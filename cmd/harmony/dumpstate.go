@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/internal/cli"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+var (
+	dumpStateBlockFlag = cli.IntFlag{
+		Name:     "block",
+		Usage:    "number of the block whose state is dumped, the head block if negative",
+		DefValue: -1,
+	}
+	dumpStateAllocFlag = cli.StringFlag{
+		Name:     "alloc",
+		Usage:    "also write the state as a genesis allocation to the given file",
+		DefValue: "",
+	}
+)
+
+var dumpStateCmd = &cobra.Command{
+	Use:   "dumpstate dbdir file",
+	Short: "dump the state of a stopped node's db to json.",
+	Long: "write the accounts of the state at a block, with their code, storage and validator wrapper, " +
+		"to a json file, and optionally as a genesis allocation to fork the state into a local network.",
+	Example: "harmony dumpstate /data/harmony_db_0 state.json --block 100000 --alloc alloc.json",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		db, err := ethRawDB.NewLevelDBDatabase(args[0], LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+		if err != nil {
+			fmt.Println("open db error:", err)
+			os.Exit(-1)
+		}
+		defer db.Close()
+
+		number := uint64(cli.GetIntFlagValue(cmd, dumpStateBlockFlag))
+		if cli.GetIntFlagValue(cmd, dumpStateBlockFlag) < 0 {
+			headNumber := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadBlockHash(db))
+			if headNumber == nil {
+				fmt.Println("empty head block")
+				os.Exit(-1)
+			}
+			number = *headNumber
+		}
+		header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, number), number)
+		if header == nil {
+			fmt.Println("block not found:", number)
+			os.Exit(-1)
+		}
+		stateDB, err := state.New(header.Root(), state.NewDatabaseWithCache(db, STATEDB_CACHE_SIZE))
+		if err != nil {
+			fmt.Println("open state error:", err)
+			os.Exit(-1)
+		}
+		fmt.Printf("dumping state %x of block %d\n", header.Root(), number)
+
+		start := time.Now()
+		accounts, err := dumpState(stateDB, header.Root(), number, args[1], cli.GetStringFlagValue(cmd, dumpStateAllocFlag))
+		if err != nil {
+			fmt.Println("dump state error:", err)
+			os.Exit(-1)
+		}
+		fmt.Printf("dumped %d accounts in %v\n", accounts, time.Since(start))
+	},
+}
+
+func registerDumpStateFlags() error {
+	return cli.RegisterFlags(dumpStateCmd, []cli.Flag{dumpStateBlockFlag, dumpStateAllocFlag})
+}
+
+// dumpedAccount is the json record of an account in the state dump.
+type dumpedAccount struct {
+	state.DumpAccount
+	Validator *staking.ValidatorWrapper `json:"validator,omitempty"`
+}
+
+// dumpState writes the state to the json file, and to the genesis allocation
+// file if given. It returns the number of dumped accounts.
+func dumpState(stateDB *state.DB, root common.Hash, number uint64, fn, allocFn string) (int, error) {
+	out, err := newJSONObjectFile(fn, fmt.Sprintf(`{"root":"%s","block":%d,"accounts":`, root.Hex(), number), "}")
+	if err != nil {
+		return 0, err
+	}
+	defer out.file.Close()
+
+	dumper := &stateDumper{state: stateDB, out: out}
+	if allocFn != "" {
+		if dumper.alloc, err = newJSONObjectFile(allocFn, "", ""); err != nil {
+			return 0, err
+		}
+		defer dumper.alloc.file.Close()
+	}
+	stateDB.DumpToCollector(dumper, &state.DumpConfig{OnlyWithAddresses: true})
+	if dumper.err != nil {
+		return dumper.accounts, dumper.err
+	}
+	if dumper.missing > 0 {
+		fmt.Printf("%d storage slots skipped for missing key preimages\n", dumper.missing)
+	}
+	if err := out.close(); err != nil {
+		return dumper.accounts, err
+	}
+	if dumper.alloc != nil {
+		return dumper.accounts, dumper.alloc.close()
+	}
+	return dumper.accounts, nil
+}
+
+// stateDumper is a state.DumpCollector writing each account to the json dump
+// and to the genesis allocation as the state trie is walked.
+type stateDumper struct {
+	state *state.DB
+	out   *jsonObjectFile
+	alloc *jsonObjectFile // nil if no genesis allocation is written
+
+	storage  map[common.Hash]common.Hash
+	accounts int
+	missing  int // number of storage slots without key preimage
+	err      error
+}
+
+// OnRoot implements state.DumpCollector.
+func (d *stateDumper) OnRoot(common.Hash) {}
+
+// OnAccountStart implements state.DumpCollector.
+func (d *stateDumper) OnAccountStart(common.Address, state.DumpAccount) {
+	d.storage = make(map[common.Hash]common.Hash)
+}
+
+// OnAccountState implements state.DumpCollector.
+func (d *stateDumper) OnAccountState(_ common.Address, _ hexutil.Bytes, key, value []byte) {
+	if key == nil {
+		d.missing++
+		return
+	}
+	_, content, _, err := rlp.Split(value)
+	if err != nil {
+		d.fail(errors.Wrapf(err, "decode storage slot %x", key))
+		return
+	}
+	d.storage[common.BytesToHash(key)] = common.BytesToHash(content)
+}
+
+// OnAccountEnd implements state.DumpCollector.
+func (d *stateDumper) OnAccountEnd(addr common.Address, account state.DumpAccount) {
+	if d.err != nil {
+		return
+	}
+	dumped := dumpedAccount{DumpAccount: account}
+	dumped.SecureKey = nil
+	if len(d.storage) > 0 {
+		dumped.Storage = make(map[common.Hash]string, len(d.storage))
+		for key, value := range d.storage {
+			dumped.Storage[key] = common.Bytes2Hex(value.Bytes())
+		}
+	}
+	if d.state.IsValidator(addr) {
+		wrapper, err := d.state.ValidatorWrapper(addr, true, false)
+		if err != nil {
+			d.fail(err)
+			return
+		}
+		dumped.Validator = wrapper
+	}
+	if err := d.out.writeField(addr.Hex(), &dumped); err != nil {
+		d.fail(err)
+		return
+	}
+	if d.alloc != nil {
+		balance, ok := new(big.Int).SetString(account.Balance, 10)
+		if !ok {
+			d.fail(errors.Errorf("invalid balance %s of account %x", account.Balance, addr))
+			return
+		}
+		genesisAccount := core.GenesisAccount{
+			Code:    account.Code,
+			Storage: d.storage,
+			Balance: balance,
+			Nonce:   account.Nonce,
+		}
+		if err := d.alloc.writeField(addr.Hex(), &genesisAccount); err != nil {
+			d.fail(err)
+			return
+		}
+	}
+	d.accounts++
+}
+
+// fail keeps the first error of the dump, the state walk itself cannot be
+// interrupted.
+func (d *stateDumper) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// jsonObjectFile writes a json object to a file field by field, so that a whole
+// state is never held in memory.
+type jsonObjectFile struct {
+	file   *os.File
+	w      *bufio.Writer
+	suffix string
+	fields int
+}
+
+// newJSONObjectFile creates the file and opens a json object after the prefix.
+// The suffix is written after the object is closed.
+func newJSONObjectFile(fn, prefix, suffix string) (*jsonObjectFile, error) {
+	file, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	f := &jsonObjectFile{file: file, w: bufio.NewWriter(file), suffix: suffix}
+	if _, err := io.WriteString(f.w, prefix+"{"); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *jsonObjectFile) writeField(key string, value interface{}) error {
+	blob, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if f.fields == 0 {
+		sep = "\n"
+	}
+	if _, err := fmt.Fprintf(f.w, "%s%q:%s", sep, key, blob); err != nil {
+		return err
+	}
+	f.fields++
+	return nil
+}
+
+func (f *jsonObjectFile) close() error {
+	if _, err := io.WriteString(f.w, "\n}"+f.suffix+"\n"); err != nil {
+		return err
+	}
+	if err := f.w.Flush(); err != nil {
+		return err
+	}
+	return f.file.Close()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+)
+
+func TestDumpState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dumpstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		db       = ethRawDB.NewMemoryDatabase()
+		sdb      = state.NewDatabase(db)
+		account1 = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		account2 = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		slot     = common.HexToHash("0x01")
+	)
+	statedb, _ := state.New(common.Hash{}, sdb)
+	statedb.SetBalance(account1, big.NewInt(100))
+	statedb.SetNonce(account1, 3)
+	statedb.SetBalance(account2, big.NewInt(200))
+	statedb.SetCode(account2, []byte{0x60, 0x00})
+	statedb.SetState(account2, slot, common.HexToHash("0x2a"))
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+
+	statedb, _ = state.New(root, sdb)
+	dumpFn, allocFn := filepath.Join(dir, "state.json"), filepath.Join(dir, "alloc.json")
+	accounts, err := dumpState(statedb, root, 10, dumpFn, allocFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accounts != 2 {
+		t.Errorf("unexpected dumped accounts: %v / %v", accounts, 2)
+	}
+
+	blob, err := ioutil.ReadFile(dumpFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dump struct {
+		Root     common.Hash                      `json:"root"`
+		Block    uint64                           `json:"block"`
+		Accounts map[common.Address]dumpedAccount `json:"accounts"`
+	}
+	if err := json.Unmarshal(blob, &dump); err != nil {
+		t.Fatal(err)
+	}
+	if dump.Root != root || dump.Block != 10 {
+		t.Errorf("unexpected dump of block %v root %x", dump.Block, dump.Root)
+	}
+	if got := dump.Accounts[account1]; got.Balance != "100" || got.Nonce != 3 {
+		t.Errorf("unexpected account %x: %+v", account1, got)
+	}
+	if got := dump.Accounts[account2].Storage[slot]; got != common.Bytes2Hex(common.HexToHash("0x2a").Bytes()) {
+		t.Errorf("unexpected storage of account %x: %v", account2, got)
+	}
+
+	// the genesis allocation rebuilds the same state
+	alloc, err := core.ReadGenesisAlloc(allocFn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forked, _ := state.New(common.Hash{}, state.NewDatabase(ethRawDB.NewMemoryDatabase()))
+	for addr, account := range alloc {
+		forked.AddBalance(addr, account.Balance)
+		forked.SetCode(addr, account.Code)
+		forked.SetNonce(addr, account.Nonce)
+		for key, value := range account.Storage {
+			forked.SetState(addr, key, value)
+		}
+	}
+	if got := forked.IntermediateRoot(false); got != root {
+		t.Errorf("unexpected root of the genesis allocation: %x / %x", got, root)
+	}
+}
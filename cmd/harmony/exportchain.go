@@ -0,0 +1,214 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/cli"
+	"github.com/harmony-one/harmony/internal/shardchain"
+	"github.com/harmony-one/harmony/shard"
+)
+
+var (
+	chainShardFlag = cli.IntFlag{
+		Name:     "shard",
+		Usage:    "shard of the exported or imported chain",
+		DefValue: 0,
+	}
+	exportFirstFlag = cli.IntFlag{
+		Name:     "first",
+		Usage:    "first block number exported",
+		DefValue: 0,
+	}
+	exportLastFlag = cli.IntFlag{
+		Name:     "last",
+		Usage:    "last block number exported, the current head if negative",
+		DefValue: -1,
+	}
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export datadir file",
+	Short: "export the blocks of a stopped node's chain into a file.",
+	Long: "write the canonical blocks of a block range, with their cross-links, incoming cross shard receipts " +
+		"and commit signatures, to a gzipped RLP file.",
+	Example: "harmony export /data/db shard0.rlp.gz --shard 0 --first 0 --last 100000 --network mainnet",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		collection, bc := openChain(cmd, args[0])
+		defer collection.Close()
+
+		first := cli.GetIntFlagValue(cmd, exportFirstFlag)
+		last := cli.GetIntFlagValue(cmd, exportLastFlag)
+		if last < 0 {
+			last = int(bc.CurrentBlock().NumberU64())
+		}
+		if first < 0 || first > last {
+			fmt.Println("invalid block range", first, last)
+			os.Exit(-1)
+		}
+
+		start := time.Now()
+		if err := exportChain(bc, args[1], uint64(first), uint64(last)); err != nil {
+			fmt.Println("export chain error:", err)
+			os.Exit(-1)
+		}
+		fmt.Printf("exported blocks %d to %d in %v\n", first, last, time.Since(start))
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import datadir file",
+	Short: "import the blocks of an exported file into a stopped node's chain.",
+	Long: "insert the blocks of a file written by the export command with the full verification of the block " +
+		"headers, signatures and states. The blocks of a shard chain are verified against the beacon chain " +
+		"of the same datadir.",
+	Example: "harmony import /data/db shard0.rlp.gz --shard 0 --network mainnet",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		collection, bc := openChain(cmd, args[0])
+		defer collection.Close()
+
+		start := time.Now()
+		imported, err := importChain(bc, args[1])
+		if err != nil {
+			fmt.Println("import chain error:", err)
+			os.Exit(-1)
+		}
+		fmt.Printf("imported %d blocks in %v, head block %d\n", imported, time.Since(start), bc.CurrentBlock().NumberU64())
+	},
+}
+
+func registerExportFlags() error {
+	return cli.RegisterFlags(exportCmd, []cli.Flag{chainShardFlag, exportFirstFlag, exportLastFlag, networkTypeFlag})
+}
+
+func registerImportFlags() error {
+	return cli.RegisterFlags(importCmd, []cli.Flag{chainShardFlag, networkTypeFlag})
+}
+
+// openChain opens the chain of the shard in the datadir of a node, along with
+// the beacon chain a shard chain is verified against.
+func openChain(cmd *cobra.Command, dataDir string) (*shardchain.CollectionImpl, core.BlockChain) {
+	networkType := getNetworkType(cmd)
+	schedule := getShardSchedule(networkType)
+	if schedule == nil {
+		fmt.Println("unsupported network type")
+		os.Exit(-1)
+	}
+	shard.Schedule = schedule
+	shardID := uint32(cli.GetIntFlagValue(cmd, chainShardFlag))
+
+	chainConfig := networkType.ChainConfig()
+	collection := shardchain.NewCollection(
+		nil, &shardchain.LDBFactory{RootDir: dataDir}, &core.GenesisInitializer{NetworkType: networkType},
+		chain.NewEngine(), &chainConfig,
+	)
+	if shardID != shard.BeaconChainShardID {
+		if _, err := collection.ShardChain(shard.BeaconChainShardID); err != nil {
+			fmt.Println("open beacon chain error:", err)
+			os.Exit(-1)
+		}
+	}
+	bc, err := collection.ShardChain(shardID)
+	if err != nil {
+		fmt.Println("open chain error:", err)
+		os.Exit(-1)
+	}
+	return collection, bc
+}
+
+// exportChain writes the blocks of the range to the gzipped file.
+func exportChain(bc core.BlockChain, fn string, first, last uint64) error {
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	writer := gzip.NewWriter(fh)
+	if err := bc.ExportN(writer, first, last); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return fh.Close()
+}
+
+// importChain inserts the blocks of the gzipped file one by one, each verified
+// against its parent already in the chain. The known blocks are skipped, so an
+// interrupted import can be resumed from the same file.
+func importChain(bc core.BlockChain, fn string) (int, error) {
+	fh, err := os.Open(fn)
+	if err != nil {
+		return 0, err
+	}
+	defer fh.Close()
+
+	reader, err := gzip.NewReader(fh)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	var (
+		stream   = rlp.NewStream(reader, 0)
+		imported int
+		logged   = time.Now()
+	)
+	for {
+		var exported core.ExportedBlock
+		if err := stream.Decode(&exported); err == io.EOF {
+			break
+		} else if err != nil {
+			return imported, errors.Wrapf(err, "decode block after %d imported", imported)
+		}
+		block := exported.Block
+		if block.NumberU64() == 0 {
+			if genesis := bc.GetHeaderByNumber(0); block.Hash() != genesis.Hash() {
+				return imported, errors.Errorf("genesis mismatch: %x / %x", block.Hash(), genesis.Hash())
+			}
+			continue
+		}
+		if bc.HasBlock(block.Hash(), block.NumberU64()) {
+			continue
+		}
+		if err := verifyAndInsertExportedBlock(bc, block, exported.CommitSigAndBitmap); err != nil {
+			return imported, errors.Wrapf(err, "import block %d", block.NumberU64())
+		}
+		imported++
+
+		if time.Since(logged) > 8*time.Second {
+			fmt.Printf("imported %d blocks, at block %d\n", imported, block.NumberU64())
+			logged = time.Now()
+		}
+	}
+	return imported, nil
+}
+
+// verifyAndInsertExportedBlock verifies the commit signature signed on the block
+// before inserting it, and keeps the signature for the following blocks.
+func verifyAndInsertExportedBlock(bc core.BlockChain, block *types.Block, sigAndBitmap []byte) error {
+	sig, bitmap, err := chain.ParseCommitSigAndBitmap(sigAndBitmap)
+	if err != nil {
+		return errors.Wrap(err, "parse commitSigAndBitmap")
+	}
+	if err := bc.Engine().VerifyHeaderSignature(bc, block.Header(), sig, bitmap); err != nil {
+		return errors.Wrap(err, "[VerifyHeaderSignature]")
+	}
+	if _, err := bc.InsertChain(types.Blocks{block}, true); err != nil {
+		return errors.Wrap(err, "[InsertChain]")
+	}
+	return bc.WriteCommitSig(block.NumberU64(), sigAndBitmap)
+}
@@ -23,6 +23,9 @@ var (
 		isBeaconArchiveFlag,
 		isOfflineFlag,
 		dataDirFlag,
+		pruneStateFlag,
+		ancientThresholdFlag,
+		dbEngineFlag,
 
 		legacyNodeTypeFlag,
 		legacyIsStakingFlag,
@@ -81,6 +84,17 @@ var (
 		wsAuthPortFlag,
 	}
 
+	graphqlFlags = []cli.Flag{
+		graphqlEnabledFlag,
+		graphqlIPFlag,
+		graphqlPortFlag,
+	}
+
+	ipcFlags = []cli.Flag{
+		ipcEnabledFlag,
+		ipcPathFlag,
+	}
+
 	rpcOptFlags = []cli.Flag{
 		rpcDebugEnabledFlag,
 		rpcEthRPCsEnabledFlag,
@@ -89,6 +103,10 @@ var (
 		rpcFilterFileFlag,
 		rpcRateLimiterEnabledFlag,
 		rpcRateLimitFlag,
+		rpcBatchRequestLimitFlag,
+		rpcBatchResponseMaxSizeFlag,
+		rpcIPRateLimitFlag,
+		rpcTrustedProxiesFlag,
 	}
 
 	blsFlags = append(newBLSFlags, legacyBLSFlags...)
@@ -176,6 +194,8 @@ var (
 		devnetNumShardsFlag,
 		devnetShardSizeFlag,
 		devnetHmyNodeSizeFlag,
+		devnetForkURLFlag,
+		devnetForkBlockFlag,
 	}
 
 	legacyDevnetFlags = []cli.Flag{
@@ -184,6 +204,17 @@ var (
 		legacyDevnetHmyNodeSizeFlag,
 	}
 
+	devFlags = []cli.Flag{
+		devFlag,
+		devPeriodFlag,
+		devAccountsFlag,
+		devBalanceFlag,
+	}
+
+	explorerFlags = []cli.Flag{
+		explorerSolcFlag,
+	}
+
 	revertFlags = append(newRevertFlags, legacyRevertFlags...)
 
 	newRevertFlags = []cli.Flag{
@@ -274,6 +305,21 @@ var (
 		Usage:    "run node in offline mode",
 		DefValue: defaultConfig.General.IsOffline,
 	}
+	pruneStateFlag = cli.BoolFlag{
+		Name:     "run.prune-state",
+		Usage:    "prune the states older than the last blocks and the staking lock epochs in non-archive mode",
+		DefValue: defaultConfig.General.EnablePruneState,
+	}
+	ancientThresholdFlag = cli.IntFlag{
+		Name:     "run.ancient-threshold",
+		Usage:    "move the blocks older than the given number of blocks behind the head to the ancient store (0 to disable)",
+		DefValue: int(defaultConfig.General.AncientThreshold),
+	}
+	dbEngineFlag = cli.StringFlag{
+		Name:     "run.db-engine",
+		Usage:    "key-value store of the chain databases (leveldb, pebble)",
+		DefValue: defaultConfig.General.DBEngine,
+	}
 	isBackupFlag = cli.BoolFlag{
 		Name:     "run.backup",
 		Usage:    "run node in backup mode",
@@ -333,6 +379,8 @@ func getRootFlags() []cli.Flag {
 	flags = append(flags, p2pFlags...)
 	flags = append(flags, httpFlags...)
 	flags = append(flags, wsFlags...)
+	flags = append(flags, graphqlFlags...)
+	flags = append(flags, ipcFlags...)
 	flags = append(flags, rpcOptFlags...)
 	flags = append(flags, blsFlags...)
 	flags = append(flags, consensusFlags...)
@@ -341,6 +389,8 @@ func getRootFlags() []cli.Flag {
 	flags = append(flags, logFlags...)
 	flags = append(flags, sysFlags...)
 	flags = append(flags, devnetFlags...)
+	flags = append(flags, devFlags...)
+	flags = append(flags, explorerFlags...)
 	flags = append(flags, revertFlags...)
 	flags = append(flags, legacyMiscFlags...)
 	flags = append(flags, prometheusFlags...)
@@ -390,6 +440,22 @@ func applyGeneralFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig)
 		config.General.IsOffline = cli.GetBoolFlagValue(cmd, isOfflineFlag)
 	}
 
+	if cli.IsFlagChanged(cmd, pruneStateFlag) {
+		config.General.EnablePruneState = cli.GetBoolFlagValue(cmd, pruneStateFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, ancientThresholdFlag) {
+		value := cli.GetIntFlagValue(cmd, ancientThresholdFlag)
+		if value < 0 {
+			panic("Must provide non-negative value for run.ancient-threshold")
+		}
+		config.General.AncientThreshold = uint64(value)
+	}
+
+	if cli.IsFlagChanged(cmd, dbEngineFlag) {
+		config.General.DBEngine = cli.GetStringFlagValue(cmd, dbEngineFlag)
+	}
+
 	if cli.IsFlagChanged(cmd, taraceFlag) {
 		config.General.TraceEnable = cli.GetBoolFlagValue(cmd, taraceFlag)
 	}
@@ -739,6 +805,69 @@ func applyWSFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	}
 }
 
+// graphql flags
+var (
+	graphqlEnabledFlag = cli.BoolFlag{
+		Name:     "graphql",
+		Usage:    "enable GraphQL endpoint",
+		DefValue: defaultConfig.GraphQL.Enabled,
+	}
+	graphqlIPFlag = cli.StringFlag{
+		Name:     "graphql.ip",
+		Usage:    "ip address to listen for GraphQL queries. Use 0.0.0.0 for public endpoint",
+		DefValue: defaultConfig.GraphQL.IP,
+	}
+	graphqlPortFlag = cli.IntFlag{
+		Name:     "graphql.port",
+		Usage:    "port to listen for GraphQL queries",
+		DefValue: defaultConfig.GraphQL.Port,
+	}
+)
+
+func applyGraphQLFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	var isGraphQLSpecified bool
+
+	if cli.IsFlagChanged(cmd, graphqlIPFlag) {
+		config.GraphQL.IP = cli.GetStringFlagValue(cmd, graphqlIPFlag)
+		isGraphQLSpecified = true
+	}
+
+	if cli.IsFlagChanged(cmd, graphqlPortFlag) {
+		config.GraphQL.Port = cli.GetIntFlagValue(cmd, graphqlPortFlag)
+		isGraphQLSpecified = true
+	}
+
+	if cli.IsFlagChanged(cmd, graphqlEnabledFlag) {
+		config.GraphQL.Enabled = cli.GetBoolFlagValue(cmd, graphqlEnabledFlag)
+	} else if isGraphQLSpecified {
+		config.GraphQL.Enabled = true
+	}
+}
+
+// ipc flags
+var (
+	ipcEnabledFlag = cli.BoolFlag{
+		Name:     "ipc",
+		Usage:    "enable IPC endpoint serving all RPC APIs, including the debug APIs",
+		DefValue: defaultConfig.IPC.Enabled,
+	}
+	ipcPathFlag = cli.StringFlag{
+		Name:     "ipc.path",
+		Usage:    "path of the IPC socket, relative to the data directory if not absolute",
+		DefValue: defaultConfig.IPC.Path,
+	}
+)
+
+func applyIPCFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, ipcPathFlag) {
+		config.IPC.Path = cli.GetStringFlagValue(cmd, ipcPathFlag)
+		config.IPC.Enabled = true
+	}
+	if cli.IsFlagChanged(cmd, ipcEnabledFlag) {
+		config.IPC.Enabled = cli.GetBoolFlagValue(cmd, ipcEnabledFlag)
+	}
+}
+
 // rpc opt flags
 var (
 	rpcDebugEnabledFlag = cli.BoolFlag{
@@ -787,6 +916,29 @@ var (
 		Usage:    "the number of requests per second for RPCs",
 		DefValue: defaultConfig.RPCOpt.RequestsPerSecond,
 	}
+
+	rpcBatchRequestLimitFlag = cli.IntFlag{
+		Name:     "rpc.batch-request-limit",
+		Usage:    "the maximum number of requests in a batch, 0 for no limit",
+		DefValue: defaultConfig.RPCOpt.BatchRequestLimit,
+	}
+
+	rpcBatchResponseMaxSizeFlag = cli.IntFlag{
+		Name:     "rpc.batch-response-max-size",
+		Usage:    "the maximum size in bytes of the results of a batch, 0 for no limit",
+		DefValue: defaultConfig.RPCOpt.BatchResponseMaxSize,
+	}
+
+	rpcIPRateLimitFlag = cli.IntFlag{
+		Name:     "rpc.ip-ratelimit",
+		Usage:    "the number of requests per second for RPCs from each client IP, 0 for no limit",
+		DefValue: defaultConfig.RPCOpt.IPRateLimit,
+	}
+
+	rpcTrustedProxiesFlag = cli.StringSliceFlag{
+		Name:  "rpc.trusted-proxies",
+		Usage: "CIDRs of the reverse proxies trusted to report the client IP (delimited by ,)",
+	}
 )
 
 func applyRPCOptFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
@@ -811,6 +963,18 @@ func applyRPCOptFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, rpcRateLimitFlag) {
 		config.RPCOpt.RequestsPerSecond = cli.GetIntFlagValue(cmd, rpcRateLimitFlag)
 	}
+	if cli.IsFlagChanged(cmd, rpcBatchRequestLimitFlag) {
+		config.RPCOpt.BatchRequestLimit = cli.GetIntFlagValue(cmd, rpcBatchRequestLimitFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcBatchResponseMaxSizeFlag) {
+		config.RPCOpt.BatchResponseMaxSize = cli.GetIntFlagValue(cmd, rpcBatchResponseMaxSizeFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcIPRateLimitFlag) {
+		config.RPCOpt.IPRateLimit = cli.GetIntFlagValue(cmd, rpcIPRateLimitFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcTrustedProxiesFlag) {
+		config.RPCOpt.TrustedProxies = cli.GetStringSliceFlagValue(cmd, rpcTrustedProxiesFlag)
+	}
 
 }
 
@@ -1382,6 +1546,16 @@ var (
 		DefValue: defaultDevnetConfig.HmyNodeSize,
 		Hidden:   true,
 	}
+	devnetForkURLFlag = cli.StringFlag{
+		Name:     "devnet.fork-url",
+		Usage:    "RPC endpoint of a shard 0 node the beacon chain state missing locally is lazily fetched from",
+		DefValue: defaultDevnetConfig.ForkURL,
+	}
+	devnetForkBlockFlag = cli.IntFlag{
+		Name:     "devnet.fork-block",
+		Usage:    "upstream block number the forked state is pinned at (0 means the latest upstream block)",
+		DefValue: int(defaultDevnetConfig.ForkBlock),
+	}
 	legacyDevnetNumShardsFlag = cli.IntFlag{
 		Name:       "dn_num_shards",
 		Usage:      "number of shards for -network_type=devnet",
@@ -1418,6 +1592,12 @@ func applyDevnetFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		if cli.IsFlagChanged(cmd, devnetHmyNodeSizeFlag) {
 			config.Devnet.HmyNodeSize = cli.GetIntFlagValue(cmd, devnetHmyNodeSizeFlag)
 		}
+		if cli.IsFlagChanged(cmd, devnetForkURLFlag) {
+			config.Devnet.ForkURL = cli.GetStringFlagValue(cmd, devnetForkURLFlag)
+		}
+		if cli.IsFlagChanged(cmd, devnetForkBlockFlag) {
+			config.Devnet.ForkBlock = uint64(cli.GetIntFlagValue(cmd, devnetForkBlockFlag))
+		}
 	}
 	if cli.HasFlagsChanged(cmd, legacyDevnetFlags) {
 		if cli.IsFlagChanged(cmd, legacyDevnetNumShardsFlag) {
@@ -1438,6 +1618,64 @@ func applyDevnetFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	}
 }
 
+var (
+	devFlag = cli.BoolFlag{
+		Name:     "dev",
+		Usage:    "run a single node localnet sealing blocks without consensus, for contract development",
+		DefValue: false,
+	}
+	devPeriodFlag = cli.IntFlag{
+		Name:     "dev.period",
+		Usage:    "seconds between the blocks sealed in dev mode (0 seals a block as soon as a transaction is pending)",
+		DefValue: defaultDevConfig.Period,
+	}
+	devAccountsFlag = cli.StringSliceFlag{
+		Name:  "dev.accounts",
+		Usage: "accounts funded in the genesis block in dev mode, the printed dev accounts if unset (delimited by ,)",
+	}
+	devBalanceFlag = cli.IntFlag{
+		Name:     "dev.balance",
+		Usage:    "ONE funded in each dev mode account",
+		DefValue: defaultDevConfig.Balance,
+	}
+)
+
+func applyDevFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, devFlag) && !cli.GetBoolFlagValue(cmd, devFlag) {
+		config.Dev = nil
+		return
+	}
+	if cli.HasFlagsChanged(cmd, devFlags) && config.Dev == nil {
+		cfg := getDefaultDevConfigCopy()
+		config.Dev = &cfg
+	}
+
+	if cli.IsFlagChanged(cmd, devPeriodFlag) {
+		config.Dev.Period = cli.GetIntFlagValue(cmd, devPeriodFlag)
+	}
+	if cli.IsFlagChanged(cmd, devAccountsFlag) {
+		config.Dev.Accounts = cli.GetStringSliceFlagValue(cmd, devAccountsFlag)
+	}
+	if cli.IsFlagChanged(cmd, devBalanceFlag) {
+		config.Dev.Balance = cli.GetIntFlagValue(cmd, devBalanceFlag)
+	}
+}
+
+var explorerSolcFlag = cli.StringFlag{
+	Name:     "explorer.solc",
+	Usage:    "path of the solc binary compiling the contract sources verified by the explorer node",
+	DefValue: "",
+}
+
+func applyExplorerFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, explorerSolcFlag) {
+		if config.Explorer == nil {
+			config.Explorer = &harmonyconfig.ExplorerConfig{}
+		}
+		config.Explorer.SolcPath = cli.GetStringFlagValue(cmd, explorerSolcFlag)
+	}
+}
+
 var (
 	revertBeaconFlag = cli.BoolFlag{
 		Name:     "revert.beacon",
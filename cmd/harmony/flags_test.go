@@ -40,6 +40,7 @@ func TestHarmonyFlags(t *testing.T) {
 					ShardID:    -1,
 					IsArchival: false,
 					DataDir:    "./",
+					DBEngine:   "leveldb",
 				},
 				Network: harmonyconfig.NetworkConfig{
 					NetworkType: "mainnet",
@@ -76,13 +77,16 @@ func TestHarmonyFlags(t *testing.T) {
 					RosettaPort:    9700,
 				},
 				RPCOpt: harmonyconfig.RpcOptConfig{
-					DebugEnabled:       false,
-					EthRPCsEnabled:     true,
-					StakingRPCsEnabled: true,
-					LegacyRPCsEnabled:  true,
-					RpcFilterFile:      "./.hmy/rpc_filter.txt",
-					RateLimterEnabled:  true,
-					RequestsPerSecond:  1000,
+					DebugEnabled:         false,
+					EthRPCsEnabled:       true,
+					StakingRPCsEnabled:   true,
+					LegacyRPCsEnabled:    true,
+					RpcFilterFile:        "./.hmy/rpc_filter.txt",
+					RateLimterEnabled:    true,
+					RequestsPerSecond:    1000,
+					BatchRequestLimit:    1000,
+					BatchResponseMaxSize: 25000000,
+					IPRateLimit:          0,
 				},
 				WS: harmonyconfig.WsConfig{
 					Enabled:  true,
@@ -90,6 +94,15 @@ func TestHarmonyFlags(t *testing.T) {
 					Port:     9800,
 					AuthPort: 9801,
 				},
+				GraphQL: harmonyconfig.GraphQLConfig{
+					Enabled: false,
+					IP:      "127.0.0.1",
+					Port:    9600,
+				},
+				IPC: harmonyconfig.IpcConfig{
+					Enabled: false,
+					Path:    "harmony.ipc",
+				},
 				Consensus: &harmonyconfig.ConsensusConfig{
 					MinPeers:     6,
 					AggregateSig: true,
@@ -189,6 +202,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    -1,
 				IsArchival: false,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -200,6 +214,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    0,
 				IsArchival: true,
 				DataDir:    "./.hmy",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -211,6 +226,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    0,
 				IsArchival: true,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -221,6 +237,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    -1,
 				IsArchival: false,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -231,6 +248,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    0,
 				IsArchival: false,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -241,6 +259,42 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    0,
 				IsArchival: false,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
+			},
+		},
+		{
+			args: []string{"--run", "explorer", "--run.shard", "0", "--run.prune-state"},
+			expConfig: harmonyconfig.GeneralConfig{
+				NodeType:         "explorer",
+				NoStaking:        false,
+				ShardID:          0,
+				IsArchival:       false,
+				DataDir:          "./",
+				DBEngine:         "leveldb",
+				EnablePruneState: true,
+			},
+		},
+		{
+			args: []string{"--run", "explorer", "--run.shard", "0", "--run.ancient-threshold", "90000"},
+			expConfig: harmonyconfig.GeneralConfig{
+				NodeType:         "explorer",
+				NoStaking:        false,
+				ShardID:          0,
+				IsArchival:       false,
+				DataDir:          "./",
+				DBEngine:         "leveldb",
+				AncientThreshold: 90000,
+			},
+		},
+		{
+			args: []string{"--run", "explorer", "--run.shard", "0", "--run.db-engine", "pebble"},
+			expConfig: harmonyconfig.GeneralConfig{
+				NodeType:   "explorer",
+				NoStaking:  false,
+				ShardID:    0,
+				IsArchival: false,
+				DataDir:    "./",
+				DBEngine:   "pebble",
 			},
 		},
 	}
@@ -624,6 +678,111 @@ func TestWSFlags(t *testing.T) {
 	}
 }
 
+func TestGraphQLFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.GraphQLConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultConfig.GraphQL,
+		},
+		{
+			args: []string{"--graphql"},
+			expConfig: harmonyconfig.GraphQLConfig{
+				Enabled: true,
+				IP:      defaultConfig.GraphQL.IP,
+				Port:    defaultConfig.GraphQL.Port,
+			},
+		},
+		{
+			args: []string{"--graphql.ip", "8.8.8.8", "--graphql.port", "9001"},
+			expConfig: harmonyconfig.GraphQLConfig{
+				Enabled: true,
+				IP:      "8.8.8.8",
+				Port:    9001,
+			},
+		},
+		{
+			args: []string{"--graphql=false", "--graphql.port", "9001"},
+			expConfig: harmonyconfig.GraphQLConfig{
+				Enabled: false,
+				IP:      defaultConfig.GraphQL.IP,
+				Port:    9001,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, graphqlFlags, applyGraphQLFlags)
+
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(hc.GraphQL, test.expConfig) {
+			t.Errorf("Test %v: \n\t%+v\n\t%+v", i, hc.GraphQL, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
+func TestIPCFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.IpcConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultConfig.IPC,
+		},
+		{
+			args: []string{"--ipc"},
+			expConfig: harmonyconfig.IpcConfig{
+				Enabled: true,
+				Path:    defaultConfig.IPC.Path,
+			},
+		},
+		{
+			args: []string{"--ipc.path", "/tmp/harmony.ipc"},
+			expConfig: harmonyconfig.IpcConfig{
+				Enabled: true,
+				Path:    "/tmp/harmony.ipc",
+			},
+		},
+		{
+			args: []string{"--ipc=false", "--ipc.path", "/tmp/harmony.ipc"},
+			expConfig: harmonyconfig.IpcConfig{
+				Enabled: false,
+				Path:    "/tmp/harmony.ipc",
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, ipcFlags, applyIPCFlags)
+
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(hc.IPC, test.expConfig) {
+			t.Errorf("Test %v: \n\t%+v\n\t%+v", i, hc.IPC, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
 func TestRPCOptFlags(t *testing.T) {
 	tests := []struct {
 		args      []string
@@ -632,104 +791,161 @@ func TestRPCOptFlags(t *testing.T) {
 		{
 			args: []string{"--rpc.debug"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       true,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
+				DebugEnabled:         true,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
 			},
 		},
 
 		{
 			args: []string{"--rpc.eth=false"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     false,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       false,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
 			},
 		},
 
 		{
 			args: []string{"--rpc.staking=false"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: false,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   false,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
 			},
 		},
 
 		{
 			args: []string{"--rpc.legacy=false"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  false,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    false,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
 			},
 		},
 
 		{
 			args: []string{"--rpc.filterspath=./rmf.toml"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./rmf.toml",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./rmf.toml",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
 			},
 		},
 
 		{
 			args: []string{},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
 			},
 		},
 
 		{
 			args: []string{"--rpc.ratelimiter", "--rpc.ratelimit", "2000"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  2000,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    2000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
 			},
 		},
 
 		{
 			args: []string{"--rpc.ratelimiter=false", "--rpc.ratelimit", "2000"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  false,
-				RequestsPerSecond:  2000,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    false,
+				RequestsPerSecond:    2000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
+			},
+		},
+
+		{
+			args: []string{"--rpc.batch-request-limit", "10", "--rpc.batch-response-max-size", "1000000", "--rpc.ip-ratelimit", "50"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				BatchRequestLimit:    10,
+				BatchResponseMaxSize: 1000000,
+				IPRateLimit:          50,
+			},
+		},
+
+		{
+			args: []string{"--rpc.trusted-proxies", "127.0.0.1/32,10.0.0.0/8"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				BatchRequestLimit:    1000,
+				BatchResponseMaxSize: 25000000,
+				IPRateLimit:          0,
+				TrustedProxies:       []string{"127.0.0.1/32", "10.0.0.0/8"},
 			},
 		},
 	}
@@ -1199,6 +1415,16 @@ func TestDevnetFlags(t *testing.T) {
 				HmyNodeSize: 60,
 			},
 		},
+		{
+			args: []string{"--devnet.fork-url", "http://localhost:9500", "--devnet.fork-block", "100"},
+			expConfig: &harmonyconfig.DevnetConfig{
+				NumShards:   2,
+				ShardSize:   10,
+				HmyNodeSize: 10,
+				ForkURL:     "http://localhost:9500",
+				ForkBlock:   100,
+			},
+		},
 		{
 			args: []string{"--dn_num_shards", "3", "--dn_shard_size", "100", "--dn_hmy_size",
 				"60"},
@@ -1227,6 +1453,84 @@ func TestDevnetFlags(t *testing.T) {
 	}
 }
 
+func TestDevFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig *harmonyconfig.DevConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: nil,
+		},
+		{
+			args: []string{"--dev"},
+			expConfig: &harmonyconfig.DevConfig{
+				Period:  0,
+				Balance: 10000,
+			},
+		},
+		{
+			args: []string{"--dev.period", "5", "--dev.balance", "100", "--dev.accounts",
+				"0x1111111111111111111111111111111111111111,one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"},
+			expConfig: &harmonyconfig.DevConfig{
+				Period:   5,
+				Accounts: []string{"0x1111111111111111111111111111111111111111", "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"},
+				Balance:  100,
+			},
+		},
+		{
+			args:      []string{"--dev=false", "--dev.period", "5"},
+			expConfig: nil,
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, devFlags, applyDevFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(hc.Dev, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Dev, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
+func TestExplorerFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig *harmonyconfig.ExplorerConfig
+	}{
+		{
+			args:      []string{},
+			expConfig: nil,
+		},
+		{
+			args: []string{"--explorer.solc", "/usr/local/bin/solc"},
+			expConfig: &harmonyconfig.ExplorerConfig{
+				SolcPath: "/usr/local/bin/solc",
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, explorerFlags, applyExplorerFlags)
+		hc, err := ts.run(test.args)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if !reflect.DeepEqual(hc.Explorer, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Explorer, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
 func TestRevertFlags(t *testing.T) {
 	tests := []struct {
 		args      []string
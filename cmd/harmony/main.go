@@ -110,6 +110,11 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(dumpConfigLegacyCmd)
 	rootCmd.AddCommand(dumpDBCmd)
+	rootCmd.AddCommand(dumpStateCmd)
+	rootCmd.AddCommand(pruneStateCmd)
+	rootCmd.AddCommand(dbConvertCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
 
 	if err := registerRootCmdFlags(); err != nil {
 		os.Exit(2)
@@ -120,6 +125,21 @@ func init() {
 	if err := registerDumpDBFlags(); err != nil {
 		os.Exit(2)
 	}
+	if err := registerDumpStateFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerPruneStateFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerDBConvertFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerExportFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerImportFlags(); err != nil {
+		os.Exit(2)
+	}
 }
 
 func main() {
@@ -214,6 +234,7 @@ func getHarmonyConfig(cmd *cobra.Command) (harmonyconfig.HarmonyConfig, error) {
 	}
 
 	applyRootFlags(cmd, &config)
+	applyDevConfig(&config)
 
 	if err := validateHarmonyConfig(config); err != nil {
 		return harmonyconfig.HarmonyConfig{}, err
@@ -232,6 +253,8 @@ func applyRootFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	applyP2PFlags(cmd, config)
 	applyHTTPFlags(cmd, config)
 	applyWSFlags(cmd, config)
+	applyGraphQLFlags(cmd, config)
+	applyIPCFlags(cmd, config)
 	applyRPCOptFlags(cmd, config)
 	applyBLSFlags(cmd, config)
 	applyConsensusFlags(cmd, config)
@@ -240,6 +263,8 @@ func applyRootFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	applyLogFlags(cmd, config)
 	applySysFlags(cmd, config)
 	applyDevnetFlags(cmd, config)
+	applyDevFlags(cmd, config)
+	applyExplorerFlags(cmd, config)
 	applyRevertFlags(cmd, config)
 	applyPrometheusFlags(cmd, config)
 	applySyncFlags(cmd, config)
@@ -266,6 +291,12 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 	var err error
 
 	nodeconfigSetShardSchedule(hc)
+	if hc.Dev != nil {
+		if err := setupDevNode(); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR cannot set up dev mode: %s\n", err)
+			os.Exit(1)
+		}
+	}
 	nodeconfig.SetShardingSchedule(shard.Schedule)
 	nodeconfig.SetVersion(getHarmonyVersion())
 
@@ -333,6 +364,10 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 	}
 
 	// Parse RPC config
+	ipcPath := hc.IPC.Path
+	if !filepath.IsAbs(ipcPath) {
+		ipcPath = filepath.Join(hc.General.DataDir, ipcPath)
+	}
 	nodeConfig.RPCServer = nodeconfig.RPCServerConfig{
 		HTTPEnabled:        hc.HTTP.Enabled,
 		HTTPIp:             hc.HTTP.IP,
@@ -342,6 +377,11 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 		WSIp:               hc.WS.IP,
 		WSPort:             hc.WS.Port,
 		WSAuthPort:         hc.WS.AuthPort,
+		GraphQLEnabled:     hc.GraphQL.Enabled,
+		GraphQLIp:          hc.GraphQL.IP,
+		GraphQLPort:        hc.GraphQL.Port,
+		IPCEnabled:         hc.IPC.Enabled,
+		IPCPath:            ipcPath,
 		DebugEnabled:       hc.RPCOpt.DebugEnabled,
 		EthRPCsEnabled:     hc.RPCOpt.EthRPCsEnabled,
 		StakingRPCsEnabled: hc.RPCOpt.StakingRPCsEnabled,
@@ -417,7 +457,9 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 	if hc.Sync.Enabled {
 		setupSyncService(currentNode, myHost, hc)
 	}
-	if currentNode.NodeConfig.Role() == nodeconfig.Validator {
+	if hc.Dev != nil {
+		currentNode.RegisterDevServices(time.Duration(hc.Dev.Period) * time.Second)
+	} else if currentNode.NodeConfig.Role() == nodeconfig.Validator {
 		currentNode.RegisterValidatorServices()
 	} else if currentNode.NodeConfig.Role() == nodeconfig.ExplorerNode {
 		currentNode.RegisterExplorerServices()
@@ -604,6 +646,7 @@ func createGlobalConfig(hc harmonyconfig.HarmonyConfig) (*nodeconfig.ConfigType,
 	nodeConfig.StagedSync = hc.Sync.StagedSync
 	nodeConfig.StagedSyncTurboMode = hc.Sync.StagedSyncCfg.TurboMode
 	nodeConfig.UseMemDB = hc.Sync.StagedSyncCfg.UseMemDB
+	nodeConfig.StagedSyncStateSync = hc.Sync.StagedSyncCfg.StateSync
 	nodeConfig.DoubleCheckBlockHashes = hc.Sync.StagedSyncCfg.DoubleCheckBlockHashes
 	nodeConfig.MaxBlocksPerSyncCycle = hc.Sync.StagedSyncCfg.MaxBlocksPerSyncCycle
 	nodeConfig.MaxBackgroundBlocks = hc.Sync.StagedSyncCfg.MaxBackgroundBlocks
@@ -690,6 +733,9 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 	var chainDBFactory shardchain.DBFactory
 	if hc.General.RunElasticMode {
 		chainDBFactory = setupTiKV(hc)
+	} else if hc.Dev != nil {
+		// A dev chain starts from its genesis block on each run
+		chainDBFactory = &shardchain.MemDBFactory{}
 	} else if hc.ShardData.EnableShardData {
 		chainDBFactory = &shardchain.LDBShardFactory{
 			RootDir:    nodeConfig.DBDir,
@@ -698,15 +744,28 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 			CacheTime:  hc.ShardData.CacheTime,
 			CacheSize:  hc.ShardData.CacheSize,
 		}
+	} else if hc.General.DBEngine == dbEnginePebble {
+		chainDBFactory = &shardchain.PebbleFactory{
+			RootDir:          nodeConfig.DBDir,
+			AncientThreshold: hc.General.AncientThreshold,
+		}
 	} else {
-		chainDBFactory = &shardchain.LDBFactory{RootDir: nodeConfig.DBDir}
+		chainDBFactory = &shardchain.LDBFactory{
+			RootDir:          nodeConfig.DBDir,
+			AncientThreshold: hc.General.AncientThreshold,
+		}
 	}
 
 	engine := chain.NewEngine()
 
+	devAlloc, err := devGenesisAlloc(hc)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error :%v \n", err)
+		os.Exit(1)
+	}
 	chainConfig := nodeConfig.GetNetworkType().ChainConfig()
 	collection := shardchain.NewCollection(
-		&hc, chainDBFactory, &core.GenesisInitializer{NetworkType: nodeConfig.GetNetworkType()}, engine, &chainConfig,
+		&hc, chainDBFactory, &core.GenesisInitializer{NetworkType: nodeConfig.GetNetworkType(), Alloc: devAlloc}, engine, &chainConfig,
 	)
 	for shardID, archival := range nodeConfig.ArchiveModes() {
 		if archival {
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state/pruner"
+	"github.com/harmony-one/harmony/internal/cli"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+var (
+	pruneBloomSizeFlag = cli.IntFlag{
+		Name:     "bloom",
+		Usage:    "size of the bloom filter marking the retained states in MB",
+		DefValue: pruner.DefaultBloomSize,
+	}
+	pruneRetainBlocksFlag = cli.IntFlag{
+		Name:     "retain.blocks",
+		Usage:    "number of block states retained behind the head",
+		DefValue: 128,
+	}
+	pruneRetainEpochsFlag = cli.IntFlag{
+		Name:     "retain.epochs",
+		Usage:    "number of epoch block states retained behind the head",
+		DefValue: staking.LockPeriodInEpoch,
+	}
+)
+
+var pruneStateCmd = &cobra.Command{
+	Use:     "prune-state dbdir",
+	Short:   "prune the old states of a stopped node's db.",
+	Long:    "delete the trie nodes and contract codes which are only part of the states older than the retained ones.",
+	Example: "harmony prune-state /data/harmony_db_0 --network mainnet",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		networkType := getNetworkType(cmd)
+		schedule := getShardSchedule(networkType)
+		if schedule == nil {
+			fmt.Println("unsupported network type")
+			os.Exit(-1)
+		}
+		bloomSize := cli.GetIntFlagValue(cmd, pruneBloomSizeFlag)
+		blocks := cli.GetIntFlagValue(cmd, pruneRetainBlocksFlag)
+		epochs := cli.GetIntFlagValue(cmd, pruneRetainEpochsFlag)
+		if bloomSize <= 0 || blocks < 0 || epochs < 0 {
+			fmt.Println("invalid bloom size or number of retained states")
+			os.Exit(-1)
+		}
+
+		db, err := ethRawDB.NewLevelDBDatabase(args[0], LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+		if err != nil {
+			fmt.Println("open db error:", err)
+			os.Exit(-1)
+		}
+		defer db.Close()
+
+		headHash := rawdb.ReadHeadBlockHash(db)
+		headNumber := rawdb.ReadHeaderNumber(db, headHash)
+		if headNumber == nil {
+			fmt.Println("empty head block")
+			os.Exit(-1)
+		}
+		head := rawdb.ReadHeader(db, headHash, *headNumber)
+		if head == nil {
+			fmt.Println("empty head block")
+			os.Exit(-1)
+		}
+		roots := core.RetainedStateRoots(db, head, schedule, uint64(blocks), uint64(epochs))
+		fmt.Printf("pruning states before block %d, retaining %d states\n", *headNumber, len(roots))
+
+		start := time.Now()
+		if err := pruner.NewPruner(db, uint64(bloomSize)).Prune(trie.NewDatabase(db), roots); err != nil {
+			fmt.Println("prune state error:", err)
+			os.Exit(-1)
+		}
+		fmt.Println("compacting db")
+		if err := db.Compact(nil, nil); err != nil {
+			fmt.Println("compact db error:", err)
+			os.Exit(-1)
+		}
+		fmt.Println("pruned state in", time.Since(start))
+	},
+}
+
+func registerPruneStateFlags() error {
+	return cli.RegisterFlags(pruneStateCmd, []cli.Flag{
+		pruneBloomSizeFlag, pruneRetainBlocksFlag, pruneRetainEpochsFlag, networkTypeFlag,
+	})
+}
@@ -0,0 +1,82 @@
+package misc
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+var (
+	// ErrMissingBaseFee is returned if a London header does not carry a base fee.
+	ErrMissingBaseFee = errors.New("header is missing baseFee")
+	// ErrInvalidBaseFee is returned if the base fee of a header does not match
+	// the one computed from its parent.
+	ErrInvalidBaseFee = errors.New("invalid baseFee")
+)
+
+// VerifyEIP1559Header verifies the EIP-1559 base fee of the header against
+// its parent. Headers before the London epoch are not checked.
+func VerifyEIP1559Header(config *params.ChainConfig, parent, header *block.Header) error {
+	if !config.IsLondon(header.Epoch()) {
+		return nil
+	}
+	baseFee := header.BaseFee()
+	if baseFee == nil {
+		return ErrMissingBaseFee
+	}
+	expected := CalcBaseFee(config, parent)
+	if baseFee.Cmp(expected) != 0 {
+		return errors.WithMessagef(ErrInvalidBaseFee,
+			"have %s, want %s, parentBaseFee %v, parentGasUsed %d",
+			baseFee, expected, parent.BaseFee(), parent.GasUsed())
+	}
+	return nil
+}
+
+// CalcBaseFee calculates the base fee of the header following the parent.
+//
+// The first London block uses params.InitialBaseFee. Afterwards, the base fee
+// moves by at most 1/BaseFeeChangeDenominator per block towards the gas target
+// (half of the gas limit), and never falls below params.InitialBaseFee.
+func CalcBaseFee(config *params.ChainConfig, parent *block.Header) *big.Int {
+	minBaseFee := new(big.Int).SetUint64(params.InitialBaseFee)
+	parentBaseFee := parent.BaseFee()
+	if !config.IsLondon(parent.Epoch()) || parentBaseFee == nil {
+		return minBaseFee
+	}
+
+	parentGasTarget := parent.GasLimit() / params.ElasticityMultiplier
+	parentGasUsed := parent.GasUsed()
+	if parentGasTarget == 0 || parentGasUsed == parentGasTarget {
+		return parentBaseFee
+	}
+
+	denominator := new(big.Int).SetUint64(params.BaseFeeChangeDenominator)
+	target := new(big.Int).SetUint64(parentGasTarget)
+	baseFee := new(big.Int)
+	if parentGasUsed > parentGasTarget {
+		// max(1, parentBaseFee * gasUsedDelta / parentGasTarget / denominator)
+		delta := new(big.Int).SetUint64(parentGasUsed - parentGasTarget)
+		delta.Mul(delta, parentBaseFee)
+		delta.Div(delta, target)
+		delta.Div(delta, denominator)
+		if delta.Sign() == 0 {
+			delta.SetUint64(1)
+		}
+		baseFee.Add(parentBaseFee, delta)
+	} else {
+		// parentBaseFee * gasUsedDelta / parentGasTarget / denominator
+		delta := new(big.Int).SetUint64(parentGasTarget - parentGasUsed)
+		delta.Mul(delta, parentBaseFee)
+		delta.Div(delta, target)
+		delta.Div(delta, denominator)
+		baseFee.Sub(parentBaseFee, delta)
+	}
+	if baseFee.Cmp(minBaseFee) < 0 {
+		return minBaseFee
+	}
+	return baseFee
+}
@@ -0,0 +1,69 @@
+package misc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+func TestCalcBaseFee(t *testing.T) {
+	config := params.AllProtocolChanges
+	factory := blockfactory.NewFactory(config)
+	initial := new(big.Int).SetUint64(params.InitialBaseFee)
+
+	tests := []struct {
+		parentBaseFee *big.Int
+		parentGasUsed uint64
+		expected      *big.Int
+	}{
+		// usage == target
+		{big.NewInt(2 * 100e9), 40000000, big.NewInt(2 * 100e9)},
+		// usage above target
+		{big.NewInt(2 * 100e9), 80000000, big.NewInt(225e9)},
+		// usage below target
+		{big.NewInt(2 * 100e9), 0, big.NewInt(175e9)},
+		// never below the initial base fee
+		{initial, 0, initial},
+		// parent without base fee
+		{nil, 40000000, initial},
+	}
+	for i, test := range tests {
+		parentFactory := factory
+		if test.parentBaseFee == nil {
+			// a pre-London parent cannot carry a base fee
+			parentFactory = blockfactory.ForTest
+		}
+		parent := parentFactory.NewHeader(common.Big0).With().
+			GasLimit(80000000).
+			GasUsed(test.parentGasUsed).
+			BaseFee(test.parentBaseFee).
+			Header()
+		if have := CalcBaseFee(config, parent); have.Cmp(test.expected) != 0 {
+			t.Errorf("test %d: have %d, want %d", i, have, test.expected)
+		}
+	}
+}
+
+func TestVerifyEIP1559Header(t *testing.T) {
+	config := params.AllProtocolChanges
+	factory := blockfactory.NewFactory(config)
+
+	parent := factory.NewHeader(common.Big0).With().
+		GasLimit(80000000).
+		GasUsed(80000000).
+		BaseFee(big.NewInt(2 * 100e9)).
+		Header()
+	header := factory.NewHeader(common.Big0).With().
+		BaseFee(CalcBaseFee(config, parent)).
+		Header()
+	if err := VerifyEIP1559Header(config, parent, header); err != nil {
+		t.Errorf("expected valid header, got %v", err)
+	}
+	header.SetBaseFee(big.NewInt(2 * 100e9))
+	if err := VerifyEIP1559Header(config, parent, header); err == nil {
+		t.Error("expected invalid base fee error")
+	}
+}
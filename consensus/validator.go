@@ -134,6 +134,9 @@ func (consensus *Consensus) validateNewBlock(recvMsg *FBFTMessage) (*types.Block
 
 	if err := consensus.VerifyBlock(&blockObj); err != nil {
 		consensus.getLogger().Error().Err(err).Msg("[validateNewBlock] Block verification failed")
+		if len(recvMsg.SenderPubkeys) > 0 {
+			consensus.Blockchain().ReportBadBlockPeer(blockObj.Hash(), recvMsg.SenderPubkeys[0].Bytes.Hex())
+		}
 		return nil, errors.New("Block verification failed")
 	}
 	return &blockObj, nil
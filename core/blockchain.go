@@ -1,6 +1,7 @@
 package core
 
 import (
+	"io"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -11,6 +12,7 @@ import (
 	"github.com/harmony-one/harmony/consensus/reward"
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/pruner"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
@@ -25,6 +27,10 @@ import (
 type Options struct {
 	// Subset of blockchain suitable for storing last epoch blocks i.e. blocks with shard state.
 	EpochChain bool
+	// StatePruner prunes the states older than the retained ones after each epoch
+	// block of a non-archival chain. The state cache of the chain must write its
+	// trie nodes through the database of the pruner.
+	StatePruner *pruner.Pruner
 }
 
 // BlockChain represents the canonical chain given a database with a genesis
@@ -62,6 +68,11 @@ type BlockChain interface {
 	State() (*state.DB, error)
 	// StateAt returns a new mutable state based on a particular point in time.
 	StateAt(root common.Hash) (*state.DB, error)
+	// StateCache returns the caching database underpinning the blockchain instance.
+	StateCache() state.Database
+	// TrieNode retrieves a blob of data associated with a trie node (or code hash)
+	// either from ephemeral in-memory cache, or from persistent storage.
+	TrieNode(hash common.Hash) ([]byte, error)
 	// HasBlock checks if a block is fully present in the database or not.
 	HasBlock(hash common.Hash, number uint64) bool
 	// HasState checks if state trie is fully present in the database or not.
@@ -106,9 +117,18 @@ type BlockChain interface {
 	//
 	// After insertion is done, all accumulated events will be fired.
 	InsertChain(chain types.Blocks, verifyHeaders bool) (int, error)
+	// Export writes the active chain to the given writer.
+	Export(w io.Writer) error
+	// ExportN writes a subset of the active chain to the given writer, each
+	// block along with the commit signature signed on it.
+	ExportN(w io.Writer, first uint64, last uint64) error
 	// BadBlocks returns a list of the last 'bad blocks' that
 	// the client has seen on the network.
 	BadBlocks() []BadBlock
+	// BadBlock returns the bad block with the given hash, if it was seen.
+	BadBlock(hash common.Hash) (BadBlock, bool)
+	// ReportBadBlockPeer records the peer a bad block was received from.
+	ReportBadBlockPeer(hash common.Hash, peer string)
 	// CurrentHeader retrieves the current head header of the canonical chain. The
 	// header is retrieved from the HeaderChain's internal cache.
 	CurrentHeader() *block.Header
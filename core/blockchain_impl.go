@@ -45,6 +45,7 @@ import (
 	"github.com/harmony-one/harmony/consensus/votepower"
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
@@ -100,7 +101,6 @@ const (
 	receiptsCacheLimit                 = 32
 	maxFutureBlocks                    = 16
 	maxTimeFutureBlocks                = 30
-	badBlockLimit                      = 10
 	triesInMemory                      = 128
 	triesInRedis                       = 1000
 	shardCacheLimit                    = 10
@@ -125,6 +125,19 @@ type CacheConfig struct {
 	Disabled      bool          // Whether to disable trie write caching (archive node)
 	TrieNodeLimit int           // Memory limit (MB) at which to flush the current in-memory trie to disk
 	TrieTimeLimit time.Duration // Time limit after which to flush the current in-memory trie to disk
+	SnapshotLimit int           // Memory allowance (MB) to use for caching snapshot entries in memory, zero disables the snapshot
+}
+
+// DefaultSnapshotLimit is the default memory allowance (MB) of the flat state snapshot.
+const DefaultSnapshotLimit = 256
+
+// DefaultCacheConfig returns the cache configuration of the non-archival nodes.
+func DefaultCacheConfig() *CacheConfig {
+	return &CacheConfig{
+		TrieNodeLimit: 256 * 1024 * 1024,
+		TrieTimeLimit: 2 * time.Minute,
+		SnapshotLimit: DefaultSnapshotLimit,
+	}
 }
 
 type BlockChainImpl struct {
@@ -166,6 +179,7 @@ type BlockChainImpl struct {
 	currentFastBlock atomic.Value // Current head of the fast-sync chain (may be above the block chain!)
 
 	stateCache                    state.Database // State database to reuse between imports (contains state cache)
+	snaps                         *snapshot.Tree // Flat state snapshot for fast state reads, nil if disabled
 	bodyCache                     *lru.Cache     // Cache for the most recent block bodies
 	bodyRLPCache                  *lru.Cache     // Cache for the most recent block bodies in RLP encoded format
 	receiptsCache                 *lru.Cache     // Cache for the most recent receipts per block
@@ -191,7 +205,7 @@ type BlockChainImpl struct {
 	processor              Processor // block processor interface
 	validator              Validator // block and state validator interface
 	vmConfig               vm.Config
-	badBlocks              *lru.Cache // Bad block cache
+	badBlocksMu            sync.Mutex // serializes the updates of the stored bad blocks
 	pendingSlashes         slash.Records
 	maxGarbCollectedBlkNum int64
 
@@ -221,17 +235,13 @@ func newBlockChainWithOptions(
 	cacheConfig *CacheConfig, chainConfig *params.ChainConfig,
 	engine consensus_engine.Engine, vmConfig vm.Config, options Options) (*BlockChainImpl, error) {
 	if cacheConfig == nil {
-		cacheConfig = &CacheConfig{
-			TrieNodeLimit: 256 * 1024 * 1024,
-			TrieTimeLimit: 2 * time.Minute,
-		}
+		cacheConfig = DefaultCacheConfig()
 	}
 	bodyCache, _ := lru.New(bodyCacheLimit)
 	bodyRLPCache, _ := lru.New(bodyCacheLimit)
 	receiptsCache, _ := lru.New(receiptsCacheLimit)
 	blockCache, _ := lru.New(blockCacheLimit)
 	futureBlocks, _ := lru.New(maxFutureBlocks)
-	badBlocks, _ := lru.New(badBlockLimit)
 	shardCache, _ := lru.New(shardCacheLimit)
 	commitsCache, _ := lru.New(commitsCacheLimit)
 	epochCache, _ := lru.New(epochCacheLimit)
@@ -268,7 +278,6 @@ func newBlockChainWithOptions(
 		blockchainPruner:              newBlockchainPruner(db),
 		engine:                        engine,
 		vmConfig:                      vmConfig,
-		badBlocks:                     badBlocks,
 		pendingSlashes:                slash.Records{},
 		maxGarbCollectedBlkNum:        -1,
 		options:                       options,
@@ -290,6 +299,11 @@ func newBlockChainWithOptions(
 		return nil, err
 	}
 	bc.shardID = bc.CurrentBlock().ShardID()
+	// Load any existing snapshot, regenerating it if loading failed
+	if cacheConfig.SnapshotLimit > 0 {
+		bc.snaps = snapshot.New(db, stateCache.TrieDB(), cacheConfig.SnapshotLimit, bc.CurrentBlock().Root(), true)
+		stateCache.SetSnapshots(bc.snaps)
+	}
 	if beaconChain == nil && bc.shardID == shard.BeaconChainShardID {
 		beaconChain = bc
 	}
@@ -599,6 +613,12 @@ func (bc *BlockChainImpl) SetHead(head uint64) error {
 	if err := bc.hc.SetHead(head, delFn); err != nil {
 		return errors.Wrap(err, "headerChain SetHeader")
 	}
+	// The frozen blocks above the new head are discarded from the ancient store
+	if frozen, err := bc.db.Ancients(); err == nil && frozen > head+1 {
+		if err := bc.db.TruncateAncients(head + 1); err != nil {
+			return errors.Wrap(err, "truncate ancients")
+		}
+	}
 	currentHeader := bc.hc.CurrentHeader()
 
 	// Clear out any stale content from the caches
@@ -622,6 +642,10 @@ func (bc *BlockChainImpl) SetHead(head uint64) error {
 			headBlockGauge.Update(int64(bc.genesisBlock.NumberU64()))
 		}
 	}
+	// The snapshot layers cannot be rewound, regenerate it from the new head
+	if bc.snaps != nil {
+		bc.snaps.Rebuild(bc.CurrentBlock().Root())
+	}
 	// Rewind the fast block in a simpleton way to the target head
 	if currentFastBlock := bc.CurrentFastBlock(); currentFastBlock != nil && currentHeader.Number().Uint64() < currentFastBlock.NumberU64() {
 		newHeadFastBlock := bc.GetBlock(currentHeader.Hash(), currentHeader.Number().Uint64())
@@ -696,6 +720,11 @@ func (bc *BlockChainImpl) StateAt(root common.Hash) (*state.DB, error) {
 	return state.New(root, bc.stateCache)
 }
 
+// StateCache returns the caching database underpinning the blockchain instance.
+func (bc *BlockChainImpl) StateCache() state.Database {
+	return bc.stateCache
+}
+
 func (bc *BlockChainImpl) Reset() error {
 	return bc.ResetWithGenesisBlock(bc.genesisBlock)
 }
@@ -795,6 +824,14 @@ func (bc *BlockChainImpl) Export(w io.Writer) error {
 	return bc.ExportN(w, uint64(0), bc.CurrentBlock().NumberU64())
 }
 
+// ExportedBlock is the record of a block written by ExportN, the block along
+// with the commit signature and bitmap signed on it, so that it can be verified
+// when imported.
+type ExportedBlock struct {
+	Block              *types.Block
+	CommitSigAndBitmap []byte
+}
+
 // ExportN writes a subset of the active chain to the given writer.
 func (bc *BlockChainImpl) ExportN(w io.Writer, first uint64, last uint64) error {
 	bc.mu.RLock()
@@ -811,7 +848,11 @@ func (bc *BlockChainImpl) ExportN(w io.Writer, first uint64, last uint64) error
 		if block == nil {
 			return fmt.Errorf("export failed on #%d: not found", nr)
 		}
-		if err := block.EncodeRLP(w); err != nil {
+		sigAndBitmap, err := bc.commitSigOf(block)
+		if err != nil {
+			return fmt.Errorf("export failed on #%d: %v", nr, err)
+		}
+		if err := rlp.Encode(w, &ExportedBlock{Block: block, CommitSigAndBitmap: sigAndBitmap}); err != nil {
 			return err
 		}
 		if time.Since(reported) >= statsReportLimit {
@@ -826,6 +867,16 @@ func (bc *BlockChainImpl) ExportN(w io.Writer, first uint64, last uint64) error
 	return nil
 }
 
+// commitSigOf returns the commit signature and bitmap signed on the block, taken
+// from the last commit of its child or read from the database for the head.
+func (bc *BlockChainImpl) commitSigOf(block *types.Block) ([]byte, error) {
+	if child := bc.GetHeaderByNumber(block.NumberU64() + 1); child != nil {
+		sig := child.LastCommitSignature()
+		return append(sig[:], child.LastCommitBitmap()...), nil
+	}
+	return bc.ReadCommitSig(block.NumberU64())
+}
+
 func (bc *BlockChainImpl) WriteHeadBlock(block *types.Block) error {
 	return bc.writeHeadBlock(block)
 }
@@ -1066,6 +1117,13 @@ func (bc *BlockChainImpl) Stop() {
 	close(bc.quit)
 	atomic.StoreInt32(&bc.procInterrupt, 1)
 
+	// Persist the snapshot diff layers, so the snapshot can be loaded on restart
+	if bc.snaps != nil {
+		if _, err := bc.snaps.Journal(bc.CurrentBlock().Root()); err != nil {
+			utils.Logger().Error().Err(err).Msg("Failed to journal state snapshot")
+		}
+	}
+
 	// Ensure the state of a recent block is also stored to disk before exiting.
 	// We're writing three different states to catch different restart scenarios:
 	//  - HEAD:     So we don't need to reprocess any blocks in the general case
@@ -1176,7 +1234,7 @@ func (bc *BlockChainImpl) Rollback(chain []common.Hash) error {
 // SetReceiptsData computes all the non-consensus fields of the receipts
 func SetReceiptsData(config *params.ChainConfig, block *types.Block, receipts types.Receipts) error {
 	signer := types.MakeSigner(config, block.Epoch())
-	ethSigner := types.NewEIP155Signer(config.EthCompatibleChainID)
+	ethSigner := types.MakeEthSigner(config, block.Epoch())
 
 	transactions, stakingTransactions, logIndex := block.Transactions(), block.StakingTransactions(), uint(0)
 	if len(transactions)+len(stakingTransactions) != len(receipts) {
@@ -1378,6 +1436,17 @@ func (bc *BlockChainImpl) WriteBlockWithState(
 		return NonStatTy, err
 	}
 
+	// Keep the snapshot diff layers within the in-memory tries, flattening the
+	// older ones into the disk layer. If the snapshot lost track of the chain,
+	// e.g. after a state sync, regenerate it from the new head.
+	if bc.snaps != nil {
+		if bc.snaps.Snapshot(root) == nil {
+			bc.snaps.Rebuild(root)
+		} else if err := bc.snaps.Cap(root, triesInMemory-1); err != nil {
+			utils.Logger().Warn().Err(err).Str("root", root.Hex()).Msg("Failed to cap state snapshot")
+		}
+	}
+
 	// Flush trie state into disk if it's archival node or the block is epoch block
 	triedb := bc.stateCache.TrieDB()
 	if bc.cacheConfig.Disabled || block.IsLastBlockInEpoch() {
@@ -1389,6 +1458,11 @@ func (bc *BlockChainImpl) WriteBlockWithState(
 			return NonStatTy, err
 		}
 
+		// Prune the older states once the state of the epoch block is on disk
+		if p := bc.options.StatePruner; p != nil && !bc.cacheConfig.Disabled {
+			go bc.pruneState(p, block.Header())
+		}
+
 		// clean block tire info in redis, used for tikv mode
 		if block.NumberU64() > triesInRedis {
 			select {
@@ -1888,36 +1962,115 @@ func (bc *BlockChainImpl) update() {
 	}
 }
 
-// BadBlock ..
+// BadBlock is a block rejected by the chain.
 type BadBlock struct {
 	Block  *types.Block
 	Reason error
+	// TxIndex is the index of the transaction that failed to execute in the
+	// block, -1 if the block was not rejected for a transaction.
+	TxIndex int
+	TxHash  common.Hash
+	// Peer is the sender of the block, empty if unknown.
+	Peer string
+	Time time.Time
 }
 
 // MarshalJSON ..
 func (b BadBlock) MarshalJSON() ([]byte, error) {
+	var txHash *common.Hash
+	if b.TxIndex >= 0 {
+		txHash = &b.TxHash
+	}
 	return json.Marshal(struct {
-		Block  *block.Header `json:"header"`
-		Reason string        `json:"error-cause"`
+		Block   *block.Header `json:"header"`
+		Reason  string        `json:"error-cause"`
+		TxIndex int           `json:"tx-index"`
+		TxHash  *common.Hash  `json:"tx-hash,omitempty"`
+		Peer    string        `json:"peer,omitempty"`
+		Time    int64         `json:"time"`
 	}{
 		b.Block.Header(),
 		b.Reason.Error(),
+		b.TxIndex,
+		txHash,
+		b.Peer,
+		b.Time.Unix(),
 	})
 }
 
+// newBadBlock converts a stored bad block.
+func newBadBlock(stored *rawdb.BadBlock) BadBlock {
+	bad := BadBlock{
+		Block:   stored.Block,
+		Reason:  errors.New(stored.Reason),
+		TxIndex: -1,
+		Peer:    stored.Peer,
+		Time:    time.Unix(int64(stored.Time), 0),
+	}
+	if stored.TxHash != (common.Hash{}) {
+		bad.TxIndex = int(stored.TxIndex)
+		bad.TxHash = stored.TxHash
+	}
+	return bad
+}
+
+// BadBlocks returns the stored bad blocks, ordered by descending block number.
 func (bc *BlockChainImpl) BadBlocks() []BadBlock {
-	blocks := make([]BadBlock, bc.badBlocks.Len())
-	for _, hash := range bc.badBlocks.Keys() {
-		if blk, exist := bc.badBlocks.Peek(hash); exist {
-			blocks = append(blocks, blk.(BadBlock))
-		}
+	stored := rawdb.ReadAllBadBlocks(bc.db)
+	blocks := make([]BadBlock, 0, len(stored))
+	for _, bad := range stored {
+		blocks = append(blocks, newBadBlock(bad))
 	}
 	return blocks
 }
 
-// addBadBlock adds a bad block to the bad-block LRU cache
+// BadBlock returns the stored bad block with the given hash.
+func (bc *BlockChainImpl) BadBlock(hash common.Hash) (BadBlock, bool) {
+	stored := rawdb.ReadBadBlock(bc.db, hash)
+	if stored == nil {
+		return BadBlock{}, false
+	}
+	return newBadBlock(stored), true
+}
+
+// ReportBadBlockPeer records the peer a stored bad block was received from.
+// It is a no-op if the block is not a known bad block.
+func (bc *BlockChainImpl) ReportBadBlockPeer(hash common.Hash, peer string) {
+	bc.badBlocksMu.Lock()
+	defer bc.badBlocksMu.Unlock()
+
+	stored := rawdb.ReadBadBlock(bc.db, hash)
+	if stored == nil || stored.Peer == peer {
+		return
+	}
+	stored.Peer = peer
+	if err := rawdb.WriteBadBlock(bc.db, stored); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store the peer of a bad block")
+	}
+}
+
+// addBadBlock stores a bad block along with the transaction that failed to
+// execute in it, if any. The peer of an already stored block is kept.
 func (bc *BlockChainImpl) addBadBlock(block *types.Block, reason error) {
-	bc.badBlocks.Add(block.Hash(), BadBlock{block, reason})
+	bc.badBlocksMu.Lock()
+	defer bc.badBlocksMu.Unlock()
+
+	bad := &rawdb.BadBlock{
+		Block:  block,
+		Reason: reason.Error(),
+		Time:   uint64(time.Now().Unix()),
+	}
+	var txErr *TxError
+	if errors.As(reason, &txErr) {
+		bad.TxHash = txErr.Hash
+		bad.TxIndex = uint64(txErr.Index)
+	}
+	if stored := rawdb.ReadBadBlock(bc.db, block.Hash()); stored != nil {
+		bad.Peer = stored.Peer
+	}
+	if err := rawdb.WriteBadBlock(bc.db, bad); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store bad block")
+	}
 }
 
 // reportBlock logs a bad block error.
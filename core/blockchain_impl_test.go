@@ -1,13 +1,22 @@
 package core
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	chain2 "github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
 	staking "github.com/harmony-one/harmony/staking/types"
 )
 
@@ -36,6 +45,33 @@ func TestPrepareStakingMetadata(t *testing.T) {
 	}
 }
 
+func TestExportN(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	chain, _, _, _ := getTestEnvironment(*key)
+	sig := bytes.Repeat([]byte{0x01}, 97)
+	if err := chain.WriteCommitSig(0, sig); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := chain.ExportN(&buf, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	var exported ExportedBlock
+	if err := rlp.Decode(&buf, &exported); err != nil {
+		t.Fatal(err)
+	}
+	if exported.Block.Hash() != chain.Genesis().Hash() {
+		t.Errorf("unexpected block: %x / %x", exported.Block.Hash(), chain.Genesis().Hash())
+	}
+	if !bytes.Equal(exported.CommitSigAndBitmap, sig) {
+		t.Errorf("unexpected commit sig: %x / %x", exported.CommitSigAndBitmap, sig)
+	}
+	if err := chain.ExportN(&buf, 1, 0); err == nil {
+		t.Errorf("export of an inverted range accepted")
+	}
+}
+
 func signedCreateValidatorStakingTxn(key *ecdsa.PrivateKey) *staking.StakingTransaction {
 	stakePayloadMaker := func() (staking.Directive, interface{}) {
 		return staking.DirectiveCreateValidator, sampleCreateValidator(*key)
@@ -83,3 +119,58 @@ func signedCollectRewardsStakingTxn(key *ecdsa.PrivateKey) *staking.StakingTrans
 	signed, _ := staking.Sign(stx, staking.NewEIP155Signer(stx.ChainID()), key)
 	return signed
 }
+
+func TestBadBlocks(t *testing.T) {
+	// The coinbase of the blocks is an ECDSA address before the staking epoch
+	config := *params.TestChainConfig
+	config.PreStakingEpoch, config.StakingEpoch = params.EpochTBD, params.EpochTBD
+	database := rawdb.NewMemoryDatabase()
+	gspec := Genesis{Config: &config, Factory: blockfactory.ForTest}
+	gspec.MustCommit(database)
+	chain, err := NewBlockChain(database, state.NewDatabase(database), nil, nil, &config, chain2.NewEngine(), vm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A transfer from an account without funds fails to execute
+	key, _ := crypto.GenerateKey()
+	tx, _ := types.SignTx(
+		types.NewTransaction(0, common.BytesToAddress([]byte{0x11}), 0, big.NewInt(1), 21000, big.NewInt(1), nil),
+		types.NewEIP155Signer(config.ChainID), key,
+	)
+	header := blockfactory.ForTest.NewHeader(common.Big0).With().
+		Number(big.NewInt(1)).
+		ParentHash(chain.CurrentBlock().Hash()).
+		GasLimit(chain.CurrentBlock().GasLimit()).
+		Header()
+	block := types.NewBlock(header, []*types.Transaction{tx}, []*types.Receipt{types.NewReceipt([]byte{}, false, 0)}, nil, nil, nil)
+	if err := chain.validateNewBlock(block); err == nil {
+		t.Fatal("invalid block accepted")
+	}
+	chain.ReportBadBlockPeer(block.Hash(), "peer")
+
+	// The block is stored along with the failing transaction and its peer
+	chain, err = NewBlockChain(database, state.NewDatabase(database), nil, nil, &config, chain2.NewEngine(), vm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad, ok := chain.BadBlock(block.Hash())
+	if !ok {
+		t.Fatal("bad block not stored")
+	}
+	if bad.TxIndex != 0 || bad.TxHash != tx.Hash() {
+		t.Errorf("unexpected failing transaction: %d %x", bad.TxIndex, bad.TxHash)
+	}
+	if bad.Peer != "peer" {
+		t.Errorf("unexpected peer: %v", bad.Peer)
+	}
+	if badBlocks := chain.BadBlocks(); len(badBlocks) != 1 || badBlocks[0].Block.Hash() != block.Hash() {
+		t.Errorf("unexpected bad blocks: %v", badBlocks)
+	}
+
+	// Reporting the block again keeps its peer
+	chain.reportBlock(block, nil, errors.New("invalid merkle root"))
+	if bad, _ := chain.BadBlock(block.Hash()); bad.Peer != "peer" || bad.TxIndex != -1 {
+		t.Errorf("unexpected bad block: %+v", bad)
+	}
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state/pruner"
+	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/shard"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+const (
+	// pruneStateRetainEpochs is the number of epoch block states kept behind the
+	// head, covering the staking lock period
+	pruneStateRetainEpochs = staking.LockPeriodInEpoch
+)
+
+// RetainedStateRoots returns the state roots kept by a state prune from the given
+// head: the head state first, then the states of the blocks blocks behind it and
+// the states of the last blocks of the epochs epochs behind it.
+func RetainedStateRoots(
+	db rawdb.DatabaseReader, head *block.Header, schedule shardingconfig.Schedule, blocks, epochs uint64,
+) []common.Hash {
+	var (
+		roots  = []common.Hash{head.Root()}
+		seen   = map[uint64]struct{}{head.Number().Uint64(): {}}
+		number = head.Number().Uint64()
+	)
+	readRoot := func(n uint64) {
+		if _, ok := seen[n]; ok {
+			return
+		}
+		seen[n] = struct{}{}
+		hash := rawdb.ReadCanonicalHash(db, n)
+		if hash == (common.Hash{}) {
+			return
+		}
+		if header := rawdb.ReadHeader(db, hash, n); header != nil {
+			roots = append(roots, header.Root())
+		}
+	}
+	for i := uint64(1); i <= blocks && i <= number; i++ {
+		readRoot(number - i)
+	}
+	epoch := head.Epoch().Uint64()
+	for i := uint64(1); i <= epochs && i <= epoch; i++ {
+		if last := schedule.EpochLastBlock(epoch - i); last < number {
+			readRoot(last)
+		}
+	}
+	return roots
+}
+
+// pruneState prunes the states not retained from the given head, which must be
+// committed to disk.
+func (bc *BlockChainImpl) pruneState(p *pruner.Pruner, head *block.Header) {
+	roots := RetainedStateRoots(bc.db, head, shard.Schedule, triesInMemory, pruneStateRetainEpochs)
+	if err := p.Prune(bc.stateCache.TrieDB(), roots); err != nil {
+		if err == pruner.ErrPruneRunning {
+			utils.Logger().Debug().Uint64("blockNum", head.Number().Uint64()).
+				Msg("[StatePruner] previous prune still running, skipped")
+			return
+		}
+		utils.Logger().Warn().Err(err).Uint64("blockNum", head.Number().Uint64()).
+			Msg("[StatePruner] failed to prune state")
+	}
+}
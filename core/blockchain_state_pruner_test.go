@@ -0,0 +1,59 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethrawdb "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/rawdb"
+	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
+)
+
+func TestRetainedStateRoots(t *testing.T) {
+	var (
+		db       = ethrawdb.NewMemoryDatabase()
+		schedule = shardingconfig.LocalnetSchedule
+		head     *block.Header
+	)
+	rootOf := func(n uint64) common.Hash {
+		return common.BigToHash(new(big.Int).SetUint64(n + 1))
+	}
+	for n := uint64(0); n <= 60; n++ {
+		head = blockfactory.NewTestHeader().With().
+			Number(new(big.Int).SetUint64(n)).
+			Epoch(schedule.CalcEpochNumber(n)).
+			Root(rootOf(n)).
+			Header()
+		if err := rawdb.WriteHeader(db, head); err != nil {
+			t.Fatal(err)
+		}
+		if err := rawdb.WriteCanonicalHash(db, head.Hash(), n); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	roots := RetainedStateRoots(db, head, schedule, 3, 2)
+
+	exp := []common.Hash{rootOf(60), rootOf(59), rootOf(58), rootOf(57)}
+	epoch := head.Epoch().Uint64()
+	for _, e := range []uint64{epoch - 1, epoch - 2} {
+		exp = append(exp, rootOf(schedule.EpochLastBlock(e)))
+	}
+	if len(roots) != len(exp) {
+		t.Fatalf("unexpected number of roots: %v / %v", len(roots), len(exp))
+	}
+	for i := range roots {
+		if roots[i] != exp[i] {
+			t.Errorf("root %v: %x / %x", i, roots[i], exp[i])
+		}
+	}
+
+	// the retained blocks overlapping the epoch blocks are not repeated
+	roots = RetainedStateRoots(db, head, schedule, 60, 2)
+	if len(roots) != 61 {
+		t.Errorf("unexpected number of roots: %v / %v", len(roots), 61)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"io"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -61,6 +62,14 @@ func (a Stub) StateAt(common.Hash) (*state.DB, error) {
 	return nil, errors.Errorf("method StateAt not implemented for %s", a.Name)
 }
 
+func (a Stub) StateCache() state.Database {
+	return nil
+}
+
+func (a Stub) TrieNode(hash common.Hash) ([]byte, error) {
+	return nil, errors.Errorf("method TrieNode not implemented for %s", a.Name)
+}
+
 func (a Stub) HasBlock(hash common.Hash, number uint64) bool {
 	return false
 }
@@ -112,10 +121,25 @@ func (a Stub) InsertChain(chain types.Blocks, verifyHeaders bool) (int, error) {
 	return 0, errors.Errorf("method InsertChain not implemented for %s", a.Name)
 }
 
+func (a Stub) Export(w io.Writer) error {
+	return errors.Errorf("method Export not implemented for %s", a.Name)
+}
+
+func (a Stub) ExportN(w io.Writer, first uint64, last uint64) error {
+	return errors.Errorf("method ExportN not implemented for %s", a.Name)
+}
+
 func (a Stub) BadBlocks() []BadBlock {
 	return nil
 }
 
+func (a Stub) BadBlock(hash common.Hash) (BadBlock, bool) {
+	return BadBlock{}, false
+}
+
+func (a Stub) ReportBadBlockPeer(hash common.Hash, peer string) {
+}
+
 func (a Stub) CurrentHeader() *block.Header {
 	return nil
 }
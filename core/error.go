@@ -17,6 +17,9 @@
 package core
 
 import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 )
 
@@ -38,3 +41,21 @@ var (
 	// ErrShardStateNotMatch is returned if the calculated shardState hash not equal that in the block header
 	ErrShardStateNotMatch = errors.New("shard state root hash not match")
 )
+
+// TxError is returned when a transaction of a block fails to execute. The
+// staking transactions are indexed after the plain transactions of the block.
+type TxError struct {
+	Index int
+	Hash  common.Hash
+	Err   error
+}
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("transaction %d (%s): %v", e.Index, e.Hash.Hex(), e.Err)
+}
+
+// Cause returns the execution error, for errors.Cause.
+func (e *TxError) Cause() error { return e.Err }
+
+// Unwrap returns the execution error, for errors.Is and errors.As.
+func (e *TxError) Unwrap() error { return e.Err }
@@ -77,6 +77,11 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		vrfAndProof := header.Vrf()
 		copy(vrf[:], vrfAndProof[:32])
 	}
+	baseFee := header.BaseFee()
+	gasPrice := new(big.Int).Set(msg.GasPrice())
+	if baseFee != nil {
+		gasPrice = types.EffectiveGasPrice(msg.GasTipCap(), msg.GasFeeCap(), baseFee)
+	}
 	return vm.Context{
 		CanTransfer:           CanTransfer,
 		Transfer:              Transfer,
@@ -84,13 +89,14 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		GetVRF:                GetVRFFn(header, chain),
 		IsValidator:           IsValidator,
 		Origin:                msg.From(),
-		GasPrice:              new(big.Int).Set(msg.GasPrice()),
+		GasPrice:              gasPrice,
 		Coinbase:              beneficiary,
 		GasLimit:              header.GasLimit(),
 		BlockNumber:           header.Number(),
 		EpochNumber:           header.Epoch(),
 		Time:                  header.Time(),
 		VRF:                   vrf,
+		BaseFee:               baseFee,
 		TxType:                0,
 		CreateValidator:       CreateValidatorFn(header, chain),
 		EditValidator:         EditValidatorFn(header, chain),
@@ -385,6 +391,7 @@ func CalculateMigrationGasFn(chain ChainContext) vm.CalculateMigrationGasFunc {
 			}
 			thisGas, err := vm.IntrinsicGas(
 				encoded,
+				nil,
 				false,
 				homestead,
 				istanbul,
@@ -408,6 +415,7 @@ func CalculateMigrationGasFn(chain ChainContext) vm.CalculateMigrationGasFunc {
 			// there are no delegations to migrate
 			return vm.IntrinsicGas(
 				[]byte{},
+				nil,
 				false,
 				homestead,
 				istanbul,
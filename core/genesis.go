@@ -273,6 +273,9 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 		ShardStateHash(g.ShardStateHash).
 		ShardState(shardStateBytes).
 		Header()
+	if g.Config != nil && g.Config.IsLondon(common.Big0) {
+		head.SetBaseFee(new(big.Int).SetUint64(params.InitialBaseFee))
+	}
 	statedb.Commit(false)
 	statedb.Database().TrieDB().Commit(root, true)
 
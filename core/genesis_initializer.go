@@ -14,6 +14,7 @@ import (
 // GenesisInitializer is a shardchain.DBInitializer adapter.
 type GenesisInitializer struct {
 	NetworkType nodeconfig.NetworkType
+	Alloc       GenesisAlloc // extra accounts allocated in the genesis block of each shard
 }
 
 // InitChainDB sets up a new genesis block in the database for the given shard.
@@ -42,6 +43,9 @@ func (gi *GenesisInitializer) InitChainDB(db ethdb.Database, shardID uint32) err
 func (gi *GenesisInitializer) setupGenesisBlock(db ethdb.Database, shardID uint32, myShardState *shard.State) {
 	utils.Logger().Info().Interface("shardID", shardID).Msg("setting up a brand new chain database")
 	gspec := NewGenesisSpec(gi.NetworkType, shardID)
+	for addr, account := range gi.Alloc {
+		gspec.Alloc[addr] = account
+	}
 	gspec.ShardStateHash = myShardState.Hash()
 	gspec.ShardState = *myShardState.DeepCopy()
 	// Store genesis block into db.
@@ -69,3 +69,17 @@ func EncodeGenesisConfig(fileName string) string {
 	by := encodeGenesisConfig(gi)
 	return string(by)
 }
+
+// ReadGenesisAlloc reads a genesis allocation from a JSON file, such as the one
+// written by the dumpstate command to fork a state into a new network.
+func ReadGenesisAlloc(fileName string) (GenesisAlloc, error) {
+	input, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var alloc GenesisAlloc
+	if err := json.Unmarshal(input, &alloc); err != nil {
+		return nil, fmt.Errorf("cannot parse genesis alloc file %v: %v", fileName, err)
+	}
+	return alloc, nil
+}
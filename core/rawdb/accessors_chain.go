@@ -20,8 +20,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/core/types"
@@ -38,9 +40,39 @@ const (
 	NAByte // not exist
 )
 
+// isFrozen returns the ancient store of the database if the given block is a
+// frozen canonical block. The blocks are moved to the ancient store once synced
+// to it, so the accessors try the key-value store first and the ancients next.
+func isFrozen(db DatabaseReader, hash common.Hash, number uint64) (ethdb.AncientReader, bool) {
+	ancients, ok := db.(ethdb.AncientReader)
+	if !ok {
+		return nil, false
+	}
+	data, err := ancients.Ancient(freezerHashTable, number)
+	if err != nil || common.BytesToHash(data) != hash {
+		return nil, false
+	}
+	return ancients, true
+}
+
+// readAncient retrieves the ancient data of the given kind of a frozen block.
+func readAncient(db DatabaseReader, kind string, hash common.Hash, number uint64) []byte {
+	ancients, ok := isFrozen(db, hash, number)
+	if !ok {
+		return nil
+	}
+	data, _ := ancients.Ancient(kind, number)
+	return data
+}
+
 // ReadCanonicalHash retrieves the hash assigned to a canonical block number.
 func ReadCanonicalHash(db DatabaseReader, number uint64) common.Hash {
 	data, _ := db.Get(headerHashKey(number))
+	if len(data) == 0 {
+		if ancients, ok := db.(ethdb.AncientReader); ok {
+			data, _ = ancients.Ancient(freezerHashTable, number)
+		}
+	}
 	if len(data) == 0 {
 		return common.Hash{}
 	}
@@ -142,13 +174,17 @@ func WriteHeadFastBlockHash(db DatabaseWriter, hash common.Hash) error {
 // ReadHeaderRLP retrieves a block header in its raw RLP database encoding.
 func ReadHeaderRLP(db DatabaseReader, hash common.Hash, number uint64) rlp.RawValue {
 	data, _ := db.Get(headerKey(number, hash))
+	if len(data) == 0 {
+		data = readAncient(db, freezerHeaderTable, hash, number)
+	}
 	return data
 }
 
 // HasHeader verifies the existence of a block header corresponding to the hash.
 func HasHeader(db DatabaseReader, hash common.Hash, number uint64) bool {
 	if has, err := db.Has(headerKey(number, hash)); !has || err != nil {
-		return false
+		_, frozen := isFrozen(db, hash, number)
+		return frozen
 	}
 	return true
 }
@@ -211,6 +247,9 @@ func DeleteHeader(db DatabaseDeleter, hash common.Hash, number uint64) error {
 // ReadBodyRLP retrieves the block body (transactions and uncles) in RLP encoding.
 func ReadBodyRLP(db DatabaseReader, hash common.Hash, number uint64) rlp.RawValue {
 	data, _ := db.Get(blockBodyKey(number, hash))
+	if len(data) == 0 {
+		data = readAncient(db, freezerBodiesTable, hash, number)
+	}
 	return data
 }
 
@@ -226,7 +265,8 @@ func WriteBodyRLP(db DatabaseWriter, hash common.Hash, number uint64, rlp rlp.Ra
 // HasBody verifies the existence of a block body corresponding to the hash.
 func HasBody(db DatabaseReader, hash common.Hash, number uint64) bool {
 	if has, err := db.Has(blockBodyKey(number, hash)); !has || err != nil {
-		return false
+		_, frozen := isFrozen(db, hash, number)
+		return frozen
 	}
 	return true
 }
@@ -267,6 +307,9 @@ func DeleteBody(db DatabaseDeleter, hash common.Hash, number uint64) error {
 // ReadTd retrieves a block's total difficulty corresponding to the hash.
 func ReadTd(db DatabaseReader, hash common.Hash, number uint64) *big.Int {
 	data, _ := db.Get(headerTDKey(number, hash))
+	if len(data) == 0 {
+		data = readAncient(db, freezerDifficultyTable, hash, number)
+	}
 	if len(data) == 0 {
 		return nil
 	}
@@ -301,10 +344,20 @@ func DeleteTd(db DatabaseDeleter, hash common.Hash, number uint64) error {
 	return nil
 }
 
+// ReadReceiptsRLP retrieves all the transaction receipts belonging to a block in
+// RLP encoding.
+func ReadReceiptsRLP(db DatabaseReader, hash common.Hash, number uint64) rlp.RawValue {
+	data, _ := db.Get(blockReceiptsKey(number, hash))
+	if len(data) == 0 {
+		data = readAncient(db, freezerReceiptTable, hash, number)
+	}
+	return data
+}
+
 // ReadReceipts retrieves all the transaction receipts belonging to a block.
 func ReadReceipts(db DatabaseReader, hash common.Hash, number uint64) types.Receipts {
 	// Retrieve the flattened receipt slice
-	data, _ := db.Get(blockReceiptsKey(number, hash))
+	data := ReadReceiptsRLP(db, hash, number)
 	if len(data) == 0 {
 		return nil
 	}
@@ -453,3 +506,86 @@ func IteratorBlocks(iterator DatabaseIterator, cb func(blockNum uint64, hash com
 
 	return
 }
+
+// badBlockToKeep is the maximum number of rejected blocks kept in the database.
+const badBlockToKeep = 64
+
+// BadBlock is a block rejected by the chain, along with the reason it was
+// rejected for.
+type BadBlock struct {
+	Block  *types.Block
+	Reason string
+	// TxHash and TxIndex identify the transaction that failed to execute,
+	// TxHash is empty when the block was not rejected for a transaction.
+	TxHash  common.Hash
+	TxIndex uint64
+	// Peer is the sender of the block, empty if unknown.
+	Peer string
+	// Time is the unix time the block was rejected at.
+	Time uint64
+}
+
+// ReadAllBadBlocks retrieves all the rejected blocks in the database, ordered
+// by descending block number.
+func ReadAllBadBlocks(db DatabaseReader) []*BadBlock {
+	data, err := db.Get(badBlockKey)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	var badBlocks []*BadBlock
+	if err := rlp.DecodeBytes(data, &badBlocks); err != nil {
+		utils.Logger().Error().Err(err).Msg("Invalid bad block list RLP")
+		return nil
+	}
+	return badBlocks
+}
+
+// ReadBadBlock retrieves the rejected block with the given hash, nil if the
+// block is unknown.
+func ReadBadBlock(db DatabaseReader, hash common.Hash) *BadBlock {
+	for _, bad := range ReadAllBadBlocks(db) {
+		if bad.Block.Hash() == hash {
+			return bad
+		}
+	}
+	return nil
+}
+
+// WriteBadBlock stores a rejected block, replacing the previous entry of the
+// same block. Only the badBlockToKeep blocks with the highest numbers are kept.
+func WriteBadBlock(db interface {
+	DatabaseReader
+	DatabaseWriter
+}, badBlock *BadBlock) error {
+	badBlocks := []*BadBlock{badBlock}
+	for _, bad := range ReadAllBadBlocks(db) {
+		if bad.Block.Hash() != badBlock.Block.Hash() {
+			badBlocks = append(badBlocks, bad)
+		}
+	}
+	sort.SliceStable(badBlocks, func(i, j int) bool {
+		return badBlocks[i].Block.NumberU64() > badBlocks[j].Block.NumberU64()
+	})
+	if len(badBlocks) > badBlockToKeep {
+		badBlocks = badBlocks[:badBlockToKeep]
+	}
+	data, err := rlp.EncodeToBytes(badBlocks)
+	if err != nil {
+		utils.Logger().Error().Msg("Failed to RLP encode bad blocks")
+		return err
+	}
+	if err := db.Put(badBlockKey, data); err != nil {
+		utils.Logger().Error().Msg("Failed to store bad blocks")
+		return err
+	}
+	return nil
+}
+
+// DeleteBadBlocks removes all the rejected blocks from the database.
+func DeleteBadBlocks(db DatabaseDeleter) error {
+	if err := db.Delete(badBlockKey); err != nil {
+		utils.Logger().Error().Msg("Failed to delete bad blocks")
+		return err
+	}
+	return nil
+}
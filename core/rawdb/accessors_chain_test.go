@@ -334,3 +334,67 @@ func TestBlockReceiptStorage(t *testing.T) {
 		t.Fatalf("deleted receipts returned: %v", rs)
 	}
 }
+
+// Tests rejected block storage and retrieval operations.
+func TestBadBlockStorage(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	newBlock := func(number int64) *types.Block {
+		return types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+			Number(big.NewInt(number)).
+			Extra([]byte("bad block")).
+			Header())
+	}
+	if entry := ReadBadBlock(db, newBlock(1).Hash()); entry != nil {
+		t.Fatalf("Non existent bad block returned: %v", entry)
+	}
+	bad := &BadBlock{
+		Block:   newBlock(1),
+		Reason:  "invalid merkle root",
+		TxHash:  common.HexToHash("0x01"),
+		TxIndex: 2,
+		Peer:    "peer",
+		Time:    100,
+	}
+	if err := WriteBadBlock(db, bad); err != nil {
+		t.Fatalf("Failed to write bad block: %v", err)
+	}
+	if entry := ReadBadBlock(db, bad.Block.Hash()); entry == nil {
+		t.Fatalf("Stored bad block not found")
+	} else if entry.Block.Hash() != bad.Block.Hash() || entry.Reason != bad.Reason ||
+		entry.TxHash != bad.TxHash || entry.TxIndex != bad.TxIndex || entry.Peer != bad.Peer || entry.Time != bad.Time {
+		t.Fatalf("Retrieved bad block mismatch: have %+v, want %+v", entry, bad)
+	}
+
+	// Rewriting a block replaces its entry
+	bad.Peer = "other peer"
+	if err := WriteBadBlock(db, bad); err != nil {
+		t.Fatalf("Failed to write bad block: %v", err)
+	}
+	if entries := ReadAllBadBlocks(db); len(entries) != 1 || entries[0].Peer != bad.Peer {
+		t.Fatalf("Bad block not replaced: %v", entries)
+	}
+
+	// Only the blocks with the highest numbers are kept, in descending order
+	for i := 0; i < badBlockToKeep+10; i++ {
+		if err := WriteBadBlock(db, &BadBlock{Block: newBlock(int64(i + 2))}); err != nil {
+			t.Fatalf("Failed to write bad block: %v", err)
+		}
+	}
+	entries := ReadAllBadBlocks(db)
+	if len(entries) != badBlockToKeep {
+		t.Fatalf("Bad block count mismatch: have %d, want %d", len(entries), badBlockToKeep)
+	}
+	for i, entry := range entries {
+		if want := uint64(badBlockToKeep + 11 - i); entry.Block.NumberU64() != want {
+			t.Fatalf("Bad block %d number mismatch: have %d, want %d", i, entry.Block.NumberU64(), want)
+		}
+	}
+
+	if err := DeleteBadBlocks(db); err != nil {
+		t.Fatalf("Failed to delete bad blocks: %v", err)
+	}
+	if entries := ReadAllBadBlocks(db); len(entries) != 0 {
+		t.Fatalf("Deleted bad blocks returned: %v", entries)
+	}
+}
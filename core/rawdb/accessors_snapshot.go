@@ -0,0 +1,150 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// ReadSnapshotRoot retrieves the root of the block whose state is contained in
+// the persisted flat state snapshot.
+func ReadSnapshotRoot(db DatabaseReader) common.Hash {
+	data, _ := db.Get(snapshotRootKey)
+	if len(data) != common.HashLength {
+		return common.Hash{}
+	}
+	return common.BytesToHash(data)
+}
+
+// WriteSnapshotRoot stores the root of the block whose state is contained in
+// the persisted flat state snapshot.
+func WriteSnapshotRoot(db DatabaseWriter, root common.Hash) error {
+	if err := db.Put(snapshotRootKey, root[:]); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store snapshot root")
+		return err
+	}
+	return nil
+}
+
+// DeleteSnapshotRoot deletes the root of the persisted flat state snapshot, which
+// marks the snapshot as invalid until it is generated again.
+func DeleteSnapshotRoot(db DatabaseDeleter) error {
+	if err := db.Delete(snapshotRootKey); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to remove snapshot root")
+		return err
+	}
+	return nil
+}
+
+// ReadAccountSnapshot retrieves the account trie value of the given account hash
+// from the flat state snapshot.
+func ReadAccountSnapshot(db DatabaseReader, hash common.Hash) []byte {
+	data, _ := db.Get(accountSnapshotKey(hash))
+	return data
+}
+
+// WriteAccountSnapshot stores the account trie value of the given account hash
+// into the flat state snapshot.
+func WriteAccountSnapshot(db DatabaseWriter, hash common.Hash, entry []byte) error {
+	if err := db.Put(accountSnapshotKey(hash), entry); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store account snapshot")
+		return err
+	}
+	return nil
+}
+
+// DeleteAccountSnapshot removes the account of the given hash from the flat
+// state snapshot.
+func DeleteAccountSnapshot(db DatabaseDeleter, hash common.Hash) error {
+	if err := db.Delete(accountSnapshotKey(hash)); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to delete account snapshot")
+		return err
+	}
+	return nil
+}
+
+// ReadStorageSnapshot retrieves the storage trie value of the given storage slot
+// of an account from the flat state snapshot.
+func ReadStorageSnapshot(db DatabaseReader, accountHash, storageHash common.Hash) []byte {
+	data, _ := db.Get(storageSnapshotKey(accountHash, storageHash))
+	return data
+}
+
+// WriteStorageSnapshot stores the storage trie value of the given storage slot
+// of an account into the flat state snapshot.
+func WriteStorageSnapshot(db DatabaseWriter, accountHash, storageHash common.Hash, entry []byte) error {
+	if err := db.Put(storageSnapshotKey(accountHash, storageHash), entry); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store storage snapshot")
+		return err
+	}
+	return nil
+}
+
+// DeleteStorageSnapshot removes the given storage slot of an account from the
+// flat state snapshot.
+func DeleteStorageSnapshot(db DatabaseDeleter, accountHash, storageHash common.Hash) error {
+	if err := db.Delete(storageSnapshotKey(accountHash, storageHash)); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to delete storage snapshot")
+		return err
+	}
+	return nil
+}
+
+// IterateStorageSnapshots returns an iterator over the flat storage slots of the
+// given account.
+func IterateStorageSnapshots(db DatabaseIterator, accountHash common.Hash) ethdb.Iterator {
+	return db.NewIteratorWithPrefix(storageSnapshotsKey(accountHash))
+}
+
+// ReadSnapshotJournal retrieves the serialized in-memory diff layers saved at the
+// last shutdown.
+func ReadSnapshotJournal(db DatabaseReader) []byte {
+	data, _ := db.Get(snapshotJournalKey)
+	return data
+}
+
+// WriteSnapshotJournal stores the serialized in-memory diff layers to be loaded
+// after a restart.
+func WriteSnapshotJournal(db DatabaseWriter, journal []byte) error {
+	if err := db.Put(snapshotJournalKey, journal); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store snapshot journal")
+		return err
+	}
+	return nil
+}
+
+// DeleteSnapshotJournal deletes the serialized in-memory diff layers.
+func DeleteSnapshotJournal(db DatabaseDeleter) error {
+	if err := db.Delete(snapshotJournalKey); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to remove snapshot journal")
+		return err
+	}
+	return nil
+}
+
+// ReadSnapshotGenerator retrieves the serialized progress of the snapshot
+// generation saved at the last shutdown.
+func ReadSnapshotGenerator(db DatabaseReader) []byte {
+	data, _ := db.Get(snapshotGeneratorKey)
+	return data
+}
+
+// WriteSnapshotGenerator stores the serialized progress of the snapshot
+// generation to be resumed after a restart.
+func WriteSnapshotGenerator(db DatabaseWriter, generator []byte) error {
+	if err := db.Put(snapshotGeneratorKey, generator); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store snapshot generator")
+		return err
+	}
+	return nil
+}
+
+// DeleteSnapshotGenerator deletes the serialized progress of the snapshot
+// generation.
+func DeleteSnapshotGenerator(db DatabaseDeleter) error {
+	if err := db.Delete(snapshotGeneratorKey); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to remove snapshot generator")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,416 @@
+package rawdb
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// freezerRecheckInterval is the frequency to check the key-value database
+	// for chain progression that might permit new blocks to be frozen into
+	// immutable storage.
+	freezerRecheckInterval = time.Minute
+
+	// freezerBatchLimit is the maximum number of blocks to freeze in one batch
+	// before doing an fsync and deleting them from the key-value store.
+	freezerBatchLimit = 30000
+)
+
+// errUnknownTable is returned if the user attempts to read from a table that
+// is not tracked by the freezer.
+var errUnknownTable = errors.New("unknown table")
+
+// freezer is an append-only database to store immutable chain data into flat
+// files:
+//
+//   - The append only nature ensures that disk writes are minimized.
+//   - The data is compressed and never rewritten, so the key-value store neither
+//     keeps nor compacts it.
+type freezer struct {
+	frozen uint64 // frozen is the number of blocks already frozen, must be accessed atomically
+
+	threshold uint64 // threshold is the number of recent blocks kept in the key-value store
+	tables    map[string]*freezerTable
+
+	quit      chan struct{}
+	closeOnce sync.Once
+}
+
+// newFreezer creates a chain freezer that moves the canonical blocks older than
+// threshold blocks from the head to the flat files in the given directory.
+func newFreezer(dir string, threshold uint64) (*freezer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	f := &freezer{
+		threshold: threshold,
+		tables:    make(map[string]*freezerTable),
+		quit:      make(chan struct{}),
+	}
+	for name, noCompression := range freezerNoCompression {
+		table, err := newTable(dir, name, noCompression)
+		if err != nil {
+			for _, table := range f.tables {
+				table.Close()
+			}
+			return nil, err
+		}
+		f.tables[name] = table
+	}
+	if err := f.repair(); err != nil {
+		for _, table := range f.tables {
+			table.Close()
+		}
+		return nil, err
+	}
+	utils.Logger().Info().Str("dir", dir).Uint64("frozen", f.frozen).Msg("Opened ancient database")
+	return f, nil
+}
+
+// repair truncates all data tables to the same length.
+func (f *freezer) repair() error {
+	min := uint64(1<<64 - 1)
+	for _, table := range f.tables {
+		if items := atomic.LoadUint64(&table.items); min > items {
+			min = items
+		}
+	}
+	for _, table := range f.tables {
+		if err := table.truncate(min); err != nil {
+			return err
+		}
+	}
+	atomic.StoreUint64(&f.frozen, min)
+	return nil
+}
+
+// Close terminates the chain freezer, closing all the data files.
+func (f *freezer) Close() error {
+	var errs []error
+	f.closeOnce.Do(func() {
+		close(f.quit)
+		for _, table := range f.tables {
+			if err := table.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+	if errs != nil {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// HasAncient returns an indicator whether the specified ancient data exists
+// in the freezer.
+func (f *freezer) HasAncient(kind string, number uint64) (bool, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.has(number), nil
+	}
+	return false, nil
+}
+
+// Ancient retrieves an ancient binary blob from the append-only immutable files.
+func (f *freezer) Ancient(kind string, number uint64) ([]byte, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.Retrieve(number)
+	}
+	return nil, errUnknownTable
+}
+
+// Ancients returns the length of the frozen items.
+func (f *freezer) Ancients() (uint64, error) {
+	return atomic.LoadUint64(&f.frozen), nil
+}
+
+// AncientSize returns the ancient size of the specified category.
+func (f *freezer) AncientSize(kind string) (uint64, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.size(), nil
+	}
+	return 0, errUnknownTable
+}
+
+// AppendAncient injects all binary blobs belong to block at the end of the
+// append-only immutable table files.
+//
+// Notably, this function is lock free but kind of thread-safe. All out-of-order
+// injection will be rejected. But if two injections with same number happen at
+// the same time, we can get into the trouble.
+func (f *freezer) AppendAncient(number uint64, hash, header, body, receipts, td []byte) (err error) {
+	if atomic.LoadUint64(&f.frozen) != number {
+		return errOutOrderInsertion
+	}
+	// Rollback all inserted data if any insertion below failed to ensure
+	// the tables won't out of sync.
+	defer func() {
+		if err != nil {
+			for _, table := range f.tables {
+				if rerr := table.truncate(number); rerr != nil {
+					utils.Logger().Error().Err(rerr).Str("table", table.name).
+						Msg("Failed to rollback ancient table")
+				}
+			}
+		}
+	}()
+	blobs := map[string][]byte{
+		freezerHashTable:       hash,
+		freezerHeaderTable:     header,
+		freezerBodiesTable:     body,
+		freezerReceiptTable:    receipts,
+		freezerDifficultyTable: td,
+	}
+	for name, blob := range blobs {
+		if err := f.tables[name].Append(number, blob); err != nil {
+			return errors.Wrapf(err, "append ancient %s of block %d", name, number)
+		}
+	}
+	atomic.AddUint64(&f.frozen, 1) // Only modify atomically
+	return nil
+}
+
+// TruncateAncients discards any recent data above the provided threshold number.
+func (f *freezer) TruncateAncients(items uint64) error {
+	if atomic.LoadUint64(&f.frozen) <= items {
+		return nil
+	}
+	for _, table := range f.tables {
+		if err := table.truncate(items); err != nil {
+			return err
+		}
+	}
+	atomic.StoreUint64(&f.frozen, items)
+	return nil
+}
+
+// Sync flushes all data tables to disk.
+func (f *freezer) Sync() error {
+	var errs []error
+	for _, table := range f.tables {
+		if err := table.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// freeze is a background thread that periodically checks the blockchain for any
+// import progress and moves ancient data from the fast database into the freezer.
+//
+// This functionality is deliberately broken off from block importing to avoid
+// incurring additional data shuffling delays on block propagation.
+func (f *freezer) freeze(db ethdb.KeyValueStore) {
+	var (
+		backoff bool
+		missing uint64 // the last block reported missing, to log it once
+	)
+	for {
+		if backoff {
+			select {
+			case <-time.After(freezerRecheckInterval):
+			case <-f.quit:
+				return
+			}
+		} else {
+			select {
+			case <-f.quit:
+				return
+			default:
+			}
+		}
+		backoff = true
+
+		// Retrieve the freezing threshold
+		hash := ReadHeadBlockHash(db)
+		if hash == (common.Hash{}) {
+			continue
+		}
+		number := ReadHeaderNumber(db, hash)
+		if number == nil || *number < f.threshold {
+			continue
+		}
+		var (
+			first = atomic.LoadUint64(&f.frozen)
+			limit = *number - f.threshold
+		)
+		if first > limit {
+			continue
+		}
+		if limit-first >= freezerBatchLimit {
+			limit = first + freezerBatchLimit - 1
+			backoff = false
+		}
+		// Seems we have data ready to be frozen, process in usable batches
+		start := time.Now()
+		var ancients []common.Hash
+		for atomic.LoadUint64(&f.frozen) <= limit {
+			n := atomic.LoadUint64(&f.frozen)
+			hash := ReadCanonicalHash(db, n)
+			header, _ := db.Get(headerKey(n, hash))
+			body, _ := db.Get(blockBodyKey(n, hash))
+			if hash == (common.Hash{}) || len(header) == 0 || len(body) == 0 {
+				if missing != n+1 {
+					utils.Logger().Warn().Uint64("number", n).Str("hash", hash.Hex()).
+						Msg("Canonical block missing, can't freeze")
+					missing = n + 1
+				}
+				backoff = true
+				break
+			}
+			// The receipts and the total difficulty are not written for all
+			// the blocks, so they are frozen empty when missing
+			receipts, _ := db.Get(blockReceiptsKey(n, hash))
+			td, _ := db.Get(headerTDKey(n, hash))
+
+			if err := f.AppendAncient(n, hash[:], header, body, receipts, td); err != nil {
+				utils.Logger().Error().Err(err).Uint64("number", n).Msg("Failed to freeze block")
+				backoff = true
+				break
+			}
+			ancients = append(ancients, hash)
+		}
+		if len(ancients) == 0 {
+			continue
+		}
+		// Batch of blocks have been frozen, flush them before wiping from the
+		// key-value store
+		if err := f.Sync(); err != nil {
+			utils.Logger().Error().Err(err).Msg("Failed to flush frozen tables")
+			return
+		}
+		if err := deleteFrozenBlocks(db, first, ancients); err != nil {
+			utils.Logger().Error().Err(err).Msg("Failed to delete frozen blocks")
+			return
+		}
+		utils.Logger().Info().
+			Uint64("blocks", uint64(len(ancients))).
+			Uint64("number", first+uint64(len(ancients))-1).
+			Str("hash", ancients[len(ancients)-1].Hex()).
+			Dur("elapsed", time.Since(start)).
+			Msg("Moved ancient blocks into freezer")
+	}
+}
+
+// deleteFrozenBlocks deletes the frozen canonical blocks starting at the given
+// number from the key-value store, together with the side chain blocks of the
+// same heights. The hash to number mappings of the canonical blocks are kept
+// for the lookups by hash. The genesis block is never deleted.
+func deleteFrozenBlocks(db ethdb.KeyValueStore, first uint64, ancients []common.Hash) error {
+	batch := db.NewBatch()
+	for i, hash := range ancients {
+		number := first + uint64(i)
+		if number == 0 {
+			continue
+		}
+		if err := batch.Delete(headerHashKey(number)); err != nil {
+			return err
+		}
+		for _, blockHash := range readAllHashes(db, number) {
+			if blockHash != hash {
+				if err := batch.Delete(headerNumberKey(blockHash)); err != nil {
+					return err
+				}
+			}
+			for _, key := range [][]byte{
+				headerKey(number, blockHash),
+				blockBodyKey(number, blockHash),
+				blockReceiptsKey(number, blockHash),
+				headerTDKey(number, blockHash),
+			} {
+				if err := batch.Delete(key); err != nil {
+					return err
+				}
+			}
+		}
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	return batch.Write()
+}
+
+// readAllHashes retrieves the hashes of all the blocks stored in the key-value
+// store at the given height.
+func readAllHashes(db ethdb.Iteratee, number uint64) []common.Hash {
+	prefix := append(append([]byte{}, headerPrefix...), encodeBlockNumber(number)...)
+
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var hashes []common.Hash
+	for it.Next() {
+		if key := it.Key(); len(key) == len(prefix)+common.HashLength {
+			hashes = append(hashes, common.BytesToHash(key[len(prefix):]))
+		}
+	}
+	return hashes
+}
+
+// freezerdb is a database wrapper that enables the freezer data retrievals.
+type freezerdb struct {
+	ethdb.KeyValueStore
+	ethdb.AncientStore
+}
+
+// Close implements io.Closer, closing both the fast key-value store as well as
+// the slow ancient tables.
+func (frdb *freezerdb) Close() error {
+	var errs []error
+	if err := frdb.AncientStore.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := frdb.KeyValueStore.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// NewDatabaseWithFreezer creates a high level database on top of a given
+// key-value data store with a freezer in the given directory, moving the
+// canonical blocks older than threshold blocks from the head out of the
+// key-value store in the background.
+func NewDatabaseWithFreezer(db ethdb.KeyValueStore, dir string, threshold uint64) (ethdb.Database, error) {
+	frdb, err := newFreezer(dir, threshold)
+	if err != nil {
+		return nil, err
+	}
+	// The freezer must belong to the chain of the key-value store, either
+	// holding its genesis block or being empty
+	if kvgenesis := ReadCanonicalHash(db, 0); kvgenesis != (common.Hash{}) {
+		if frozen, _ := frdb.Ancients(); frozen > 0 {
+			if frgenesis, err := frdb.Ancient(freezerHashTable, 0); err != nil {
+				frdb.Close()
+				return nil, errors.Wrap(err, "read ancient genesis")
+			} else if common.BytesToHash(frgenesis) != kvgenesis {
+				frdb.Close()
+				return nil, fmt.Errorf("genesis mismatch: %#x (leveldb) != %#x (ancients)", kvgenesis, frgenesis)
+			}
+		}
+	} else if frozen, _ := frdb.Ancients(); frozen > 0 {
+		frdb.Close()
+		return nil, errors.New("ancient chain segments already extracted from another database")
+	}
+	go frdb.freeze(db)
+
+	return &freezerdb{
+		KeyValueStore: db,
+		AncientStore:  frdb,
+	}, nil
+}
@@ -0,0 +1,189 @@
+package rawdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+func TestFreezerTable_AppendRetrieve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	items := [][]byte{[]byte("first"), {}, bytes.Repeat([]byte("third"), 100)}
+	for _, noCompression := range []bool{true, false} {
+		tab, err := newTable(dir, "test", noCompression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, item := range items {
+			if err := tab.Append(uint64(i), item); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tab.Append(10, items[0]); err != errOutOrderInsertion {
+			t.Errorf("unexpected error: %v / %v", err, errOutOrderInsertion)
+		}
+		for i, item := range items {
+			got, err := tab.Retrieve(uint64(i))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, item) {
+				t.Errorf("item %v: %x / %x", i, got, item)
+			}
+		}
+		if _, err := tab.Retrieve(uint64(len(items))); err != errOutOfBounds {
+			t.Errorf("unexpected error: %v / %v", err, errOutOfBounds)
+		}
+		if err := tab.truncate(1); err != nil {
+			t.Fatal(err)
+		}
+		if tab.has(1) || !tab.has(0) {
+			t.Errorf("table not truncated")
+		}
+		tab.Close()
+	}
+}
+
+func TestFreezerTable_Repair(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tab, err := newTable(dir, "test", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := tab.Append(uint64(i), []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tab.Close()
+
+	// Lose the data of the last item and write a partial index entry
+	if err := os.Truncate(filepath.Join(dir, "test.rdat"), 2); err != nil {
+		t.Fatal(err)
+	}
+	index, err := os.OpenFile(filepath.Join(dir, "test.idx"), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index.Write([]byte{0, 0, 1})
+	index.Close()
+
+	tab, err = newTable(dir, "test", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tab.Close()
+	if tab.items != 2 {
+		t.Fatalf("unexpected items after repair: %v / %v", tab.items, 2)
+	}
+	if got, err := tab.Retrieve(1); err != nil || !bytes.Equal(got, []byte{1}) {
+		t.Errorf("unexpected item: %x, %v", got, err)
+	}
+	if err := tab.Append(2, []byte{2}); err != nil {
+		t.Errorf("append after repair: %v", err)
+	}
+}
+
+func TestFreezer_Freeze(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kvdb := rawdb.NewMemoryDatabase()
+	var blocks []*types.Block
+	parent := common.Hash{}
+	for i := int64(0); i < 10; i++ {
+		header := blockfactory.NewTestHeader().With().Number(big.NewInt(i)).ParentHash(parent).Header()
+		block := types.NewBlockWithHeader(header)
+		WriteBlock(kvdb, block)
+		WriteReceipts(kvdb, block.Hash(), block.NumberU64(), types.Receipts{{CumulativeGasUsed: uint64(i)}})
+		WriteCanonicalHash(kvdb, block.Hash(), block.NumberU64())
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	WriteHeadBlockHash(kvdb, parent)
+	// a side chain block at a frozen height
+	side := types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+		Number(big.NewInt(3)).Extra([]byte("side")).Header())
+	WriteBlock(kvdb, side)
+
+	db, err := NewDatabaseWithFreezer(kvdb, dir, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Blocks 0 to 5 are frozen and then deleted from the key-value store
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if has, _ := kvdb.Has(headerHashKey(5)); !has {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("blocks not frozen")
+		}
+	}
+	if frozen, _ := db.Ancients(); frozen != 6 {
+		t.Errorf("unexpected frozen blocks: %v / %v", frozen, 6)
+	}
+	if has, _ := kvdb.Has(headerKey(3, side.Hash())); has {
+		t.Errorf("side chain block not deleted")
+	}
+	if has, _ := kvdb.Has(headerHashKey(0)); !has {
+		t.Errorf("genesis block deleted")
+	}
+	if has, _ := kvdb.Has(headerHashKey(6)); !has {
+		t.Errorf("recent block deleted")
+	}
+
+	// The frozen blocks are read transparently
+	for _, block := range blocks {
+		hash, number := block.Hash(), block.NumberU64()
+		if got := ReadCanonicalHash(db, number); got != hash {
+			t.Errorf("block %v: canonical hash %x / %x", number, got, hash)
+		}
+		if got := ReadBlock(db, hash, number); got == nil || got.Hash() != hash {
+			t.Errorf("block %v: block not found", number)
+		}
+		if !HasHeader(db, hash, number) || !HasBody(db, hash, number) {
+			t.Errorf("block %v: header or body missing", number)
+		}
+		if got := ReadReceipts(db, hash, number); len(got) != 1 || got[0].CumulativeGasUsed != number {
+			t.Errorf("block %v: unexpected receipts %v", number, got)
+		}
+	}
+	if HasHeader(db, side.Hash(), 3) || ReadHeader(db, side.Hash(), 3) != nil {
+		t.Errorf("side chain block read from the ancients")
+	}
+
+	db.(*freezerdb).AncientStore.Close()
+
+	// Reopening checks the ancients belong to the key-value store
+	if _, err := NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), dir, 4); err == nil {
+		t.Errorf("ancients of another database accepted")
+	}
+	db, err = NewDatabaseWithFreezer(kvdb, dir, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.(*freezerdb).AncientStore.Close()
+}
@@ -48,6 +48,7 @@ var (
 	shardStatePrefix             = []byte("ss") // shardStatePrefix + num (uint64 big endian) + hash -> shardState
 	lastCommitsKey               = []byte("LastCommits")
 	blockCommitSigPrefix         = []byte("block-sig-")
+	badBlockKey                  = []byte("InvalidBlock")     // list of the last rejected blocks
 	pendingCrosslinkKey          = []byte("pendingCL")        // prefix for shard last pending crosslink
 	pendingSlashingKey           = []byte("pendingSC")        // prefix for shard last pending slashing record
 	preimagePrefix               = []byte("secure-key-")      // preimagePrefix + hash -> preimage
@@ -74,8 +75,62 @@ var (
 	currentRewardGivenOutPrefix = []byte("blk-rwd-")
 	// key of SnapdbInfo
 	snapdbInfoKey = []byte("SnapdbInfo")
+
+	// snapshotRootKey tracks the hash of the last flat state snapshot.
+	snapshotRootKey = []byte("SnapshotRoot")
+	// snapshotJournalKey tracks the in-memory diff layers across restarts.
+	snapshotJournalKey = []byte("SnapshotJournal")
+	// snapshotGeneratorKey tracks the progress of the snapshot generation across restarts.
+	snapshotGeneratorKey = []byte("SnapshotGenerator")
+
+	// SnapshotAccountPrefix + account hash -> account trie value
+	SnapshotAccountPrefix = []byte("a")
+	// SnapshotStoragePrefix + account hash + storage hash -> storage trie value
+	SnapshotStoragePrefix = []byte("o")
+)
+
+const (
+	// freezerHashTable indicates the name of the freezer canonical hash table.
+	freezerHashTable = "hashes"
+
+	// freezerHeaderTable indicates the name of the freezer header table.
+	freezerHeaderTable = "headers"
+
+	// freezerBodiesTable indicates the name of the freezer block body table.
+	freezerBodiesTable = "bodies"
+
+	// freezerReceiptTable indicates the name of the freezer receipts table.
+	freezerReceiptTable = "receipts"
+
+	// freezerDifficultyTable indicates the name of the freezer total difficulty table.
+	freezerDifficultyTable = "diffs"
 )
 
+// freezerNoCompression contains the compression setting of the freezer tables,
+// the hashes being incompressible and the difficulties too small to compress.
+var freezerNoCompression = map[string]bool{
+	freezerHashTable:       true,
+	freezerHeaderTable:     false,
+	freezerBodiesTable:     false,
+	freezerReceiptTable:    false,
+	freezerDifficultyTable: true,
+}
+
+// accountSnapshotKey = SnapshotAccountPrefix + hash
+func accountSnapshotKey(hash common.Hash) []byte {
+	return append(append([]byte{}, SnapshotAccountPrefix...), hash.Bytes()...)
+}
+
+// storageSnapshotKey = SnapshotStoragePrefix + account hash + storage hash
+func storageSnapshotKey(accountHash, storageHash common.Hash) []byte {
+	return append(storageSnapshotsKey(accountHash), storageHash.Bytes()...)
+}
+
+// storageSnapshotsKey = SnapshotStoragePrefix + account hash
+func storageSnapshotsKey(accountHash common.Hash) []byte {
+	return append(append([]byte{}, SnapshotStoragePrefix...), accountHash.Bytes()...)
+}
+
 // TxLookupEntry is a positional metadata to help looking up the data content of
 // a transaction or receipt given only its hash.
 type TxLookupEntry struct {
@@ -22,6 +22,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	lru "github.com/hashicorp/golang-lru"
 )
 
@@ -56,6 +57,20 @@ type Database interface {
 
 	// TrieDB retrieves the low level trie database used for data storage.
 	TrieDB() *trie.Database
+
+	// Snapshots retrieves the flat state snapshot tree, or nil if the state is
+	// not snapshotted.
+	Snapshots() *snapshot.Tree
+
+	// SetSnapshots sets the flat state snapshot tree used to serve the reads.
+	SetSnapshots(snaps *snapshot.Tree)
+
+	// Fork retrieves the upstream state the accounts and storage slots missing
+	// from the local state are fetched from, or nil if the state is not forked.
+	Fork() Fork
+
+	// SetFork sets the upstream state of a forked chain.
+	SetFork(fork Fork)
 }
 
 // Trie is a Ethereum Merkle Patricia trie.
@@ -124,6 +139,8 @@ func NewDatabaseWithCache(db ethdb.Database, cache int) Database {
 type cachingDB struct {
 	db            *trie.Database
 	codeSizeCache *lru.Cache
+	snaps         *snapshot.Tree
+	fork          Fork
 }
 
 // OpenTrie opens the main account trie at a specific root hash.
@@ -168,3 +185,23 @@ func (db *cachingDB) ContractCodeSize(addrHash, codeHash common.Hash) (int, erro
 func (db *cachingDB) TrieDB() *trie.Database {
 	return db.db
 }
+
+// Snapshots retrieves the flat state snapshot tree, if any.
+func (db *cachingDB) Snapshots() *snapshot.Tree {
+	return db.snaps
+}
+
+// SetSnapshots sets the flat state snapshot tree.
+func (db *cachingDB) SetSnapshots(snaps *snapshot.Tree) {
+	db.snaps = snaps
+}
+
+// Fork retrieves the upstream state of a forked chain, if any.
+func (db *cachingDB) Fork() Fork {
+	return db.fork
+}
+
+// SetFork sets the upstream state of a forked chain.
+func (db *cachingDB) SetFork(fork Fork) {
+	db.fork = fork
+}
@@ -0,0 +1,19 @@
+package state
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Fork is the upstream state of a forked chain, pinned at the fork block. The
+// accounts and storage slots never written locally are lazily fetched from it,
+// and the written ones are kept in the local state, zeroed ones included.
+type Fork interface {
+	// Account returns the nonce, balance and code of the account, all empty if
+	// the account does not exist upstream.
+	Account(addr common.Address) (nonce uint64, balance *big.Int, code []byte, err error)
+
+	// Storage returns the value of the storage slot of the account.
+	Storage(addr common.Address, key common.Hash) (common.Hash, error)
+}
@@ -0,0 +1,106 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+type testForkAccount struct {
+	nonce   uint64
+	balance *big.Int
+	code    []byte
+	storage map[common.Hash]common.Hash
+}
+
+// testFork is an in-memory upstream state counting its reads.
+type testFork struct {
+	accounts map[common.Address]testForkAccount
+	reads    int
+}
+
+func (f *testFork) Account(addr common.Address) (uint64, *big.Int, []byte, error) {
+	f.reads++
+	acc, ok := f.accounts[addr]
+	if !ok {
+		return 0, new(big.Int), nil, nil
+	}
+	return acc.nonce, new(big.Int).Set(acc.balance), acc.code, nil
+}
+
+func (f *testFork) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
+	f.reads++
+	return f.accounts[addr].storage[key], nil
+}
+
+func TestForkedState(t *testing.T) {
+	var (
+		contract = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		eoa      = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		missing  = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		slot1    = common.HexToHash("0x01")
+		slot2    = common.HexToHash("0x02")
+		fork     = &testFork{accounts: map[common.Address]testForkAccount{
+			contract: {
+				nonce:   1,
+				balance: big.NewInt(10),
+				code:    []byte{0x60, 0x00},
+				storage: map[common.Hash]common.Hash{slot1: common.HexToHash("0x2a"), slot2: common.HexToHash("0x2b")},
+			},
+			eoa: {nonce: 5, balance: big.NewInt(100)},
+		}}
+	)
+	sdb := NewDatabase(rawdb.NewMemoryDatabase())
+	sdb.SetFork(fork)
+	state, _ := New(common.Hash{}, sdb)
+
+	// The missing accounts and slots are fetched from the fork
+	if got := state.GetBalance(eoa); got.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("unexpected balance: %v / %v", got, 100)
+	}
+	if got := state.GetNonce(contract); got != 1 {
+		t.Errorf("unexpected nonce: %v / %v", got, 1)
+	}
+	if got := state.GetState(contract, slot1); got != common.HexToHash("0x2a") {
+		t.Errorf("unexpected slot: %x", got)
+	}
+	if state.Exist(missing) {
+		t.Errorf("account missing upstream exists")
+	}
+
+	// Zeroed slots and deleted accounts are not fetched again after a commit
+	state.SetState(contract, slot1, common.Hash{})
+	state.AddBalance(eoa, big.NewInt(1))
+	state.Suicide(eoa)
+	root, err := state.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+
+	state, _ = New(root, sdb)
+	reads := fork.reads
+	if got := state.GetState(contract, slot1); got != (common.Hash{}) {
+		t.Errorf("zeroed slot fetched from the fork: %x", got)
+	}
+	if got := state.GetBalance(eoa); got.Sign() != 0 {
+		t.Errorf("deleted account fetched from the fork: balance %v", got)
+	}
+	if got := state.GetCode(contract); len(got) != 2 {
+		t.Errorf("unexpected code: %x", got)
+	}
+	if fork.reads != reads {
+		t.Errorf("unexpected fork reads: %v / %v", fork.reads, reads)
+	}
+	// The slots never written locally are still fetched
+	if got := state.GetState(contract, slot2); got != common.HexToHash("0x2b") {
+		t.Errorf("unexpected slot: %x", got)
+	}
+	if fork.reads != reads+1 {
+		t.Errorf("unexpected fork reads: %v / %v", fork.reads, reads+1)
+	}
+}
@@ -0,0 +1,46 @@
+package pruner
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	bloomfilter "github.com/steakknife/bloomfilter"
+)
+
+// stateBloomHasher is a wrapper around a byte blob to satisfy the interface API
+// requirements of the bloom library used. It's used to convert a trie hash or
+// contract code hash into a 64 bit mini hash.
+type stateBloomHasher []byte
+
+func (f stateBloomHasher) Write(p []byte) (n int, err error) { panic("not implemented") }
+func (f stateBloomHasher) Sum(b []byte) []byte               { panic("not implemented") }
+func (f stateBloomHasher) Reset()                            { panic("not implemented") }
+func (f stateBloomHasher) BlockSize() int                    { panic("not implemented") }
+func (f stateBloomHasher) Size() int                         { return 8 }
+func (f stateBloomHasher) Sum64() uint64                     { return binary.BigEndian.Uint64(f) }
+
+// stateBloom is a bloom filter used during the state pruning to mark the trie
+// nodes and contract codes of the retained states. The entries not in the bloom
+// are garbage and deleted, while false positives only leave some garbage.
+type stateBloom struct {
+	bloom *bloomfilter.Filter
+}
+
+// newStateBloomWithSize creates a state bloom of the given size in megabytes.
+func newStateBloomWithSize(size uint64) (*stateBloom, error) {
+	bloom, err := bloomfilter.New(size*1024*1024*8, 4)
+	if err != nil {
+		return nil, errors.Wrap(err, "create state bloom")
+	}
+	return &stateBloom{bloom: bloom}, nil
+}
+
+// Put marks the given trie node hash or contract code hash.
+func (bloom *stateBloom) Put(key []byte) {
+	bloom.bloom.Add(stateBloomHasher(key))
+}
+
+// Contain returns whether the given key might be marked.
+func (bloom *stateBloom) Contain(key []byte) bool {
+	return bloom.bloom.Contains(stateBloomHasher(key))
+}
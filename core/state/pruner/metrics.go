@@ -0,0 +1,73 @@
+package pruner
+
+import (
+	prom "github.com/harmony-one/harmony/api/service/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	prom.PromRegistry().MustRegister(
+		prunerRunning,
+		markedNodeCount,
+		sweptNodeCount,
+		sweptNodeSize,
+		sweepProgress,
+		pruneUsedTime,
+	)
+}
+
+var (
+	prunerRunning = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "hmy",
+			Subsystem: "state_pruner",
+			Name:      "running",
+			Help:      "whether the state pruner is running",
+		},
+	)
+
+	markedNodeCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "state_pruner",
+			Name:      "marked_node_count",
+			Help:      "number of marked trie nodes and contract codes of the retained states",
+		},
+	)
+
+	sweptNodeCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "state_pruner",
+			Name:      "swept_node_count",
+			Help:      "number of deleted trie nodes and contract codes",
+		},
+	)
+
+	sweptNodeSize = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "state_pruner",
+			Name:      "swept_node_size",
+			Help:      "sum of the size of deleted trie nodes and contract codes in bytes",
+		},
+	)
+
+	sweepProgress = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "hmy",
+			Subsystem: "state_pruner",
+			Name:      "sweep_progress",
+			Help:      "progress of the sweep of the database in percent",
+		},
+	)
+
+	pruneUsedTime = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "state_pruner",
+			Name:      "used_time",
+			Help:      "sum of state pruning time in ms",
+		},
+	)
+)
@@ -0,0 +1,376 @@
+package pruner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultBloomSize is the default size of the bloom filter marking the
+	// retained states in megabytes.
+	DefaultBloomSize = 1024
+	// sweepBatchSize is the number of garbage keys deleted at once while holding
+	// the write lock against the concurrent trie node writes.
+	sweepBatchSize = 10000
+	// logInterval is the interval of the progress logs.
+	logInterval = 8 * time.Second
+)
+
+var (
+	// emptyRoot is the known root hash of an empty trie.
+	emptyRoot = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+	// emptyCode is the known hash of the empty EVM bytecode.
+	emptyCode = crypto.Keccak256(nil)
+
+	// ErrPruneRunning is returned when a prune is started while another one is
+	// still in progress.
+	ErrPruneRunning = errors.New("state pruning is already running")
+)
+
+// Pruner deletes the trie nodes and contract codes not reachable from a set of
+// retained state roots. The retained states are marked in a bloom filter and
+// then all the other content addressed entries of the database are swept.
+//
+// The trie nodes written during a prune are marked as well when they are written
+// through the database returned by Database, so the pruning can run against a
+// live chain.
+type Pruner struct {
+	running int32 // running must be called atomically
+
+	db        ethdb.Database
+	bloomSize uint64
+
+	lock  sync.RWMutex // lock guards the sweep against the concurrent writes
+	bloom *stateBloom
+}
+
+// NewPruner creates a state pruner on the given database, using a bloom filter
+// of bloomSize megabytes to mark the retained states.
+func NewPruner(db ethdb.Database, bloomSize uint64) *Pruner {
+	return &Pruner{
+		db:        db,
+		bloomSize: bloomSize,
+	}
+}
+
+// Database returns the database the trie nodes of the chain must be written to,
+// so that the nodes committed while pruning are never swept.
+func (p *Pruner) Database() ethdb.Database {
+	return &markingDatabase{Database: p.db, pruner: p}
+}
+
+// Running returns whether a prune is in progress.
+func (p *Pruner) Running() bool {
+	return atomic.LoadInt32(&p.running) == 1
+}
+
+// mark adds the key to the bloom of the running prune, if any.
+func (p *Pruner) mark(key []byte) {
+	if len(key) != common.HashLength {
+		return
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.bloom != nil {
+		p.bloom.Put(key)
+	}
+}
+
+// Prune deletes all the trie nodes and contract codes which are not part of the
+// given state roots. The first root is the base state and must be complete; the
+// other roots are marked against it and skipped when unavailable.
+func (p *Pruner) Prune(triedb *trie.Database, roots []common.Hash) error {
+	if len(roots) == 0 {
+		return errors.New("no state root to retain")
+	}
+	if !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+		return ErrPruneRunning
+	}
+	defer atomic.StoreInt32(&p.running, 0)
+
+	prunerRunning.Set(1)
+	defer prunerRunning.Set(0)
+
+	bloom, err := newStateBloomWithSize(p.bloomSize)
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	p.bloom = bloom
+	p.lock.Unlock()
+	defer func() {
+		p.lock.Lock()
+		p.bloom = nil
+		p.lock.Unlock()
+	}()
+
+	start := time.Now()
+	marked, err := p.markStates(triedb, roots)
+	if err != nil {
+		return err
+	}
+	utils.Logger().Info().
+		Int("roots", len(roots)).
+		Uint64("marked", marked).
+		Dur("cost", time.Since(start)).
+		Msg("[StatePruner] marked retained states")
+
+	sweepStart := time.Now()
+	count, size, err := p.sweep()
+	if err != nil {
+		return err
+	}
+	pruneUsedTime.Add(float64(time.Since(start).Milliseconds()))
+
+	utils.Logger().Info().
+		Str("base", roots[0].Hex()).
+		Uint64("deleted", count).
+		Uint64("deletedSize", size).
+		Dur("sweepCost", time.Since(sweepStart)).
+		Dur("cost", time.Since(start)).
+		Msg("[StatePruner] pruned state")
+	return nil
+}
+
+// markStates marks the full base state and the difference of the other states
+// against it. The other states are only kept in memory on a non-archival chain,
+// so a state garbage collected while being marked is skipped: whatever it left
+// on disk is not a complete state anymore.
+func (p *Pruner) markStates(triedb *trie.Database, roots []common.Hash) (uint64, error) {
+	base, err := trie.New(roots[0], triedb)
+	if err != nil {
+		return 0, errors.Wrapf(err, "open base state %x", roots[0])
+	}
+	var marked uint64
+	for i, root := range roots {
+		count, err := p.markState(triedb, base, root, i == 0)
+		marked += count
+		if err != nil {
+			if i == 0 {
+				return marked, err
+			}
+			utils.Logger().Debug().Err(err).Str("root", root.Hex()).
+				Msg("[StatePruner] retained state not available, skipped")
+			continue
+		}
+		utils.Logger().Info().
+			Int("root", i+1).
+			Int("roots", len(roots)).
+			Uint64("marked", marked).
+			Msg("[StatePruner] marked retained state")
+	}
+	markedNodeCount.Add(float64(marked))
+	return marked, nil
+}
+
+// markState marks the trie nodes and contract codes of the state which are not
+// in the base state, or all of them if the state is the base.
+func (p *Pruner) markState(triedb *trie.Database, base *trie.Trie, root common.Hash, isBase bool) (uint64, error) {
+	var (
+		marked  uint64
+		logged  = time.Now()
+		emptyIt = func() trie.NodeIterator {
+			tr, _ := trie.New(common.Hash{}, triedb)
+			return tr.NodeIterator(nil)
+		}
+		it trie.NodeIterator
+	)
+	if isBase {
+		it = base.NodeIterator(nil)
+	} else {
+		tr, err := trie.New(root, triedb)
+		if err != nil {
+			return marked, errors.Wrapf(err, "open state %x", root)
+		}
+		it, _ = trie.NewDifferenceIterator(base.NodeIterator(nil), tr.NodeIterator(nil))
+	}
+	for it.Next(true) {
+		if time.Since(logged) > logInterval {
+			utils.Logger().Info().
+				Str("root", root.Hex()).
+				Uint64("marked", marked).
+				Msg("[StatePruner] marking retained state")
+			logged = time.Now()
+		}
+		if hash := it.Hash(); hash != (common.Hash{}) {
+			p.bloom.Put(hash[:])
+			marked++
+		}
+		if !it.Leaf() {
+			continue
+		}
+		var acc state.Account
+		if err := rlp.DecodeBytes(it.LeafBlob(), &acc); err != nil {
+			return marked, errors.Wrapf(err, "decode account of state %x", root)
+		}
+		if !bytes.Equal(acc.CodeHash, emptyCode) {
+			p.bloom.Put(acc.CodeHash)
+			marked++
+		}
+		if acc.Root == emptyRoot {
+			continue
+		}
+		// Storage tries are marked against the storage of the same account
+		// in the base state, which is marked in full.
+		baseIt := emptyIt()
+		if !isBase {
+			baseAcc, err := readAccount(base, it.LeafKey())
+			if err != nil {
+				return marked, err
+			}
+			if baseAcc != nil && baseAcc.Root == acc.Root {
+				continue
+			}
+			if baseAcc != nil && baseAcc.Root != emptyRoot {
+				baseSt, err := trie.New(baseAcc.Root, triedb)
+				if err != nil {
+					return marked, errors.Wrapf(err, "open base storage %x", baseAcc.Root)
+				}
+				baseIt = baseSt.NodeIterator(nil)
+			}
+		}
+		st, err := trie.New(acc.Root, triedb)
+		if err != nil {
+			return marked, errors.Wrapf(err, "open storage %x of state %x", acc.Root, root)
+		}
+		stIt, _ := trie.NewDifferenceIterator(baseIt, st.NodeIterator(nil))
+		for stIt.Next(true) {
+			if hash := stIt.Hash(); hash != (common.Hash{}) {
+				p.bloom.Put(hash[:])
+				marked++
+			}
+		}
+		if err := stIt.Error(); err != nil {
+			return marked, errors.Wrapf(err, "iterate storage %x of state %x", acc.Root, root)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return marked, errors.Wrapf(err, "iterate state %x", root)
+	}
+	return marked, nil
+}
+
+// readAccount reads the account of the given hashed address from the state trie.
+func readAccount(tr *trie.Trie, key []byte) (*state.Account, error) {
+	blob, err := tr.TryGet(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read base account %x", key)
+	}
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	var acc state.Account
+	if err := rlp.DecodeBytes(blob, &acc); err != nil {
+		return nil, errors.Wrapf(err, "decode base account %x", key)
+	}
+	return &acc, nil
+}
+
+// sweep deletes all the trie nodes and contract codes not marked in the bloom.
+// Both are stored under the hash of their content, which tells them apart from
+// the other entries of the database.
+func (p *Pruner) sweep() (count, size uint64, err error) {
+	var (
+		it      = p.db.NewIterator()
+		garbage = make([][]byte, 0, sweepBatchSize)
+		sizes   = make([]int, 0, sweepBatchSize)
+		logged  = time.Now()
+	)
+	defer it.Release()
+
+	flush := func() error {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		batch := p.db.NewBatch()
+		for i, key := range garbage {
+			// re-check the keys written since they were collected
+			if p.bloom.Contain(key) {
+				continue
+			}
+			if err := batch.Delete(key); err != nil {
+				return err
+			}
+			count++
+			size += uint64(sizes[i])
+		}
+		garbage, sizes = garbage[:0], sizes[:0]
+		return batch.Write()
+	}
+
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		if len(key) != common.HashLength || p.bloom.Contain(key) {
+			continue
+		}
+		if !bytes.Equal(crypto.Keccak256(value), key) {
+			continue
+		}
+		garbage = append(garbage, common.CopyBytes(key))
+		sizes = append(sizes, len(key)+len(value))
+
+		if len(garbage) >= sweepBatchSize {
+			if err := flush(); err != nil {
+				return count, size, errors.Wrap(err, "delete pruned state")
+			}
+			progress := float64(binary.BigEndian.Uint16(key)) * 100 / 65536
+			sweepProgress.Set(progress)
+			if time.Since(logged) > logInterval {
+				utils.Logger().Info().
+					Uint64("deleted", count).
+					Float64("progress", progress).
+					Msg("[StatePruner] sweeping pruned state")
+				logged = time.Now()
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return count, size, errors.Wrap(err, "iterate database")
+	}
+	if err := flush(); err != nil {
+		return count, size, errors.Wrap(err, "delete pruned state")
+	}
+	sweepProgress.Set(100)
+	sweptNodeCount.Add(float64(count))
+	sweptNodeSize.Add(float64(size))
+	return count, size, nil
+}
+
+// markingDatabase marks the trie nodes written during a prune.
+type markingDatabase struct {
+	ethdb.Database
+	pruner *Pruner
+}
+
+func (db *markingDatabase) Put(key []byte, value []byte) error {
+	db.pruner.mark(key)
+	return db.Database.Put(key, value)
+}
+
+func (db *markingDatabase) NewBatch() ethdb.Batch {
+	return &markingBatch{Batch: db.Database.NewBatch(), pruner: db.pruner}
+}
+
+// markingBatch marks the trie nodes written in a batch during a prune.
+type markingBatch struct {
+	ethdb.Batch
+	pruner *Pruner
+}
+
+func (b *markingBatch) Put(key []byte, value []byte) error {
+	b.pruner.mark(key)
+	return b.Batch.Put(key, value)
+}
@@ -0,0 +1,141 @@
+package pruner
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethrawdb "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/core/state"
+)
+
+var (
+	testAccount1 = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testAccount2 = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testSlot     = common.HexToHash("0x01")
+)
+
+func TestPruner_Prune(t *testing.T) {
+	db := ethrawdb.NewMemoryDatabase()
+	pruner := NewPruner(db, 1)
+	sdb := state.NewDatabase(pruner.Database())
+
+	var roots []common.Hash
+	parent := common.Hash{}
+	for i := 1; i <= 4; i++ {
+		root := commitState(t, sdb, parent, int64(i))
+		roots = append(roots, root)
+		parent = root
+	}
+	before := countNodes(db)
+
+	// retain the latest state and the second one
+	if err := pruner.Prune(sdb.TrieDB(), []common.Hash{roots[3], roots[1]}); err != nil {
+		t.Fatal(err)
+	}
+	if after := countNodes(db); after >= before {
+		t.Errorf("no node pruned: %v / %v", after, before)
+	}
+	// a fresh database drops the in-memory caches of the trie nodes
+	fresh := state.NewDatabase(db)
+	for _, i := range []int{1, 3} {
+		if err := checkState(fresh, roots[i], int64(i+1)); err != nil {
+			t.Errorf("retained state %v: %v", i, err)
+		}
+	}
+	if err := checkState(fresh, roots[0], 1); err == nil {
+		t.Errorf("pruned state still complete")
+	}
+}
+
+func TestPruner_ConcurrentWrites(t *testing.T) {
+	db := ethrawdb.NewMemoryDatabase()
+	pruner := NewPruner(db, 1)
+	sdb := state.NewDatabase(pruner.Database())
+
+	root := commitState(t, sdb, common.Hash{}, 1)
+
+	// a state committed while the prune is running must not be swept
+	bloom, err := newStateBloomWithSize(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pruner.bloom = bloom
+	next := commitState(t, sdb, root, 2)
+	if _, err := pruner.markStates(sdb.TrieDB(), []common.Hash{root}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := pruner.sweep(); err != nil {
+		t.Fatal(err)
+	}
+	pruner.bloom = nil
+
+	if err := checkState(state.NewDatabase(db), next, 2); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPruner_Running(t *testing.T) {
+	pruner := NewPruner(ethrawdb.NewMemoryDatabase(), 1)
+	pruner.running = 1
+	if err := pruner.Prune(nil, []common.Hash{{}}); err != ErrPruneRunning {
+		t.Errorf("unexpected error: %v / %v", err, ErrPruneRunning)
+	}
+}
+
+// commitState commits a state on top of the parent where both accounts have
+// the given balance and the second one stores it in a slot, and flushes it to
+// the disk.
+func commitState(t *testing.T, sdb state.Database, parent common.Hash, balance int64) common.Hash {
+	statedb, err := state.New(parent, sdb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb.SetBalance(testAccount1, big.NewInt(balance))
+	statedb.SetBalance(testAccount2, big.NewInt(balance))
+	statedb.SetCode(testAccount2, []byte{byte(balance)})
+	statedb.SetState(testAccount2, testSlot, common.BigToHash(big.NewInt(balance)))
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// checkState checks the state of the root is complete with the given balance.
+func checkState(sdb state.Database, root common.Hash, balance int64) error {
+	statedb, err := state.New(root, sdb)
+	if err != nil {
+		return err
+	}
+	for _, addr := range []common.Address{testAccount1, testAccount2} {
+		if got := statedb.GetBalance(addr); got.Int64() != balance {
+			return fmt.Errorf("account %x balance %v / %v", addr, got, balance)
+		}
+	}
+	if got := statedb.GetCode(testAccount2); len(got) != 1 || got[0] != byte(balance) {
+		return fmt.Errorf("account %x code %x / %x", testAccount2, got, balance)
+	}
+	if got := statedb.GetState(testAccount2, testSlot); got.Big().Int64() != balance {
+		return fmt.Errorf("slot %x value %x / %x", testSlot, got, balance)
+	}
+	return statedb.Error()
+}
+
+func countNodes(db ethdb.Iteratee) int {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var count int
+	for it.Next() {
+		if len(it.Key()) == common.HashLength {
+			count++
+		}
+	}
+	return count
+}
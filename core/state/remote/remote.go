@@ -0,0 +1,156 @@
+// Package remote implements the upstream state of a forked chain, served by the
+// RPC endpoint of a Harmony node.
+package remote
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/eth/rpc"
+)
+
+// requestTimeout is the timeout of a request to the upstream node.
+const requestTimeout = 30 * time.Second
+
+var _ state.Fork = (*State)(nil)
+
+// State is the state of an upstream node pinned at a block, fetched through the
+// hmy_getBalance, hmy_getTransactionCount, hmy_getCode and hmy_getStorageAt
+// calls. The upstream state never changes, so every fetched value is cached.
+type State struct {
+	client *rpc.Client
+	block  uint64
+
+	lock     sync.Mutex
+	accounts map[common.Address]*account
+	storage  map[common.Address]map[common.Hash]common.Hash
+}
+
+type account struct {
+	nonce   uint64
+	balance *big.Int
+	code    []byte
+}
+
+// New connects to the RPC endpoint of the upstream node and pins its state at
+// the given block, or at its latest block if zero.
+func New(url string, block uint64) (*State, error) {
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "dial fork upstream %s", url)
+	}
+	if block == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		var latest hexutil.Uint64
+		if err := client.CallContext(ctx, &latest, "hmy_blockNumber"); err != nil {
+			client.Close()
+			return nil, errors.Wrap(err, "get fork upstream block number")
+		}
+		block = uint64(latest)
+	}
+	return &State{
+		client:   client,
+		block:    block,
+		accounts: make(map[common.Address]*account),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+	}, nil
+}
+
+// Block returns the number of the upstream block the state is pinned at.
+func (s *State) Block() uint64 {
+	return s.block
+}
+
+// Close closes the connection to the upstream node.
+func (s *State) Close() {
+	s.client.Close()
+}
+
+// Account implements state.Fork.
+func (s *State) Account(addr common.Address) (uint64, *big.Int, []byte, error) {
+	acc, err := s.account(addr)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return acc.nonce, new(big.Int).Set(acc.balance), common.CopyBytes(acc.code), nil
+}
+
+func (s *State) account(addr common.Address) (*account, error) {
+	s.lock.Lock()
+	acc, ok := s.accounts[addr]
+	s.lock.Unlock()
+	if ok {
+		return acc, nil
+	}
+
+	var (
+		balance hexutil.Big
+		nonce   hexutil.Uint64
+		code    hexutil.Bytes
+		block   = hexutil.EncodeUint64(s.block)
+	)
+	batch := []rpc.BatchElem{
+		{Method: "hmy_getBalance", Args: []interface{}{addr.Hex(), block}, Result: &balance},
+		{Method: "hmy_getTransactionCount", Args: []interface{}{addr.Hex(), block}, Result: &nonce},
+		{Method: "hmy_getCode", Args: []interface{}{addr.Hex(), block}, Result: &code},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	if err := s.client.BatchCallContext(ctx, batch); err != nil {
+		return nil, errors.Wrapf(err, "fetch fork account %x", addr)
+	}
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, errors.Wrapf(elem.Error, "fetch fork account %x: %s", addr, elem.Method)
+		}
+	}
+	acc = &account{nonce: uint64(nonce), balance: balance.ToInt(), code: code}
+
+	s.lock.Lock()
+	s.accounts[addr] = acc
+	s.lock.Unlock()
+	return acc, nil
+}
+
+// Storage implements state.Fork.
+func (s *State) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
+	s.lock.Lock()
+	value, ok := s.storage[addr][key]
+	s.lock.Unlock()
+	if ok {
+		return value, nil
+	}
+	// The storage of the accounts missing upstream is empty
+	acc, err := s.account(addr)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if acc.nonce != 0 || acc.balance.Sign() != 0 || len(acc.code) != 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		var result hexutil.Bytes
+		err := s.client.CallContext(ctx, &result, "hmy_getStorageAt", addr.Hex(), key.Hex(), hexutil.EncodeUint64(s.block))
+		if err != nil {
+			return common.Hash{}, errors.Wrapf(err, "fetch fork storage %x of account %x", key, addr)
+		}
+		value = common.BytesToHash(result)
+	}
+
+	s.lock.Lock()
+	if s.storage[addr] == nil {
+		s.storage[addr] = make(map[common.Hash]common.Hash)
+	}
+	s.storage[addr][key] = value
+	s.lock.Unlock()
+	return value, nil
+}
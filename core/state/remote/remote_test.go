@@ -0,0 +1,117 @@
+package remote
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/harmony-one/harmony/eth/rpc"
+)
+
+var (
+	testContract = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testMissing  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testSlot     = common.HexToHash("0x01")
+)
+
+// testAPI serves the hmy calls of an upstream node at block 42, counting them.
+type testAPI struct {
+	calls  int
+	blocks []string
+}
+
+func (api *testAPI) BlockNumber() hexutil.Uint64 {
+	return 42
+}
+
+func (api *testAPI) GetBalance(ctx context.Context, addr common.Address, block string) *hexutil.Big {
+	api.calls++
+	api.blocks = append(api.blocks, block)
+	if addr == testContract {
+		return (*hexutil.Big)(big.NewInt(1000))
+	}
+	return (*hexutil.Big)(new(big.Int))
+}
+
+func (api *testAPI) GetTransactionCount(ctx context.Context, addr common.Address, block string) hexutil.Uint64 {
+	api.calls++
+	if addr == testContract {
+		return 1
+	}
+	return 0
+}
+
+func (api *testAPI) GetCode(ctx context.Context, addr common.Address, block string) hexutil.Bytes {
+	api.calls++
+	if addr == testContract {
+		return hexutil.Bytes{0x60, 0x00}
+	}
+	return nil
+}
+
+func (api *testAPI) GetStorageAt(ctx context.Context, addr common.Address, key string, block string) hexutil.Bytes {
+	api.calls++
+	if addr == testContract && common.HexToHash(key) == testSlot {
+		return common.HexToHash("0x2a").Bytes()
+	}
+	return common.Hash{}.Bytes()
+}
+
+func TestState(t *testing.T) {
+	api := &testAPI{}
+	server := rpc.NewServer()
+	if err := server.RegisterName("hmy", api, nil); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	s, err := New(httpServer.URL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if s.Block() != 42 {
+		t.Errorf("unexpected block: %v / %v", s.Block(), 42)
+	}
+
+	nonce, balance, code, err := s.Account(testContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 1 || balance.Cmp(big.NewInt(1000)) != 0 || len(code) != 2 {
+		t.Errorf("unexpected account: nonce %v, balance %v, code %x", nonce, balance, code)
+	}
+	if len(api.blocks) != 1 || api.blocks[0] != "0x2a" {
+		t.Errorf("unexpected requested blocks: %v", api.blocks)
+	}
+	value, err := s.Storage(testContract, testSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != common.HexToHash("0x2a") {
+		t.Errorf("unexpected storage: %x", value)
+	}
+	if api.calls != 4 {
+		t.Errorf("unexpected calls: %v / %v", api.calls, 4)
+	}
+
+	// The fetched values are cached
+	s.Account(testContract)
+	s.Storage(testContract, testSlot)
+	if api.calls != 4 {
+		t.Errorf("unexpected calls: %v / %v", api.calls, 4)
+	}
+
+	// The storage of a missing account is not requested
+	if _, err := s.Storage(testMissing, testSlot); err != nil {
+		t.Fatal(err)
+	}
+	if api.calls != 7 {
+		t.Errorf("unexpected calls: %v / %v", api.calls, 7)
+	}
+}
@@ -0,0 +1,228 @@
+package snapshot
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+var (
+	// emptyRoot is the known root hash of an empty trie.
+	emptyRoot = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+)
+
+// account is the consensus representation of accounts, as stored in the account
+// trie and in the snapshot.
+type account struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     common.Hash
+	CodeHash []byte
+}
+
+// journalGenerator is a disk layer entry containing the generator progress marker.
+type journalGenerator struct {
+	Done   bool // Whether the generator finished creating the snapshot
+	Marker []byte
+}
+
+// generateSnapshot regenerates a brand new snapshot based on an existing state
+// database and head block asynchronously. The snapshot is returned immediately
+// and generation is continued in the background until done.
+func generateSnapshot(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root common.Hash) *diskLayer {
+	// Create a new disk layer with an initialized state marker at zero
+	batch := diskdb.NewBatch()
+	rawdb.WriteSnapshotRoot(batch, root)
+	writeGenerator(batch, false, []byte{})
+	if err := batch.Write(); err != nil {
+		utils.Logger().Error().Err(err).Msg("[snapshot] failed to write initialized state marker")
+	}
+	base := &diskLayer{
+		diskdb:     diskdb,
+		triedb:     triedb,
+		root:       root,
+		cache:      fastcache.New(cache * 1024 * 1024),
+		genMarker:  []byte{}, // Initialized but empty!
+		genPending: make(chan struct{}),
+		genAbort:   make(chan chan struct{}),
+	}
+	go base.generate()
+	return base
+}
+
+// writeGenerator stores the generator progress into the batch.
+func writeGenerator(batch ethdb.KeyValueWriter, done bool, marker []byte) {
+	blob, err := rlp.EncodeToBytes(journalGenerator{Done: done, Marker: marker})
+	if err != nil {
+		panic(err) // Cannot happen, here to catch dev errors
+	}
+	rawdb.WriteSnapshotGenerator(batch, blob)
+}
+
+// wipeSnapshot deletes all the flat accounts and storage slots from the database.
+func wipeSnapshot(db ethdb.KeyValueStore) error {
+	for _, item := range []struct {
+		prefix []byte
+		keyLen int
+	}{
+		{rawdb.SnapshotAccountPrefix, len(rawdb.SnapshotAccountPrefix) + common.HashLength},
+		{rawdb.SnapshotStoragePrefix, len(rawdb.SnapshotStoragePrefix) + 2*common.HashLength},
+	} {
+		batch := db.NewBatch()
+		it := db.NewIteratorWithPrefix(item.prefix)
+		for it.Next() {
+			// Skip the keys of other data sharing the prefix, such as trie nodes
+			if key := it.Key(); len(key) == item.keyLen {
+				batch.Delete(key)
+			}
+			if batch.ValueSize() > ethdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					it.Release()
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		it.Release()
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generate is a background thread that iterates over the state and storage tries,
+// constructing the state snapshot. All the arguments are purely for statistics
+// gathering and logging, since the method surfs the blocks as they arrive, often
+// being restarted.
+func (dl *diskLayer) generate() {
+	dl.lock.RLock()
+	marker := dl.genMarker
+	dl.lock.RUnlock()
+
+	var (
+		logged   = time.Now()
+		accounts uint64
+		slots    uint64
+	)
+	logger := utils.Logger().With().Str("root", dl.root.Hex()).Logger()
+	logger.Info().Hex("at", marker).Msg("[snapshot] generating state snapshot")
+
+	// stop waits for the abort request, after the generator stopped on an error.
+	stop := func(err error) {
+		logger.Warn().Err(err).Msg("[snapshot] state snapshot generation stopped")
+		abort := <-dl.genAbort
+		close(abort)
+	}
+	// A fresh generation starts with wiping the leftovers of the last snapshot
+	if len(marker) == 0 {
+		if err := wipeSnapshot(dl.diskdb); err != nil {
+			stop(err)
+			return
+		}
+	}
+	accTrie, err := trie.NewSecure(dl.root, dl.triedb)
+	if err != nil {
+		// The account trie is missing (GC), surf the chain until one becomes available
+		stop(err)
+		return
+	}
+	var (
+		batch = dl.diskdb.NewBatch()
+		it    = trie.NewIterator(accTrie.NodeIterator(marker))
+	)
+	for it.Next() {
+		accountHash := common.BytesToHash(it.Key)
+		// The account at the marker is already generated
+		if bytes.Equal(accountHash[:], marker) {
+			continue
+		}
+		var acc account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			stop(err)
+			return
+		}
+		rawdb.WriteAccountSnapshot(batch, accountHash, it.Value)
+		accounts++
+
+		// Drop the leftovers of an interrupted run, then generate the storage slots
+		stIt := rawdb.IterateStorageSnapshots(dl.diskdb, accountHash)
+		for stIt.Next() {
+			if key := stIt.Key(); len(key) == len(rawdb.SnapshotStoragePrefix)+2*common.HashLength {
+				batch.Delete(key)
+			}
+		}
+		stIt.Release()
+		if acc.Root != emptyRoot {
+			stTrie, err := trie.NewSecure(acc.Root, dl.triedb)
+			if err != nil {
+				stop(err)
+				return
+			}
+			stIt := trie.NewIterator(stTrie.NodeIterator(nil))
+			for stIt.Next() {
+				rawdb.WriteStorageSnapshot(batch, accountHash, common.BytesToHash(stIt.Key), stIt.Value)
+				slots++
+			}
+			if stIt.Err != nil {
+				stop(stIt.Err)
+				return
+			}
+		}
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			// Flush out the batch along with the progress marker
+			writeGenerator(batch, false, accountHash[:])
+			if err := batch.Write(); err != nil {
+				stop(err)
+				return
+			}
+			batch.Reset()
+
+			dl.lock.Lock()
+			dl.genMarker = accountHash[:]
+			dl.lock.Unlock()
+
+			if time.Since(logged) > 8*time.Second {
+				logger.Info().
+					Hex("at", accountHash[:]).
+					Uint64("accounts", accounts).
+					Uint64("slots", slots).
+					Msg("[snapshot] generating state snapshot")
+				logged = time.Now()
+			}
+			select {
+			case abort := <-dl.genAbort:
+				close(abort)
+				return
+			default:
+			}
+		}
+	}
+	if it.Err != nil {
+		stop(it.Err)
+		return
+	}
+	// Snapshot fully generated, set the marker to nil
+	writeGenerator(batch, true, nil)
+	if err := batch.Write(); err != nil {
+		stop(err)
+		return
+	}
+	logger.Info().
+		Uint64("accounts", accounts).
+		Uint64("slots", slots).
+		Msg("[snapshot] generated state snapshot")
+
+	dl.lock.Lock()
+	dl.genMarker = nil
+	close(dl.genPending)
+	dl.lock.Unlock()
+}
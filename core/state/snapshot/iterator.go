@@ -0,0 +1,264 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/core/rawdb"
+)
+
+// Iterator is an iterator to step over all the accounts or the specific
+// storage in a snapshot which may or may not be composed of multiple layers.
+type Iterator interface {
+	// Next steps the iterator forward one element, returning false if exhausted,
+	// or an error if iteration failed for some reason (e.g. root being iterated
+	// becomes stale and garbage collected).
+	Next() bool
+
+	// Error returns any failure that occurred during iteration, which might have
+	// caused a premature iteration exit (e.g. snapshot stack becoming stale).
+	Error() error
+
+	// Hash returns the hash of the account or storage slot the iterator is
+	// currently at.
+	Hash() common.Hash
+
+	// Release releases associated resources. Release should always succeed and
+	// can be called multiple times without causing error.
+	Release()
+}
+
+// AccountIterator is an iterator to step over all the accounts in a snapshot,
+// which may or may not be composed of multiple layers.
+type AccountIterator interface {
+	Iterator
+
+	// Account returns the RLP encoded account trie value the iterator is
+	// currently at.
+	Account() []byte
+}
+
+// StorageIterator is an iterator to step over the specific storage in a
+// snapshot, which may or may not be composed of multiple layers.
+type StorageIterator interface {
+	Iterator
+
+	// Slot returns the RLP encoded storage trie value the iterator is currently
+	// at.
+	Slot() []byte
+}
+
+// diffEntry is an item of a diff layer collected for iteration, where a nil
+// value means the item is deleted.
+type diffEntry struct {
+	hash  common.Hash
+	value []byte
+}
+
+// layerIterator merges the sorted items of the diff layers with the flat items
+// persisted in the disk layer. The diff items take precedence over the disk ones.
+type layerIterator struct {
+	diffs []diffEntry
+	pos   int
+
+	disk      ethdb.Iterator // Disk iterator, nil if the disk items are hidden
+	diskLen   int            // Length of the keys of the iterated items
+	diskStart int            // Offset of the item hash within the disk keys
+	diskValid bool
+
+	hash  common.Hash
+	value []byte
+}
+
+// newLayerIterator creates an iterator over the given diff items and the disk
+// items of the given prefix. The diff items are sorted by the method.
+func newLayerIterator(items map[common.Hash][]byte, db ethdb.KeyValueStore, prefix []byte, seek common.Hash) *layerIterator {
+	it := &layerIterator{}
+	for hash, value := range items {
+		if bytes.Compare(hash[:], seek[:]) >= 0 {
+			it.diffs = append(it.diffs, diffEntry{hash: hash, value: value})
+		}
+	}
+	sort.Slice(it.diffs, func(i, j int) bool {
+		return bytes.Compare(it.diffs[i].hash[:], it.diffs[j].hash[:]) < 0
+	})
+	if prefix != nil {
+		it.disk = db.NewIteratorWithStart(append(common.CopyBytes(prefix), seek[:]...))
+		it.diskLen = len(prefix) + common.HashLength
+		it.diskStart = len(prefix)
+		it.nextDisk(prefix)
+	}
+	return it
+}
+
+// nextDisk advances the disk iterator to the next item of the given prefix.
+func (it *layerIterator) nextDisk(prefix []byte) {
+	for it.disk.Next() {
+		key := it.disk.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		// Skip the keys of other data sharing the prefix
+		if len(key) == it.diskLen {
+			it.diskValid = true
+			return
+		}
+	}
+	it.diskValid = false
+}
+
+// Next steps the iterator forward one element, skipping deleted items.
+func (it *layerIterator) Next() bool {
+	for {
+		var diskHash common.Hash
+		if it.diskValid {
+			diskHash = common.BytesToHash(it.disk.Key()[it.diskStart:])
+		}
+		switch {
+		case it.pos < len(it.diffs) && (!it.diskValid || bytes.Compare(it.diffs[it.pos].hash[:], diskHash[:]) <= 0):
+			entry := it.diffs[it.pos]
+			it.pos++
+			if it.diskValid && entry.hash == diskHash {
+				it.nextDisk(it.disk.Key()[:it.diskLen-common.HashLength])
+			}
+			if len(entry.value) == 0 {
+				continue
+			}
+			it.hash, it.value = entry.hash, entry.value
+			return true
+
+		case it.diskValid:
+			it.hash, it.value = diskHash, common.CopyBytes(it.disk.Value())
+			it.nextDisk(it.disk.Key()[:it.diskLen-common.HashLength])
+			return true
+
+		default:
+			it.hash, it.value = common.Hash{}, nil
+			return false
+		}
+	}
+}
+
+// Error returns any failure that occurred during iteration.
+func (it *layerIterator) Error() error {
+	if it.disk != nil {
+		return it.disk.Error()
+	}
+	return nil
+}
+
+// Hash returns the hash of the item the iterator is currently at.
+func (it *layerIterator) Hash() common.Hash {
+	return it.hash
+}
+
+// Account returns the account trie value the iterator is currently at.
+func (it *layerIterator) Account() []byte {
+	return it.value
+}
+
+// Slot returns the storage trie value the iterator is currently at.
+func (it *layerIterator) Slot() []byte {
+	return it.value
+}
+
+// Release releases the disk iterator.
+func (it *layerIterator) Release() {
+	if it.disk != nil {
+		it.disk.Release()
+		it.disk = nil
+		it.diskValid = false
+	}
+}
+
+// AccountIterator creates a new account iterator for the specified root hash and
+// seeks to a starting account hash. The iterator fails with ErrNotCoveredYet
+// while the disk layer is still being generated.
+func (t *Tree) AccountIterator(root common.Hash, seek common.Hash) (AccountIterator, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	snap, ok := t.layers[root]
+	if !ok {
+		return nil, fmt.Errorf("snapshot [%#x] missing", root)
+	}
+	items := make(map[common.Hash][]byte)
+	for layer := snap; ; layer = layer.Parent() {
+		if layer.Stale() {
+			return nil, ErrSnapshotStale
+		}
+		switch layer := layer.(type) {
+		case *diffLayer:
+			layer.lock.RLock()
+			// A recreated account overrides the destruct marker of its own layer
+			for hash, data := range layer.accountData {
+				if _, ok := items[hash]; !ok {
+					items[hash] = data
+				}
+			}
+			for hash := range layer.destructSet {
+				if _, ok := items[hash]; !ok {
+					items[hash] = nil
+				}
+			}
+			layer.lock.RUnlock()
+
+		case *diskLayer:
+			layer.lock.RLock()
+			generating := layer.genMarker != nil
+			layer.lock.RUnlock()
+			if generating {
+				return nil, ErrNotCoveredYet
+			}
+			return newLayerIterator(items, layer.diskdb, rawdb.SnapshotAccountPrefix, seek), nil
+		}
+	}
+}
+
+// StorageIterator creates a new storage iterator for the specified root hash and
+// account. The iterator will be moved to the specific start position. The
+// iterator fails with ErrNotCoveredYet if the account is not generated yet.
+func (t *Tree) StorageIterator(root common.Hash, account common.Hash, seek common.Hash) (StorageIterator, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	snap, ok := t.layers[root]
+	if !ok {
+		return nil, fmt.Errorf("snapshot [%#x] missing", root)
+	}
+	items := make(map[common.Hash][]byte)
+	for layer := snap; ; layer = layer.Parent() {
+		if layer.Stale() {
+			return nil, ErrSnapshotStale
+		}
+		switch layer := layer.(type) {
+		case *diffLayer:
+			layer.lock.RLock()
+			for hash, data := range layer.storageData[account] {
+				if _, ok := items[hash]; !ok {
+					items[hash] = data
+				}
+			}
+			_, destructed := layer.destructSet[account]
+			layer.lock.RUnlock()
+
+			// The storage of a destructed account is hidden in the lower layers
+			if destructed {
+				return newLayerIterator(items, nil, nil, seek), nil
+			}
+
+		case *diskLayer:
+			layer.lock.RLock()
+			covered := layer.covered(account)
+			layer.lock.RUnlock()
+			if !covered {
+				return nil, ErrNotCoveredYet
+			}
+			prefix := append(common.CopyBytes(rawdb.SnapshotStoragePrefix), account[:]...)
+			return newLayerIterator(items, layer.diskdb, prefix, seek), nil
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/pkg/errors"
+)
+
+// journalVersion is the version of the journal encoding. Journals of another
+// version are discarded.
+const journalVersion uint64 = 0
+
+// journal is the serialized diff layers of a snapshot, from the bottom up.
+type journal struct {
+	Version  uint64
+	DiskRoot common.Hash
+	Diffs    []journalDiff
+}
+
+// journalDiff is a diff layer entry of the journal.
+type journalDiff struct {
+	Root      common.Hash
+	Destructs []common.Hash
+	Accounts  []journalAccount
+	Storage   []journalStorage
+}
+
+// journalAccount is an account entry in a diffLayer's disk journal.
+type journalAccount struct {
+	Hash common.Hash
+	Blob []byte
+}
+
+// journalStorage is an account's storage map in a diffLayer's disk journal.
+type journalStorage struct {
+	Hash common.Hash
+	Keys []common.Hash
+	Vals [][]byte
+}
+
+// newJournal serializes the diff layers from the given one down to the disk layer.
+func newJournal(snap snapshot) ([]byte, error) {
+	var diffs []journalDiff
+	for ; ; snap = snap.Parent() {
+		diff, ok := snap.(*diffLayer)
+		if !ok {
+			break
+		}
+		if diff.Stale() {
+			return nil, ErrSnapshotStale
+		}
+		diff.lock.RLock()
+		entry := journalDiff{Root: diff.root}
+		for hash := range diff.destructSet {
+			entry.Destructs = append(entry.Destructs, hash)
+		}
+		for hash, blob := range diff.accountData {
+			entry.Accounts = append(entry.Accounts, journalAccount{Hash: hash, Blob: blob})
+		}
+		for hash, slots := range diff.storageData {
+			storage := journalStorage{Hash: hash}
+			for key, val := range slots {
+				storage.Keys = append(storage.Keys, key)
+				storage.Vals = append(storage.Vals, val)
+			}
+			entry.Storage = append(entry.Storage, storage)
+		}
+		diff.lock.RUnlock()
+		diffs = append(diffs, entry)
+	}
+	// Store the diffs from the bottom up
+	for i, j := 0, len(diffs)-1; i < j; i, j = i+1, j-1 {
+		diffs[i], diffs[j] = diffs[j], diffs[i]
+	}
+	return rlp.EncodeToBytes(journal{
+		Version:  journalVersion,
+		DiskRoot: snap.Root(),
+		Diffs:    diffs,
+	})
+}
+
+// loadSnapshot loads a pre-existing state snapshot backed by a key-value store.
+func loadSnapshot(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root common.Hash) (snapshot, error) {
+	// Retrieve the block number and hash of the snapshot, failing if no snapshot
+	// is present in the database (or crashed mid-update).
+	baseRoot := rawdb.ReadSnapshotRoot(diskdb)
+	if baseRoot == (common.Hash{}) {
+		return nil, errors.New("missing or corrupted snapshot")
+	}
+	var generator journalGenerator
+	if err := rlp.DecodeBytes(rawdb.ReadSnapshotGenerator(diskdb), &generator); err != nil {
+		return nil, errors.Wrap(err, "missing snapshot generator")
+	}
+	base := &diskLayer{
+		diskdb: diskdb,
+		triedb: triedb,
+		cache:  fastcache.New(cache * 1024 * 1024),
+		root:   baseRoot,
+	}
+	// Load all the snapshot diffs from the journal, which is only valid on top of
+	// the disk layer it was written with.
+	var snap snapshot = base
+	if blob := rawdb.ReadSnapshotJournal(diskdb); len(blob) > 0 {
+		var j journal
+		if err := rlp.DecodeBytes(blob, &j); err == nil && j.Version == journalVersion && j.DiskRoot == baseRoot {
+			for _, diff := range j.Diffs {
+				snap = loadDiffLayer(snap, diff)
+			}
+		}
+	}
+	// Entire snapshot journal loaded, sanity check the head
+	if head := snap.Root(); head != root {
+		return nil, fmt.Errorf("head doesn't match snapshot: have %#x, want %#x", head, root)
+	}
+	// Everything loaded correctly, resume any suspended operations
+	if !generator.Done {
+		marker := generator.Marker
+		if marker == nil {
+			marker = []byte{}
+		}
+		base.genMarker = marker
+		base.genPending = make(chan struct{})
+		base.genAbort = make(chan chan struct{})
+		go base.generate()
+	}
+	return snap, nil
+}
+
+// loadDiffLayer creates a diff layer from a journal entry on top of the parent.
+func loadDiffLayer(parent snapshot, diff journalDiff) snapshot {
+	destructs := make(map[common.Hash]struct{}, len(diff.Destructs))
+	for _, hash := range diff.Destructs {
+		destructs[hash] = struct{}{}
+	}
+	accounts := make(map[common.Hash][]byte, len(diff.Accounts))
+	for _, entry := range diff.Accounts {
+		accounts[entry.Hash] = nilIfEmpty(entry.Blob)
+	}
+	storage := make(map[common.Hash]map[common.Hash][]byte, len(diff.Storage))
+	for _, entry := range diff.Storage {
+		slots := make(map[common.Hash][]byte, len(entry.Keys))
+		for i, key := range entry.Keys {
+			if i < len(entry.Vals) {
+				slots[key] = nilIfEmpty(entry.Vals[i])
+			}
+		}
+		storage[entry.Hash] = slots
+	}
+	return newDiffLayer(parent, diff.Root, destructs, accounts, storage)
+}
@@ -0,0 +1,324 @@
+// Package snapshot implements a flat key-value view of the state, made of a
+// persistent disk layer and in-memory diff layers on top of it, one per block.
+// Reads hit the flat layers instead of walking the Merkle tries.
+package snapshot
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrSnapshotStale is returned from data accessors if the underlying snapshot
+	// layer had been invalidated due to the chain progressing forward far enough
+	// to not maintain the layer's original state.
+	ErrSnapshotStale = errors.New("snapshot stale")
+
+	// ErrNotCoveredYet is returned from data accessors if the underlying snapshot
+	// is being generated currently and the requested data item is not yet in the
+	// range of accounts covered.
+	ErrNotCoveredYet = errors.New("not covered yet")
+
+	// errSnapshotCycle is returned if a snapshot is attempted to be inserted
+	// that forms a cycle in the snapshot tree.
+	errSnapshotCycle = errors.New("snapshot cycle")
+)
+
+// Snapshot represents the functionality supported by a snapshot storage layer.
+type Snapshot interface {
+	// Root returns the root hash for which this snapshot was made.
+	Root() common.Hash
+
+	// AccountRLP directly retrieves the account trie value associated with a
+	// particular hash in the snapshot. A nil value means the account is missing.
+	AccountRLP(hash common.Hash) ([]byte, error)
+
+	// Storage directly retrieves the storage trie value associated with a
+	// particular hash, within a particular account. A nil value means the slot
+	// is empty.
+	Storage(accountHash, storageHash common.Hash) ([]byte, error)
+}
+
+// snapshot is the internal version of the snapshot data layer that supports some
+// additional methods compared to the public API.
+type snapshot interface {
+	Snapshot
+
+	// Parent returns the subsequent layer of a snapshot, or nil if the base was
+	// reached.
+	Parent() snapshot
+
+	// Update creates a new layer on top of the existing snapshot diff tree with
+	// the specified data items.
+	Update(blockRoot common.Hash, destructs map[common.Hash]struct{}, accounts map[common.Hash][]byte, storage map[common.Hash]map[common.Hash][]byte) *diffLayer
+
+	// Stale return whether this layer has become stale (was flattened across) or
+	// if it's still live.
+	Stale() bool
+}
+
+// Tree is an Ethereum state snapshot tree. It consists of one persistent base
+// layer backed by a key-value store, on top of which arbitrarily many in-memory
+// diff layers are topped. The memory diffs can form a tree with branching, but
+// the disk layer is singleton and common to all. If a reorg goes deeper than the
+// disk layer, everything needs to be deleted.
+//
+// The goal of a state snapshot is twofold: to allow direct access to account and
+// storage data to avoid expensive multi-level trie lookups; and to allow sorted,
+// cheap iteration of the account/storage tries for serving the state sync.
+type Tree struct {
+	diskdb ethdb.KeyValueStore      // Persistent database to store the snapshot
+	triedb *trie.Database           // In-memory cache to access the trie through
+	cache  int                      // Megabytes permitted to use for read caches
+	layers map[common.Hash]snapshot // Collection of all known layers
+	lock   sync.RWMutex
+}
+
+// New attempts to load an already existing snapshot from a persistent key-value
+// store (with a number of memory layers from a journal), ensuring that the head
+// of the snapshot matches the expected one.
+//
+// If the snapshot is missing or inconsistent, the entirety is deleted and will
+// be reconstructed from scratch based on the tries in the key-value store, on a
+// background thread. If async is false, New waits for the generation to finish.
+func New(diskdb ethdb.KeyValueStore, triedb *trie.Database, cache int, root common.Hash, async bool) *Tree {
+	snap := &Tree{
+		diskdb: diskdb,
+		triedb: triedb,
+		cache:  cache,
+		layers: make(map[common.Hash]snapshot),
+	}
+	head, err := loadSnapshot(diskdb, triedb, cache, root)
+	if err != nil {
+		utils.Logger().Warn().Err(err).Msg("[snapshot] failed to load snapshot, regenerating")
+		snap.Rebuild(root)
+	} else {
+		for head != nil {
+			snap.layers[head.Root()] = head
+			head = head.Parent()
+		}
+	}
+	if !async {
+		snap.waitGeneration()
+	}
+	return snap
+}
+
+// waitGeneration waits until the disk layer is completely generated.
+func (t *Tree) waitGeneration() {
+	if disk := t.disklayer(); disk != nil && disk.genPending != nil {
+		<-disk.genPending
+	}
+}
+
+// Snapshot retrieves a snapshot belonging to the given block root, or nil if no
+// snapshot is maintained for that block.
+func (t *Tree) Snapshot(blockRoot common.Hash) Snapshot {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if snap, ok := t.layers[blockRoot]; ok {
+		return snap
+	}
+	return nil
+}
+
+// Update adds a new snapshot into the tree, if that can be linked to an existing
+// old parent. It is disallowed to insert a disk layer (the origin of all).
+func (t *Tree) Update(blockRoot common.Hash, parentRoot common.Hash, destructs map[common.Hash]struct{}, accounts map[common.Hash][]byte, storage map[common.Hash]map[common.Hash][]byte) error {
+	// Reject noop updates to avoid self-loops in the snapshot tree. This is a
+	// special case that can only happen for empty blocks, which are common.
+	if blockRoot == parentRoot {
+		return errSnapshotCycle
+	}
+	// Generate a new snapshot on top of the parent
+	parent := t.Snapshot(parentRoot)
+	if parent == nil {
+		return fmt.Errorf("parent [%#x] snapshot missing", parentRoot)
+	}
+	snap := parent.(snapshot).Update(blockRoot, destructs, accounts, storage)
+
+	// Save the new snapshot for later
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.layers[snap.root] = snap
+	return nil
+}
+
+// Cap traverses downwards the snapshot tree from a head block hash until the
+// number of allowed layers are crossed. All layers beyond the permitted number
+// are flattened downwards into the disk layer.
+func (t *Tree) Cap(root common.Hash, layers int) error {
+	// Retrieve the head snapshot to cap from
+	snap := t.Snapshot(root)
+	if snap == nil {
+		return fmt.Errorf("snapshot [%#x] missing", root)
+	}
+	diff, ok := snap.(*diffLayer)
+	if !ok {
+		// Nothing to flatten on top of the disk layer
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// Collect the diff layers from the head down to the disk layer
+	var diffs []*diffLayer
+	for layer := snapshot(diff); ; layer = layer.Parent() {
+		d, ok := layer.(*diffLayer)
+		if !ok {
+			break
+		}
+		diffs = append(diffs, d)
+	}
+	if len(diffs) <= layers {
+		return nil
+	}
+	// Flatten the layers beyond the limit into the disk layer, bottom first
+	var base *diskLayer
+	for i := len(diffs) - 1; i >= layers; i-- {
+		if base != nil {
+			diffs[i].lock.Lock()
+			diffs[i].parent = base
+			diffs[i].lock.Unlock()
+		}
+		base = diffToDisk(diffs[i])
+		diffs[i].markStale()
+		t.layers[base.root] = base
+	}
+	if layers == 0 {
+		diffs = nil
+	} else {
+		diffs[layers-1].lock.Lock()
+		diffs[layers-1].parent = base
+		diffs[layers-1].lock.Unlock()
+	}
+
+	// Remove any layer that is stale or links into a stale layer
+	children := make(map[common.Hash][]common.Hash)
+	for root, snap := range t.layers {
+		if diff, ok := snap.(*diffLayer); ok {
+			parent := diff.Parent().Root()
+			children[parent] = append(children[parent], root)
+		}
+	}
+	var remove func(root common.Hash)
+	remove = func(root common.Hash) {
+		if diff, ok := t.layers[root].(*diffLayer); ok {
+			diff.markStale()
+		}
+		delete(t.layers, root)
+		for _, child := range children[root] {
+			remove(child)
+		}
+		delete(children, root)
+	}
+	for root, snap := range t.layers {
+		if snap.Stale() {
+			remove(root)
+			continue
+		}
+		if diff, ok := snap.(*diffLayer); ok && diff.Parent().Stale() {
+			remove(root)
+		}
+	}
+	return nil
+}
+
+// Journal commits an entire diff hierarchy to disk into a single journal entry.
+// This is meant to be used during shutdown to persist the snapshot without
+// flattening everything down (bad for reorgs). The generation of the disk layer
+// is stopped and its progress is persisted to be resumed after a restart.
+//
+// The method returns the root hash of the base layer that needs to be persisted
+// to disk as a trie too to allow continuing any pending generation op.
+func (t *Tree) Journal(root common.Hash) (common.Hash, error) {
+	snap := t.Snapshot(root)
+	if snap == nil {
+		return common.Hash{}, fmt.Errorf("snapshot [%#x] missing", root)
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	disk := t.disklayer()
+	if disk == nil {
+		return common.Hash{}, errors.New("snapshot disk layer missing")
+	}
+	disk.stopGeneration()
+
+	journal, err := newJournal(snap.(snapshot))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := rawdb.WriteSnapshotJournal(t.diskdb, journal); err != nil {
+		return common.Hash{}, err
+	}
+	return disk.root, nil
+}
+
+// Rebuild wipes all available snapshot data from the persistent database and
+// discard all caches and diff layers. Afterwards, it starts a new snapshot
+// generator with the given root hash.
+func (t *Tree) Rebuild(root common.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// Firstly delete any recovery flag in the database. Because now we are
+	// building a brand new snapshot.
+	rawdb.DeleteSnapshotJournal(t.diskdb)
+
+	// Iterate over and mark all layers stale
+	for _, layer := range t.layers {
+		switch layer := layer.(type) {
+		case *diskLayer:
+			layer.stopGeneration()
+			layer.lock.Lock()
+			layer.stale = true
+			layer.lock.Unlock()
+
+		case *diffLayer:
+			layer.markStale()
+
+		default:
+			panic(fmt.Sprintf("unknown layer type: %T", layer))
+		}
+	}
+	// Start generating a new snapshot from scratch on a background thread. The
+	// generator will run a wiper first if there's not one running right now.
+	utils.Logger().Info().Str("root", root.Hex()).Msg("[snapshot] rebuilding state snapshot")
+	base := generateSnapshot(t.diskdb, t.triedb, t.cache, root)
+	t.layers = map[common.Hash]snapshot{
+		root: base,
+	}
+}
+
+// DiskRoot is an external helper function to return the disk layer root.
+func (t *Tree) DiskRoot() common.Hash {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	disk := t.disklayer()
+	if disk == nil {
+		return common.Hash{}
+	}
+	return disk.Root()
+}
+
+// disklayer is an internal helper function to return the disk layer. The lock
+// of the tree is assumed to be held.
+func (t *Tree) disklayer() *diskLayer {
+	for _, layer := range t.layers {
+		if disk, ok := layer.(*diskLayer); ok {
+			return disk
+		}
+	}
+	return nil
+}
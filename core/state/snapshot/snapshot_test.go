@@ -0,0 +1,356 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethrawdb "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+)
+
+var (
+	testAccount1 = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testAccount2 = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testAccount3 = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	testSlot1    = common.HexToHash("0x01")
+	testSlot2    = common.HexToHash("0x02")
+)
+
+func TestTree_Generate(t *testing.T) {
+	db, triedb, root := makeTestState(t)
+	snaps := New(db, triedb, 16, root, false)
+
+	snap := snaps.Snapshot(root)
+	if snap == nil {
+		t.Fatalf("snapshot of root %x missing", root)
+	}
+	if err := checkAccount(snap, testAccount1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkAccount(snap, testAccount2, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkAccount(snap, testAccount3, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSlot(snap, testAccount2, testSlot1, 0x11); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSlot(snap, testAccount2, testSlot2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := rawdb.ReadSnapshotRoot(db); got != root {
+		t.Errorf("unexpected snapshot root: %x / %x", got, root)
+	}
+}
+
+func TestTree_Update(t *testing.T) {
+	db, triedb, root := makeTestState(t)
+	snaps := New(db, triedb, 16, root, false)
+
+	root1 := common.HexToHash("0xa1")
+	err := snaps.Update(root1, root, map[common.Hash]struct{}{
+		hashOf(testAccount2): {},
+	}, map[common.Hash][]byte{
+		hashOf(testAccount3): makeTestAccount(3, emptyRoot),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root2 := common.HexToHash("0xa2")
+	err = snaps.Update(root2, root1, nil, map[common.Hash][]byte{
+		hashOf(testAccount2): makeTestAccount(4, emptyRoot),
+	}, map[common.Hash]map[common.Hash][]byte{
+		hashOf(testAccount2): {hashOf(testSlot2): makeTestSlot(0x22)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := snaps.Update(root2, root2, nil, nil, nil); err != errSnapshotCycle {
+		t.Errorf("unexpected error: %v / %v", err, errSnapshotCycle)
+	}
+
+	snap1, snap2 := snaps.Snapshot(root1), snaps.Snapshot(root2)
+	tests := []struct {
+		snap    Snapshot
+		account common.Address
+		balance int64
+		slot    common.Hash
+		value   byte
+	}{
+		{snaps.Snapshot(root), testAccount2, 2, testSlot1, 0x11},
+		{snap1, testAccount1, 1, testSlot1, 0},
+		{snap1, testAccount2, 0, testSlot1, 0},
+		{snap1, testAccount3, 3, testSlot1, 0},
+		{snap2, testAccount2, 4, testSlot1, 0},
+		{snap2, testAccount2, 4, testSlot2, 0x22},
+		{snap2, testAccount3, 3, testSlot1, 0},
+	}
+	for i, test := range tests {
+		if err := checkAccount(test.snap, test.account, test.balance); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+		if err := checkSlot(test.snap, test.account, test.slot, test.value); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+}
+
+func TestTree_Cap(t *testing.T) {
+	db, triedb, root := makeTestState(t)
+	snaps := New(db, triedb, 16, root, false)
+
+	var (
+		parent = root
+		roots  []common.Hash
+	)
+	for i := 1; i <= 4; i++ {
+		next := common.BigToHash(big.NewInt(int64(i)))
+		err := snaps.Update(next, parent, nil, map[common.Hash][]byte{
+			hashOf(testAccount1): makeTestAccount(int64(10+i), emptyRoot),
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, next)
+		parent = next
+	}
+	if err := snaps.Cap(parent, 2); err != nil {
+		t.Fatal(err)
+	}
+	// The two bottom layers are flattened into the disk layer
+	if got := snaps.DiskRoot(); got != roots[1] {
+		t.Errorf("unexpected disk root: %x / %x", got, roots[1])
+	}
+	for _, r := range []common.Hash{root, roots[0]} {
+		if snaps.Snapshot(r) != nil {
+			t.Errorf("stale snapshot %x not removed", r)
+		}
+	}
+	if err := checkAccount(snaps.Snapshot(roots[1]), testAccount1, 12); err != nil {
+		t.Error(err)
+	}
+	if err := checkAccount(snaps.Snapshot(parent), testAccount1, 14); err != nil {
+		t.Error(err)
+	}
+	if got := rawdb.ReadAccountSnapshot(db, hashOf(testAccount1)); len(got) == 0 {
+		t.Errorf("flattened account not written to disk")
+	}
+	if got := rawdb.ReadSnapshotRoot(db); got != roots[1] {
+		t.Errorf("unexpected snapshot root: %x / %x", got, roots[1])
+	}
+}
+
+func TestTree_Journal(t *testing.T) {
+	db, triedb, root := makeTestState(t)
+	snaps := New(db, triedb, 16, root, false)
+
+	root1 := common.HexToHash("0xa1")
+	err := snaps.Update(root1, root, map[common.Hash]struct{}{
+		hashOf(testAccount1): {},
+	}, map[common.Hash][]byte{
+		hashOf(testAccount3): makeTestAccount(3, emptyRoot),
+	}, map[common.Hash]map[common.Hash][]byte{
+		hashOf(testAccount2): {hashOf(testSlot1): nil},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diskRoot, err := snaps.Journal(root1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diskRoot != root {
+		t.Errorf("unexpected disk root: %x / %x", diskRoot, root)
+	}
+
+	loaded := New(db, triedb, 16, root1, false)
+	snap := loaded.Snapshot(root1)
+	if _, ok := snap.(*diffLayer); !ok {
+		t.Fatalf("journaled diff layer not loaded: %T", snap)
+	}
+	if err := checkAccount(snap, testAccount1, 0); err != nil {
+		t.Error(err)
+	}
+	if err := checkAccount(snap, testAccount3, 3); err != nil {
+		t.Error(err)
+	}
+	if err := checkSlot(snap, testAccount2, testSlot1, 0); err != nil {
+		t.Error(err)
+	}
+	// A journal not matching the head is dropped and the snapshot regenerated
+	regenerated := New(db, triedb, 16, root, false)
+	if _, ok := regenerated.Snapshot(root).(*diskLayer); !ok {
+		t.Fatalf("snapshot not regenerated")
+	}
+}
+
+func TestTree_Iterators(t *testing.T) {
+	db, triedb, root := makeTestState(t)
+	snaps := New(db, triedb, 16, root, false)
+
+	root1 := common.HexToHash("0xa1")
+	err := snaps.Update(root1, root, map[common.Hash]struct{}{
+		hashOf(testAccount1): {},
+	}, map[common.Hash][]byte{
+		hashOf(testAccount3): makeTestAccount(3, emptyRoot),
+	}, map[common.Hash]map[common.Hash][]byte{
+		hashOf(testAccount2): {hashOf(testSlot1): nil, hashOf(testSlot2): makeTestSlot(0x22)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accIt, err := snaps.AccountIterator(root1, common.Hash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var accounts []common.Hash
+	for accIt.Next() {
+		accounts = append(accounts, accIt.Hash())
+	}
+	accIt.Release()
+	if err := accIt.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSorted(accounts, hashOf(testAccount2), hashOf(testAccount3)); err != nil {
+		t.Error(err)
+	}
+
+	stIt, err := snaps.StorageIterator(root1, hashOf(testAccount2), common.Hash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var slots []common.Hash
+	for stIt.Next() {
+		slots = append(slots, stIt.Hash())
+	}
+	stIt.Release()
+	if err := checkSorted(slots, hashOf(testSlot2)); err != nil {
+		t.Error(err)
+	}
+
+	stIt, err = snaps.StorageIterator(root, hashOf(testAccount2), common.Hash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slots = slots[:0]
+	for stIt.Next() {
+		slots = append(slots, stIt.Hash())
+	}
+	stIt.Release()
+	if err := checkSorted(slots, hashOf(testSlot1)); err != nil {
+		t.Error(err)
+	}
+}
+
+// makeTestState commits a state with two accounts, the second one having a
+// storage slot, and returns its root.
+func makeTestState(t *testing.T) (ethdb.KeyValueStore, *trie.Database, common.Hash) {
+	db := ethrawdb.NewMemoryDatabase()
+	triedb := trie.NewDatabase(db)
+
+	stTrie, _ := trie.NewSecure(common.Hash{}, triedb)
+	stTrie.Update(testSlot1[:], makeTestSlot(0x11))
+	stRoot, err := stTrie.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accTrie, _ := trie.NewSecure(common.Hash{}, triedb)
+	accTrie.Update(testAccount1[:], makeTestAccount(1, emptyRoot))
+	accTrie.Update(testAccount2[:], makeTestAccount(2, stRoot))
+	root, err := accTrie.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := triedb.Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return db, triedb, root
+}
+
+func makeTestAccount(balance int64, root common.Hash) []byte {
+	blob, _ := rlp.EncodeToBytes(account{
+		Balance:  big.NewInt(balance),
+		Root:     root,
+		CodeHash: crypto.Keccak256(nil),
+	})
+	return blob
+}
+
+func makeTestSlot(value byte) []byte {
+	blob, _ := rlp.EncodeToBytes([]byte{value})
+	return blob
+}
+
+func hashOf(b interface{ Bytes() []byte }) common.Hash {
+	return crypto.Keccak256Hash(b.Bytes())
+}
+
+// checkAccount checks the balance of the account, where zero means missing.
+func checkAccount(snap Snapshot, addr common.Address, balance int64) error {
+	blob, err := snap.AccountRLP(hashOf(addr))
+	if err != nil {
+		return err
+	}
+	if balance == 0 {
+		if blob != nil {
+			return fmt.Errorf("account %x exists", addr)
+		}
+		return nil
+	}
+	var acc account
+	if err := rlp.DecodeBytes(blob, &acc); err != nil {
+		return err
+	}
+	if acc.Balance.Int64() != balance {
+		return fmt.Errorf("account %x balance %v / %v", addr, acc.Balance, balance)
+	}
+	return nil
+}
+
+// checkSlot checks the value of the slot, where zero means empty.
+func checkSlot(snap Snapshot, addr common.Address, slot common.Hash, value byte) error {
+	blob, err := snap.Storage(hashOf(addr), hashOf(slot))
+	if err != nil {
+		return err
+	}
+	if value == 0 {
+		if blob != nil {
+			return fmt.Errorf("slot %x of account %x exists", slot, addr)
+		}
+		return nil
+	}
+	var content []byte
+	if err := rlp.DecodeBytes(blob, &content); err != nil {
+		return err
+	}
+	if len(content) != 1 || content[0] != value {
+		return fmt.Errorf("slot %x of account %x value %x / %x", slot, addr, content, value)
+	}
+	return nil
+}
+
+// checkSorted checks the iterated hashes are the expected ones in the key order.
+func checkSorted(got []common.Hash, exp ...common.Hash) error {
+	sort.Slice(exp, func(i, j int) bool {
+		return bytes.Compare(exp[i][:], exp[j][:]) < 0
+	})
+	if len(got) != len(exp) {
+		return fmt.Errorf("iterated %v items / %v", len(got), len(exp))
+	}
+	for i := range got {
+		if got[i] != exp[i] {
+			return fmt.Errorf("item %v: %x / %x", i, got[i], exp[i])
+		}
+	}
+	return nil
+}
@@ -96,6 +96,7 @@ type Object struct {
 	dirtyCode bool // true if the code was updated
 	suicided  bool
 	deleted   bool
+	forked    bool // true if the storage missing locally is fetched from the fork
 }
 
 // empty returns whether the account is considered empty.
@@ -205,12 +206,36 @@ func (s *Object) GetCommittedState(db Database, key common.Hash) common.Hash {
 	if metrics.EnabledExpensive {
 		defer func(start time.Time) { s.db.StorageReads += time.Since(start) }(time.Now())
 	}
-	// Otherwise load the value from the database
-	enc, err := s.getTrie(db).TryGet(key[:])
+	// Otherwise load the value from the snapshot if available, falling back to
+	// the trie if the snapshot is stale or not generated yet
+	var (
+		enc []byte
+		err error
+	)
+	if s.db.snap != nil {
+		// If the object was destructed in this block, the storage has been cleared
+		// out and the snapshot must not be consulted
+		if _, destructed := s.db.snapDestructs[s.addrHash]; destructed {
+			return common.Hash{}
+		}
+		enc, err = s.db.snap.Storage(s.addrHash, crypto.Keccak256Hash(key[:]))
+	}
+	if s.db.snap == nil || err != nil {
+		enc, err = s.getTrie(db).TryGet(key[:])
+	}
 	if err != nil {
 		s.setError(err)
 		return common.Hash{}
 	}
+	if len(enc) == 0 && s.forked {
+		value, err := s.db.fork.Storage(s.address, key)
+		if err != nil {
+			s.setError(err)
+			return common.Hash{}
+		}
+		s.originStorage[key] = value
+		return value
+	}
 	var value common.Hash
 	if len(enc) > 0 {
 		_, content, _, err := rlp.Split(enc)
@@ -288,6 +313,7 @@ func (s *Object) updateTrie(db Database) Trie {
 	}
 	// Insert all the pending updates into the trie
 	tr := s.getTrie(db)
+	var storage map[common.Hash][]byte
 	for key, value := range s.pendingStorage {
 		// Skip noop changes, persist actual changes
 		if value == s.originStorage[key] {
@@ -295,13 +321,26 @@ func (s *Object) updateTrie(db Database) Trie {
 		}
 		s.originStorage[key] = value
 
-		if (value == common.Hash{}) {
+		var v []byte
+		if (value == common.Hash{}) && !s.forked {
 			s.setError(tr.TryDelete(key[:]))
-			continue
+		} else {
+			// A zero slot of a forked account is kept in the trie, or it would
+			// be fetched again from the upstream state
+			// Encoding []byte cannot fail, ok to ignore the error.
+			v, _ = rlp.EncodeToBytes(common.TrimLeftZeroes(value[:]))
+			s.setError(tr.TryUpdate(key[:], v))
+		}
+		// Track the slot for the snapshot, an empty value means a deletion
+		if s.db.snap != nil {
+			if storage == nil {
+				if storage = s.db.snapStorage[s.addrHash]; storage == nil {
+					storage = make(map[common.Hash][]byte)
+					s.db.snapStorage[s.addrHash] = storage
+				}
+			}
+			storage[crypto.Keccak256Hash(key[:])] = v
 		}
-		// Encoding []byte cannot fail, ok to ignore the error.
-		v, _ := rlp.EncodeToBytes(common.TrimLeftZeroes(value[:]))
-		s.setError(tr.TryUpdate(key[:], v))
 	}
 	if len(s.pendingStorage) > 0 {
 		s.pendingStorage = make(Storage)
@@ -390,6 +429,7 @@ func (s *Object) deepCopy(db *DB) *Object {
 	stateObject.suicided = s.suicided
 	stateObject.dirtyCode = s.dirtyCode
 	stateObject.deleted = s.deleted
+	stateObject.forked = s.forked
 	return stateObject
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 	common2 "github.com/harmony-one/harmony/internal/common"
 	"github.com/harmony-one/harmony/internal/utils"
@@ -74,6 +75,18 @@ type DB struct {
 	db   Database
 	trie Trie
 
+	// Flat state snapshot serving the reads, and the changes to apply on top of
+	// it on commit. The snapshot is nil if the state is not snapshotted.
+	snaps         *snapshot.Tree
+	snap          snapshot.Snapshot
+	snapDestructs map[common.Hash]struct{}
+	snapAccounts  map[common.Hash][]byte
+	snapStorage   map[common.Hash]map[common.Hash][]byte
+
+	// Upstream state the accounts and storage slots missing locally are
+	// fetched from, nil if the chain is not forked.
+	fork Fork
+
 	// This map holds 'live' objects, which will get modified while processing a state transition.
 	stateObjects        map[common.Address]*Object
 	stateObjectsPending map[common.Address]struct{} // State objects finalized but not yet written to the trie
@@ -98,6 +111,9 @@ type DB struct {
 
 	preimages map[common.Hash][]byte
 
+	// Per-transaction access list
+	accessList *accessList
+
 	// Journal of state modifications. This is the backbone of
 	// Snapshot and RevertToSnapshot.
 	journal        *journal
@@ -121,9 +137,11 @@ func New(root common.Hash, db Database) (*DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &DB{
+	sdb := &DB{
 		db:                  db,
 		trie:                tr,
+		snaps:               db.Snapshots(),
+		fork:                db.Fork(),
 		stateObjects:        make(map[common.Address]*Object),
 		stateObjectsPending: make(map[common.Address]struct{}),
 		stateObjectsDirty:   make(map[common.Address]struct{}),
@@ -131,7 +149,24 @@ func New(root common.Hash, db Database) (*DB, error) {
 		logs:                make(map[common.Hash][]*types.Log),
 		preimages:           make(map[common.Hash][]byte),
 		journal:             newJournal(),
-	}, nil
+		accessList:          newAccessList(),
+	}
+	sdb.openSnapshot(root)
+	return sdb, nil
+}
+
+// openSnapshot starts serving the reads from the flat state snapshot of the
+// given root, if one is maintained.
+func (db *DB) openSnapshot(root common.Hash) {
+	db.snap, db.snapDestructs, db.snapAccounts, db.snapStorage = nil, nil, nil, nil
+	if db.snaps == nil {
+		return
+	}
+	if db.snap = db.snaps.Snapshot(root); db.snap != nil {
+		db.snapDestructs = make(map[common.Hash]struct{})
+		db.snapAccounts = make(map[common.Hash][]byte)
+		db.snapStorage = make(map[common.Hash]map[common.Hash][]byte)
+	}
 }
 
 // setError remembers the first non-nil error it is called with.
@@ -164,6 +199,8 @@ func (db *DB) Reset(root common.Hash) error {
 	db.logs = make(map[common.Hash][]*types.Log)
 	db.logSize = 0
 	db.preimages = make(map[common.Hash][]byte)
+	db.accessList = newAccessList()
+	db.openSnapshot(root)
 	db.clearJournalAndRefund()
 	return nil
 }
@@ -424,6 +461,15 @@ func (db *DB) SetState(addr common.Address, key, value common.Hash) {
 	}
 }
 
+// SetStorage replaces the entire storage for the specified account with given
+// storage. This function should only be used for debugging.
+func (db *DB) SetStorage(addr common.Address, storage map[common.Hash]common.Hash) {
+	stateObject := db.GetOrNewStateObject(addr)
+	if stateObject != nil {
+		stateObject.SetStorage(storage)
+	}
+}
+
 // Suicide marks the given account as suicided.
 // This clears the account balance.
 //
@@ -463,6 +509,11 @@ func (db *DB) updateStateObject(obj *Object) {
 		panic(fmt.Errorf("can't encode object at %x: %v", addr[:], err))
 	}
 	db.setError(db.trie.TryUpdate(addr[:], data))
+
+	// Track the account for the snapshot, the blob is the account trie value
+	if db.snap != nil {
+		db.snapAccounts[obj.addrHash] = data
+	}
 }
 
 // deleteStateObject removes the given object from the state trie.
@@ -473,6 +524,16 @@ func (db *DB) deleteStateObject(obj *Object) {
 	}
 	// Delete the account from the trie
 	addr := obj.Address()
+	if db.fork != nil {
+		// An empty account is kept in place of a deleted one, or it would be
+		// fetched again from the upstream state
+		data, _ := rlp.EncodeToBytes(&Account{Balance: new(big.Int), Root: emptyRoot, CodeHash: emptyCodeHash})
+		db.setError(db.trie.TryUpdate(addr[:], data))
+		if db.snap != nil {
+			db.snapAccounts[obj.addrHash] = data
+		}
+		return
+	}
 	db.setError(db.trie.TryDelete(addr[:]))
 }
 
@@ -499,9 +560,22 @@ func (db *DB) getDeletedStateObject(addr common.Address) *Object {
 	if metrics.EnabledExpensive {
 		defer func(start time.Time) { db.AccountReads += time.Since(start) }(time.Now())
 	}
-	// Load the object from the database
-	enc, err := db.trie.TryGet(addr[:])
+	// Load the object from the snapshot if available, falling back to the trie
+	// if the snapshot is stale or not generated yet
+	var (
+		enc []byte
+		err error
+	)
+	if db.snap != nil {
+		enc, err = db.snap.AccountRLP(crypto.Keccak256Hash(addr[:]))
+	}
+	if db.snap == nil || err != nil {
+		enc, err = db.trie.TryGet(addr[:])
+	}
 	if len(enc) == 0 {
+		if err == nil && db.fork != nil {
+			return db.getForkStateObject(addr)
+		}
 		db.setError(err)
 		return nil
 	}
@@ -512,6 +586,30 @@ func (db *DB) getDeletedStateObject(addr common.Address) *Object {
 	}
 	// Insert into the live set
 	obj := newObject(db, addr, data)
+	// An empty account is the tombstone of a deleted one, whose storage is
+	// never fetched from the upstream state
+	obj.forked = db.fork != nil && !obj.empty()
+	db.setStateObject(obj)
+	return obj
+}
+
+// getForkStateObject loads an account missing from the local state from the
+// upstream state of a forked chain. Its code is written along with the account
+// once the account is committed locally.
+func (db *DB) getForkStateObject(addr common.Address) *Object {
+	nonce, balance, code, err := db.fork.Account(addr)
+	if err != nil {
+		db.setError(err)
+		return nil
+	}
+	if nonce == 0 && balance.Sign() == 0 && len(code) == 0 {
+		return nil
+	}
+	obj := newObject(db, addr, Account{Nonce: nonce, Balance: balance, CodeHash: crypto.Keccak256(code)})
+	obj.forked = true
+	if len(code) > 0 {
+		obj.code, obj.dirtyCode = code, true
+	}
 	db.setStateObject(obj)
 	return obj
 }
@@ -534,12 +632,20 @@ func (db *DB) GetOrNewStateObject(addr common.Address) *Object {
 func (db *DB) createObject(addr common.Address) (newobj, prev *Object) {
 	prev = db.getDeletedStateObject(addr) // Note, prev might have been deleted, we need that!
 
+	// The storage of the overwritten account is wiped in the snapshot
+	var prevdestruct bool
+	if db.snap != nil && prev != nil {
+		_, prevdestruct = db.snapDestructs[prev.addrHash]
+		if !prevdestruct {
+			db.snapDestructs[prev.addrHash] = struct{}{}
+		}
+	}
 	newobj = newObject(db, addr, Account{})
 	newobj.setNonce(0) // sets the object to dirty
 	if prev == nil {
 		db.journal.append(createObjectChange{account: &addr})
 	} else {
-		db.journal.append(resetObjectChange{prev: prev})
+		db.journal.append(resetObjectChange{prev: prev, prevdestruct: prevdestruct})
 	}
 	db.setStateObject(newobj)
 	return newobj, prev
@@ -608,6 +714,28 @@ func (db *DB) Copy() *DB {
 		logSize:             db.logSize,
 		preimages:           make(map[common.Hash][]byte),
 		journal:             newJournal(),
+		snaps:               db.snaps,
+		snap:                db.snap,
+		fork:                db.fork,
+	}
+	if db.snap != nil {
+		// The snapshot changes are copied, as the copy commits on its own
+		state.snapDestructs = make(map[common.Hash]struct{}, len(db.snapDestructs))
+		for hash := range db.snapDestructs {
+			state.snapDestructs[hash] = struct{}{}
+		}
+		state.snapAccounts = make(map[common.Hash][]byte, len(db.snapAccounts))
+		for hash, account := range db.snapAccounts {
+			state.snapAccounts[hash] = account
+		}
+		state.snapStorage = make(map[common.Hash]map[common.Hash][]byte, len(db.snapStorage))
+		for hash, storage := range db.snapStorage {
+			slots := make(map[common.Hash][]byte, len(storage))
+			for key, value := range storage {
+				slots[key] = value
+			}
+			state.snapStorage[hash] = slots
+		}
 	}
 	// Copy the dirty states, logs, and preimages
 	for addr := range db.journal.dirties {
@@ -655,6 +783,12 @@ func (db *DB) Copy() *DB {
 	for hash, preimage := range db.preimages {
 		state.preimages[hash] = preimage
 	}
+	// Do we need to copy the access list? In practice: No. At the start of a
+	// transaction, the access list is empty. In practice, we only ever copy state
+	// _between_ transactions/blocks, never in the middle of a transaction.
+	// However, it doesn't cost us much to copy an empty list, so we do it anyway
+	// to not blow up if we ever decide copy it in the middle of a transaction
+	state.accessList = db.accessList.Copy()
 	return state
 }
 
@@ -709,6 +843,14 @@ func (db *DB) Finalise(deleteEmptyObjects bool) {
 		}
 		if obj.suicided || (deleteEmptyObjects && obj.empty()) {
 			obj.deleted = true
+
+			// Destruct the account in the snapshot along with its storage, and
+			// drop any change of it made earlier in the block
+			if db.snap != nil {
+				db.snapDestructs[obj.addrHash] = struct{}{}
+				delete(db.snapAccounts, obj.addrHash)
+				delete(db.snapStorage, obj.addrHash)
+			}
 		} else {
 			obj.finalise()
 		}
@@ -757,6 +899,70 @@ func (db *DB) SetTxHashETH(ethTxHash common.Hash) {
 	db.ethTxHash = ethTxHash
 }
 
+// PrepareAccessList handles the preparatory steps for executing a state
+// transition with regards to EIP-2929 and EIP-2930:
+//
+// - Add sender to access list
+// - Add destination to access list
+// - Add precompiles to access list
+// - Add the contents of the optional tx access list (EIP-2930)
+//
+// This method should only be called if the Berlin epoch is active.
+func (db *DB) PrepareAccessList(sender common.Address, dst *common.Address, precompiles []common.Address, list types.AccessList) {
+	// Clear out any leftover from previous executions
+	db.accessList = newAccessList()
+
+	db.AddAddressToAccessList(sender)
+	if dst != nil {
+		db.AddAddressToAccessList(*dst)
+		// If it's a create-tx, the destination will be added inside evm.create
+	}
+	for _, addr := range precompiles {
+		db.AddAddressToAccessList(addr)
+	}
+	for _, el := range list {
+		db.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			db.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
+// AddAddressToAccessList adds the given address to the access list
+func (db *DB) AddAddressToAccessList(addr common.Address) {
+	if db.accessList.AddAddress(addr) {
+		db.journal.append(accessListAddAccountChange{&addr})
+	}
+}
+
+// AddSlotToAccessList adds the given (address, slot)-tuple to the access list
+func (db *DB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	addrMod, slotMod := db.accessList.AddSlot(addr, slot)
+	if addrMod {
+		// In practice, this should not happen, since there is no way to enter the
+		// scope of 'address' without having the 'address' become already added
+		// to the access list (via call-variant, create, etc).
+		// Better safe than sorry, though
+		db.journal.append(accessListAddAccountChange{&addr})
+	}
+	if slotMod {
+		db.journal.append(accessListAddSlotChange{
+			address: &addr,
+			slot:    &slot,
+		})
+	}
+}
+
+// AddressInAccessList returns true if the given address is in the access list.
+func (db *DB) AddressInAccessList(addr common.Address) bool {
+	return db.accessList.ContainsAddress(addr)
+}
+
+// SlotInAccessList returns true if the given (address, slot)-tuple is in the access list.
+func (db *DB) SlotInAccessList(addr common.Address, slot common.Hash) (addressPresent bool, slotPresent bool) {
+	return db.accessList.Contains(addr, slot)
+}
+
 func (db *DB) clearJournalAndRefund() {
 	if len(db.journal.entries) > 0 {
 		db.journal = newJournal()
@@ -791,7 +997,7 @@ func (db *DB) Commit(deleteEmptyObjects bool) (root common.Hash, err error) {
 	if metrics.EnabledExpensive {
 		defer func(start time.Time) { db.AccountCommits += time.Since(start) }(time.Now())
 	}
-	return db.trie.Commit(func(leaf []byte, parent common.Hash) error {
+	root, err = db.trie.Commit(func(leaf []byte, parent common.Hash) error {
 		var account Account
 		if err := rlp.DecodeBytes(leaf, &account); err != nil {
 			return nil
@@ -805,6 +1011,21 @@ func (db *DB) Commit(deleteEmptyObjects bool) (root common.Hash, err error) {
 		}
 		return nil
 	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	// Push the changes of the block as a new diff layer onto the snapshot tree
+	if db.snap != nil {
+		if parent := db.snap.Root(); parent != root {
+			if err := db.snaps.Update(root, parent, db.snapDestructs, db.snapAccounts, db.snapStorage); err != nil {
+				utils.Logger().Warn().Err(err).
+					Str("from", parent.Hex()).Str("to", root.Hex()).
+					Msg("[state] failed to update snapshot tree")
+			}
+		}
+		db.snap, db.snapDestructs, db.snapAccounts, db.snapStorage = nil, nil, nil, nil
+	}
+	return root, nil
 }
 
 var (
@@ -32,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 
 	"github.com/harmony-one/harmony/crypto/bls"
@@ -920,6 +921,61 @@ func TestDeleteCreateRevert(t *testing.T) {
 	}
 }
 
+func TestFlatSnapshotReads(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	db := NewDatabase(diskdb)
+	state, _ := New(common.Hash{}, db)
+
+	addr1 := common.BytesToAddress([]byte("one"))
+	addr2 := common.BytesToAddress([]byte("two"))
+	slot := common.BytesToHash([]byte("slot"))
+	state.SetBalance(addr1, big.NewInt(1))
+	state.SetBalance(addr2, big.NewInt(2))
+	state.SetState(addr2, slot, common.BytesToHash([]byte{0x11}))
+	root, _ := state.Commit(false)
+	db.TrieDB().Commit(root, false)
+
+	snaps := snapshot.New(diskdb, db.TrieDB(), 16, root, false)
+	db.SetSnapshots(snaps)
+
+	// Update the state on top of the snapshot, destructing the second account
+	state, _ = New(root, db)
+	if state.snap == nil {
+		t.Fatalf("state not opened on the snapshot")
+	}
+	if got := state.GetBalance(addr1); got.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("unexpected balance: %v / 1", got)
+	}
+	if got := state.GetState(addr2, slot); got != common.BytesToHash([]byte{0x11}) {
+		t.Errorf("unexpected slot: %x", got)
+	}
+	state.SetBalance(addr1, big.NewInt(3))
+	state.Suicide(addr2)
+	state.Finalise(true)
+	state.CreateAccount(addr2)
+	state.SetBalance(addr2, big.NewInt(4))
+	newRoot, _ := state.Commit(true)
+
+	snap := snaps.Snapshot(newRoot)
+	if snap == nil {
+		t.Fatalf("snapshot of the new root missing")
+	}
+	// The reads of the new state are served by the diff layer
+	state, _ = New(newRoot, db)
+	if got := state.GetBalance(addr1); got.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("unexpected balance: %v / 3", got)
+	}
+	if got := state.GetBalance(addr2); got.Cmp(big.NewInt(4)) != 0 {
+		t.Errorf("unexpected balance: %v / 4", got)
+	}
+	if got := state.GetState(addr2, slot); got != (common.Hash{}) {
+		t.Errorf("storage of the destructed account not cleared: %x", got)
+	}
+	if blob, err := snap.Storage(crypto.Keccak256Hash(addr2[:]), crypto.Keccak256Hash(slot[:])); err != nil || blob != nil {
+		t.Errorf("unexpected snapshot slot: %x, %v", blob, err)
+	}
+}
+
 func makeValidValidatorWrapper(addr common.Address) stk.ValidatorWrapper {
 	cr := stk.CommissionRates{
 		Rate:          numeric.ZeroDec(),
@@ -1040,6 +1096,178 @@ func verifyValidatorWrapperRevert(
 	}
 }
 
+func TestStateDBAccessList(t *testing.T) {
+	// Some helpers
+	addr := func(a string) common.Address {
+		return common.HexToAddress(a)
+	}
+	slot := func(a string) common.Hash {
+		return common.HexToHash(a)
+	}
+
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+	state.accessList = newAccessList()
+
+	verifyAddrs := func(astrings ...string) {
+		t.Helper()
+		// convert to common.Address form
+		var addresses []common.Address
+		var addressMap = make(map[common.Address]struct{})
+		for _, astring := range astrings {
+			address := addr(astring)
+			addresses = append(addresses, address)
+			addressMap[address] = struct{}{}
+		}
+		// Check that the given addresses are in the access list
+		for _, address := range addresses {
+			if !state.AddressInAccessList(address) {
+				t.Fatalf("expected %x to be in access list", address)
+			}
+		}
+		// Check that only the expected addresses are present in the access list
+		for address := range state.accessList.addresses {
+			if _, exist := addressMap[address]; !exist {
+				t.Fatalf("extra address %x in access list", address)
+			}
+		}
+	}
+	verifySlots := func(addrString string, slotStrings ...string) {
+		if !state.AddressInAccessList(addr(addrString)) {
+			t.Fatalf("scope missing address/slots %v", addrString)
+		}
+		var address = addr(addrString)
+		// convert to common.Hash form
+		var slots []common.Hash
+		var slotMap = make(map[common.Hash]struct{})
+		for _, slotString := range slotStrings {
+			s := slot(slotString)
+			slots = append(slots, s)
+			slotMap[s] = struct{}{}
+		}
+		// Check that the expected items are in the access list
+		for i, s := range slots {
+			if _, slotPresent := state.SlotInAccessList(address, s); !slotPresent {
+				t.Fatalf("input %d: scope missing slot %v (address %v)", i, s, addrString)
+			}
+		}
+		// Check that no extra elements are in the access list
+		index := state.accessList.addresses[address]
+		if index >= 0 {
+			stateSlots := state.accessList.slots[index]
+			for s := range stateSlots {
+				if _, slotPresent := slotMap[s]; !slotPresent {
+					t.Fatalf("scope has extra slot %v (address %v)", s, addrString)
+				}
+			}
+		}
+	}
+
+	state.AddAddressToAccessList(addr("aa"))          // 1
+	state.AddSlotToAccessList(addr("bb"), slot("01")) // 2,3
+	state.AddSlotToAccessList(addr("bb"), slot("02")) // 4
+	verifyAddrs("aa", "bb")
+	verifySlots("bb", "01", "02")
+
+	// Make a copy
+	stateCopy1 := state.Copy()
+	if exp, got := 4, state.journal.length(); exp != got {
+		t.Fatalf("journal length mismatch: have %d, want %d", got, exp)
+	}
+
+	// same again, should cause no journal entries
+	state.AddSlotToAccessList(addr("bb"), slot("01"))
+	state.AddSlotToAccessList(addr("bb"), slot("02"))
+	state.AddAddressToAccessList(addr("aa"))
+	if exp, got := 4, state.journal.length(); exp != got {
+		t.Fatalf("journal length mismatch: have %d, want %d", got, exp)
+	}
+	// some new ones
+	state.AddSlotToAccessList(addr("bb"), slot("03")) // 5
+	state.AddSlotToAccessList(addr("aa"), slot("01")) // 6
+	state.AddSlotToAccessList(addr("cc"), slot("01")) // 7,8
+	state.AddAddressToAccessList(addr("cc"))
+	if exp, got := 8, state.journal.length(); exp != got {
+		t.Fatalf("journal length mismatch: have %d, want %d", got, exp)
+	}
+
+	verifyAddrs("aa", "bb", "cc")
+	verifySlots("aa", "01")
+	verifySlots("bb", "01", "02", "03")
+	verifySlots("cc", "01")
+
+	// now start rolling back changes
+	state.journal.revert(state, 7)
+	if _, ok := state.SlotInAccessList(addr("cc"), slot("01")); ok {
+		t.Fatalf("slot present, expected missing")
+	}
+	verifyAddrs("aa", "bb", "cc")
+	verifySlots("aa", "01")
+	verifySlots("bb", "01", "02", "03")
+
+	state.journal.revert(state, 6)
+	if state.AddressInAccessList(addr("cc")) {
+		t.Fatalf("addr present, expected missing")
+	}
+	verifyAddrs("aa", "bb")
+	verifySlots("aa", "01")
+	verifySlots("bb", "01", "02", "03")
+
+	state.journal.revert(state, 5)
+	if _, ok := state.SlotInAccessList(addr("aa"), slot("01")); ok {
+		t.Fatalf("slot present, expected missing")
+	}
+	verifyAddrs("aa", "bb")
+	verifySlots("bb", "01", "02", "03")
+
+	state.journal.revert(state, 4)
+	if _, ok := state.SlotInAccessList(addr("bb"), slot("03")); ok {
+		t.Fatalf("slot present, expected missing")
+	}
+	verifyAddrs("aa", "bb")
+	verifySlots("bb", "01", "02")
+
+	state.journal.revert(state, 3)
+	if _, ok := state.SlotInAccessList(addr("bb"), slot("02")); ok {
+		t.Fatalf("slot present, expected missing")
+	}
+	verifyAddrs("aa", "bb")
+	verifySlots("bb", "01")
+
+	state.journal.revert(state, 2)
+	if _, ok := state.SlotInAccessList(addr("bb"), slot("01")); ok {
+		t.Fatalf("slot present, expected missing")
+	}
+	verifyAddrs("aa", "bb")
+
+	state.journal.revert(state, 1)
+	if state.AddressInAccessList(addr("bb")) {
+		t.Fatalf("addr present, expected missing")
+	}
+	verifyAddrs("aa")
+
+	state.journal.revert(state, 0)
+	if state.AddressInAccessList(addr("aa")) {
+		t.Fatalf("addr present, expected missing")
+	}
+	if got, exp := len(state.accessList.addresses), 0; got != exp {
+		t.Fatalf("expected empty, got %d", got)
+	}
+	if got, exp := len(state.accessList.slots), 0; got != exp {
+		t.Fatalf("expected empty, got %d", got)
+	}
+	// Check the copy
+	// Make a copy
+	state = stateCopy1
+	verifyAddrs("aa", "bb")
+	verifySlots("bb", "01", "02")
+	if got, exp := len(state.accessList.addresses), 2; got != exp {
+		t.Fatalf("expected empty, got %d", got)
+	}
+	if got, exp := len(state.accessList.slots), 1; got != exp {
+		t.Fatalf("expected empty, got %d", got)
+	}
+}
+
 func TestValidatorCreationRevert(t *testing.T) {
 	state, err := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
 	emptyState := state.Copy()
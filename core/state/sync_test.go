@@ -0,0 +1,174 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// testAccount is the data associated with an account used by the state tests.
+type testAccount struct {
+	address common.Address
+	balance *big.Int
+	code    []byte
+	slot    common.Hash
+}
+
+// makeTestState create a sample test state to test node-wise reconstruction.
+func makeTestState(t *testing.T) (Database, common.Hash, []*testAccount) {
+	db := NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, _ := New(common.Hash{}, db)
+
+	var accounts []*testAccount
+	for i := byte(0); i < 96; i++ {
+		acc := &testAccount{
+			address: common.BytesToAddress([]byte{i}),
+			balance: big.NewInt(int64(11 * i)),
+		}
+		statedb.AddBalance(acc.address, acc.balance)
+		if i%3 == 0 {
+			acc.code = []byte{i, i, i, i, i}
+			statedb.SetCode(acc.address, acc.code)
+		}
+		if i%5 == 0 {
+			acc.slot = common.BytesToHash([]byte{i, i})
+			statedb.SetState(acc.address, common.BytesToHash([]byte{i}), acc.slot)
+		}
+		accounts = append(accounts, acc)
+	}
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	if err := db.TrieDB().Commit(root, false); err != nil {
+		t.Fatalf("failed to commit trie: %v", err)
+	}
+	return db, root, accounts
+}
+
+// checkStateAccounts cross references a reconstructed state with an expected
+// account array.
+func checkStateAccounts(t *testing.T, db ethdb.Database, root common.Hash, accounts []*testAccount) {
+	state, err := New(root, NewDatabase(db))
+	if err != nil {
+		t.Fatalf("failed to create state trie at %x: %v", root, err)
+	}
+	for i, acc := range accounts {
+		if balance := state.GetBalance(acc.address); balance.Cmp(acc.balance) != 0 {
+			t.Errorf("account %d: balance mismatch: have %v, want %v", i, balance, acc.balance)
+		}
+		if code := state.GetCode(acc.address); !bytes.Equal(code, acc.code) {
+			t.Errorf("account %d: code mismatch: have %x, want %x", i, code, acc.code)
+		}
+		key := common.BytesToHash([]byte{byte(i)})
+		if slot := state.GetState(acc.address, key); slot != acc.slot {
+			t.Errorf("account %d: storage mismatch: have %x, want %x", i, slot, acc.slot)
+		}
+	}
+}
+
+func TestEmptyStateSync(t *testing.T) {
+	empty := common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+	sync := NewStateSync(empty, rawdb.NewMemoryDatabase(), trie.NewSyncBloom(1, rawdb.NewMemoryDatabase()))
+	if missing := sync.Missing(1); len(missing) != 0 {
+		t.Errorf("content requested for empty state: %v", missing)
+	}
+}
+
+func TestIterativeStateSync(t *testing.T) {
+	srcDb, srcRoot, srcAccounts := makeTestState(t)
+
+	dstDb := rawdb.NewMemoryDatabase()
+	sched := NewStateSync(srcRoot, dstDb, trie.NewSyncBloom(1, dstDb))
+
+	queue := append([]common.Hash{}, sched.Missing(16)...)
+	for len(queue) > 0 {
+		results := make([]trie.SyncResult, len(queue))
+		for i, hash := range queue {
+			data, err := srcDb.TrieDB().Node(hash)
+			if err != nil {
+				t.Fatalf("failed to retrieve node data for %x: %v", hash, err)
+			}
+			results[i] = trie.SyncResult{Hash: hash, Data: data}
+		}
+		if _, index, err := sched.Process(results); err != nil {
+			t.Fatalf("failed to process result #%d: %v", index, err)
+		}
+		batch := dstDb.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			t.Fatalf("failed to commit data: %v", err)
+		}
+		batch.Write()
+		queue = append(queue[:0], sched.Missing(16)...)
+	}
+	checkStateAccounts(t, dstDb, srcRoot, srcAccounts)
+}
+
+func TestResumedStateSync(t *testing.T) {
+	srcDb, srcRoot, srcAccounts := makeTestState(t)
+
+	// Sync half of the state, then start over from the partially filled database
+	dstDb := rawdb.NewMemoryDatabase()
+	sched := NewStateSync(srcRoot, dstDb, trie.NewSyncBloom(1, dstDb))
+	for round := 0; round < 2; round++ {
+		queue := sched.Missing(8)
+		results := make([]trie.SyncResult, len(queue))
+		for i, hash := range queue {
+			data, err := srcDb.TrieDB().Node(hash)
+			if err != nil {
+				t.Fatalf("failed to retrieve node data for %x: %v", hash, err)
+			}
+			results[i] = trie.SyncResult{Hash: hash, Data: data}
+		}
+		if _, index, err := sched.Process(results); err != nil {
+			t.Fatalf("failed to process result #%d: %v", index, err)
+		}
+		batch := dstDb.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			t.Fatalf("failed to commit data: %v", err)
+		}
+		batch.Write()
+	}
+
+	sched = NewStateSync(srcRoot, dstDb, trie.NewSyncBloom(1, dstDb))
+	for queue := sched.Missing(16); len(queue) > 0; queue = sched.Missing(16) {
+		results := make([]trie.SyncResult, len(queue))
+		for i, hash := range queue {
+			data, err := srcDb.TrieDB().Node(hash)
+			if err != nil {
+				t.Fatalf("failed to retrieve node data for %x: %v", hash, err)
+			}
+			results[i] = trie.SyncResult{Hash: hash, Data: data}
+		}
+		if _, index, err := sched.Process(results); err != nil {
+			t.Fatalf("failed to process result #%d: %v", index, err)
+		}
+		batch := dstDb.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			t.Fatalf("failed to commit data: %v", err)
+		}
+		batch.Write()
+	}
+	checkStateAccounts(t, dstDb, srcRoot, srcAccounts)
+}
@@ -136,7 +136,7 @@ func (p *StateProcessor) Process(
 			p.config, p.bc, &beneficiary, gp, statedb, header, tx, usedGas, cfg,
 		)
 		if err != nil {
-			return nil, nil, nil, nil, 0, nil, statedb, err
+			return nil, nil, nil, nil, 0, nil, statedb, &TxError{Index: i, Hash: tx.Hash(), Err: err}
 		}
 		receipts = append(receipts, receipt)
 		if cxReceipt != nil {
@@ -158,7 +158,7 @@ func (p *StateProcessor) Process(
 			p.config, p.bc, &beneficiary, gp, statedb, header, tx, usedGas, cfg,
 		)
 		if err != nil {
-			return nil, nil, nil, nil, 0, nil, statedb, err
+			return nil, nil, nil, nil, 0, nil, statedb, &TxError{Index: i + L, Hash: tx.Hash(), Err: err}
 		}
 		receipts = append(receipts, receipt)
 		allLogs = append(allLogs, receipt.Logs...)
@@ -259,12 +259,23 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 		)
 	}
 
+	switch tx.Type() {
+	case types.AccessListTxType:
+		if !config.IsBerlin(header.Epoch()) {
+			return nil, nil, nil, 0, types.ErrTxTypeNotSupported
+		}
+	case types.DynamicFeeTxType:
+		if !config.IsLondon(header.Epoch()) {
+			return nil, nil, nil, 0, types.ErrTxTypeNotSupported
+		}
+	}
+
 	var signer types.Signer
 	if tx.IsEthCompatible() {
 		if !config.IsEthCompatible(header.Epoch()) {
 			return nil, nil, nil, 0, errors.New("ethereum compatible transactions not supported at current epoch")
 		}
-		signer = types.NewEIP155Signer(config.EthCompatibleChainID)
+		signer = types.MakeEthSigner(config, header.Epoch())
 	} else {
 		signer = types.MakeSigner(config, header.Epoch())
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/shard"
 	stakingTypes "github.com/harmony-one/harmony/staking/types"
@@ -44,6 +45,10 @@ var (
 	errNegativeAmount              = errors.New("amount can not be negative")
 	errDupIdentity                 = errors.New("validator identity exists")
 	errDupBlsKey                   = errors.New("BLS key exists")
+
+	// ErrTipAboveFeeCap is returned if the max priority fee per gas of a
+	// message is higher than its max fee per gas.
+	ErrTipAboveFeeCap = errors.New("max priority fee per gas higher than max fee per gas")
 )
 
 /*
@@ -85,12 +90,15 @@ type Message interface {
 	To() *common.Address
 
 	GasPrice() *big.Int
+	GasFeeCap() *big.Int
+	GasTipCap() *big.Int
 	Gas() uint64
 	Value() *big.Int
 
 	Nonce() uint64
 	CheckNonce() bool
 	Data() []byte
+	AccessList() types.AccessList
 	Type() types.TransactionType
 	BlockNum() *big.Int
 }
@@ -131,11 +139,15 @@ func (result *ExecutionResult) Revert() []byte {
 
 // NewStateTransition initialises and returns a new state transition object.
 func NewStateTransition(evm *vm.EVM, msg Message, gp *GasPool, bc ChainContext) *StateTransition {
+	gasPrice := msg.GasPrice()
+	if baseFee := evm.Context.BaseFee; baseFee != nil {
+		gasPrice = types.EffectiveGasPrice(msg.GasTipCap(), msg.GasFeeCap(), baseFee)
+	}
 	return &StateTransition{
 		gp:       gp,
 		evm:      evm,
 		msg:      msg,
-		gasPrice: msg.GasPrice(),
+		gasPrice: gasPrice,
 		value:    msg.Value(),
 		data:     msg.Data(),
 		state:    evm.StateDB,
@@ -178,10 +190,18 @@ func (st *StateTransition) useGas(amount uint64) error {
 
 func (st *StateTransition) buyGas() error {
 	mgval := new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), st.gasPrice)
-	if have := st.state.GetBalance(st.msg.From()); have.Cmp(mgval) < 0 {
+	// The balance must cover the fee cap, even if less is charged eventually.
+	balanceCheck := mgval
+	if st.evm.Context.BaseFee != nil {
+		balanceCheck = new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), st.msg.GasFeeCap())
+		if balanceCheck.Cmp(mgval) < 0 {
+			balanceCheck = mgval
+		}
+	}
+	if have := st.state.GetBalance(st.msg.From()); have.Cmp(balanceCheck) < 0 {
 		return errors.Wrapf(
 			errInsufficientBalanceForGas,
-			"had: %s but need: %s", have.String(), mgval.String(),
+			"had: %s but need: %s", have.String(), balanceCheck.String(),
 		)
 	}
 	if err := st.gp.SubGas(st.msg.Gas()); err != nil {
@@ -205,6 +225,23 @@ func (st *StateTransition) preCheck() error {
 			return ErrNonceTooLow
 		}
 	}
+	// Make sure the fee caps cover the base fee of the block (EIP-1559).
+	// Fee-less calls are exempt if the base fee is explicitly disabled.
+	if baseFee := st.evm.Context.BaseFee; baseFee != nil {
+		feeCap, tipCap := st.msg.GasFeeCap(), st.msg.GasTipCap()
+		if !st.evm.Config().NoBaseFee || feeCap.Sign() > 0 || tipCap.Sign() > 0 {
+			if feeCap.Cmp(tipCap) < 0 {
+				return errors.Wrapf(ErrTipAboveFeeCap,
+					"address %s, maxPriorityFeePerGas: %s, maxFeePerGas: %s",
+					st.msg.From().Hex(), tipCap, feeCap)
+			}
+			if feeCap.Cmp(baseFee) < 0 {
+				return errors.Wrapf(types.ErrGasFeeCapTooLow,
+					"address %s, maxFeePerGas: %s, baseFee: %s",
+					st.msg.From().Hex(), feeCap, baseFee)
+			}
+		}
+	}
 	return st.buyGas()
 }
 
@@ -222,7 +259,7 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	contractCreation := msg.To() == nil
 
 	// Pay intrinsic gas
-	gas, err := vm.IntrinsicGas(st.data, contractCreation, homestead, istanbul, false)
+	gas, err := vm.IntrinsicGas(st.data, msg.AccessList(), contractCreation, homestead, istanbul, false)
 	if err != nil {
 		return ExecutionResult{}, err
 	}
@@ -230,6 +267,11 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 		return ExecutionResult{}, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, st.gas, gas)
 	}
 
+	// Warm up the sender, the destination, the precompiles and the access list (EIP-2929)
+	if rules := st.evm.ChainConfig().Rules(st.evm.EpochNumber); rules.IsBerlin {
+		st.state.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(rules), msg.AccessList())
+	}
+
 	evm := st.evm
 
 	var ret []byte
@@ -253,7 +295,13 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 			return ExecutionResult{}, vmErr
 		}
 	}
-	st.refundGas()
+	if st.evm.ChainConfig().IsLondon(st.evm.EpochNumber) {
+		// After EIP-3529: refunds are capped to gasUsed / 5
+		st.refundGas(params.RefundQuotientEIP3529)
+	} else {
+		// Before EIP-3529: refunds were capped to gasUsed / 2
+		st.refundGas(params.RefundQuotient)
+	}
 	st.collectGas()
 
 	return ExecutionResult{
@@ -263,9 +311,9 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	}, err
 }
 
-func (st *StateTransition) refundGas() {
-	// Apply refund counter, capped to half of the used gas.
-	refund := st.gasUsed() / 2
+func (st *StateTransition) refundGas(refundQuotient uint64) {
+	// Apply refund counter, capped to a refund quotient of the used gas.
+	refund := st.gasUsed() / refundQuotient
 	if refund > st.state.GetRefund() {
 		refund = st.state.GetRefund()
 	}
@@ -281,12 +329,22 @@ func (st *StateTransition) refundGas() {
 }
 
 func (st *StateTransition) collectGas() {
+	// The base fee part of the Txn Fees is always burned (EIP-1559),
+	// the rules below only apply to the tip.
+	gasPrice := st.gasPrice
+	if baseFee := st.evm.Context.BaseFee; baseFee != nil {
+		gasPrice = new(big.Int).Sub(gasPrice, baseFee)
+		if gasPrice.Sign() < 0 {
+			// only fee-less calls with the base fee disabled get here
+			gasPrice.SetUint64(0)
+		}
+	}
 	// Burn Txn Fees after staking epoch
 	if config := st.evm.ChainConfig(); !config.IsStaking(st.evm.EpochNumber) {
-		txFee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice)
+		txFee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), gasPrice)
 		st.state.AddBalance(st.evm.Coinbase, txFee)
 	} else if config.IsFeeCollectEpoch(st.evm.EpochNumber) { // collect Txn Fees to community-managed account.
-		txFee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice)
+		txFee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), gasPrice)
 		txFeeCollector := shard.Schedule.InstanceForEpoch(st.evm.EpochNumber).FeeCollector()
 		st.state.AddBalance(txFeeCollector, txFee)
 	}
@@ -311,7 +369,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 	istanbul := st.evm.ChainConfig().IsIstanbul(st.evm.EpochNumber)
 
 	// Pay intrinsic gas
-	gas, err := vm.IntrinsicGas(st.data, false, homestead, istanbul, msg.Type() == types.StakeCreateVal)
+	gas, err := vm.IntrinsicGas(st.data, nil, false, homestead, istanbul, msg.Type() == types.StakeCreateVal)
 
 	if err != nil {
 		return 0, err
@@ -383,7 +441,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 	default:
 		return 0, stakingTypes.ErrInvalidStakingKind
 	}
-	st.refundGas()
+	st.refundGas(params.RefundQuotient)
 	st.collectGas()
 
 	return st.gasUsed(), err
@@ -263,6 +263,7 @@ type TxPool struct {
 	scope        event.SubscriptionScope
 	chainHeadCh  chan ChainHeadEvent
 	chainHeadSub event.Subscription
+	resetCh      chan chan struct{}
 	mu           sync.RWMutex
 
 	currentState  *state.DB           // Current state in the blockchain head
@@ -284,6 +285,8 @@ type TxPool struct {
 
 	homestead bool
 	istanbul  bool
+	berlin    bool
+	london    bool
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -304,6 +307,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig,
 		beats:       make(map[common.Address]time.Time),
 		all:         newTxLookup(),
 		chainHeadCh: make(chan ChainHeadEvent, chainHeadChanSize),
+		resetCh:     make(chan chan struct{}),
 		gasPrice:    new(big.Int).SetUint64(config.PriceLimit),
 		txErrorSink: txErrorSink,
 	}
@@ -313,6 +317,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig,
 		pool.locals.add(addr)
 	}
 	pool.priced = newTxPricedList(pool.all)
+	pool.updateRules(chain.CurrentBlock().Epoch())
 	pool.reset(nil, chain.CurrentBlock().Header())
 
 	// If local transactions and journaling is enabled, load from disk
@@ -336,6 +341,23 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig,
 	return pool
 }
 
+// updateRules enables the transaction validation rules of the forks that are
+// active at the given epoch.
+func (pool *TxPool) updateRules(epoch *big.Int) {
+	if pool.chainconfig.IsS3(epoch) {
+		pool.homestead = true
+	}
+	if pool.chainconfig.IsIstanbul(epoch) {
+		pool.istanbul = true
+	}
+	if pool.chainconfig.IsBerlin(epoch) {
+		pool.berlin = true
+	}
+	if pool.chainconfig.IsLondon(epoch) {
+		pool.london = true
+	}
+}
+
 // loop is the transaction pool's main event loop, waiting for and reacting to
 // outside blockchain events as well as for various reporting and transaction
 // eviction events.
@@ -364,16 +386,20 @@ func (pool *TxPool) loop() {
 		case ev := <-pool.chainHeadCh:
 			if ev.Block != nil {
 				pool.mu.Lock()
-				if pool.chainconfig.IsS3(ev.Block.Epoch()) {
-					pool.homestead = true
-				}
-				if pool.chainconfig.IsIstanbul(ev.Block.Epoch()) {
-					pool.istanbul = true
-				}
+				pool.updateRules(ev.Block.Epoch())
 				pool.reset(head.Header(), ev.Block.Header())
 				head = ev.Block
 				pool.mu.Unlock()
 			}
+		// Handle the explicit resets to the chain head
+		case done := <-pool.resetCh:
+			head = pool.chain.CurrentBlock()
+			pool.mu.Lock()
+			pool.updateRules(head.Epoch())
+			pool.reset(nil, head.Header())
+			pool.mu.Unlock()
+			close(done)
+
 		// Be unsubscribed due to system stopped
 		case <-pool.chainHeadSub.Err():
 			return
@@ -429,6 +455,16 @@ func (pool *TxPool) loop() {
 	}
 }
 
+// Reset synchronously resets the pool to the current head of the chain, without
+// waiting for the chain head event. It is also needed after the chain was
+// rewound with SetHead, which emits no event; the transactions of the discarded
+// blocks are not reinjected.
+func (pool *TxPool) Reset() {
+	done := make(chan struct{})
+	pool.resetCh <- done
+	<-done
+}
+
 // lockedReset is a wrapper around reset to allow calling it in a thread safe
 // manner. This method is only ever used in the tester!
 func (pool *TxPool) lockedReset(oldHead, newHead *block.Header) {
@@ -718,6 +754,27 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	if tx.Value().Sign() < 0 {
 		return errors.WithMessagef(ErrNegativeValue, "transaction value is %s", tx.Value().String())
 	}
+	// Access list transactions are only accepted after Berlin (EIP-2930) and
+	// dynamic fee transactions after London (EIP-1559). The tip of the latter
+	// can never be higher than their fee cap.
+	var accessList types.AccessList
+	if plainTx, ok := tx.(*types.Transaction); ok {
+		accessList = plainTx.AccessList()
+		switch plainTx.Type() {
+		case types.AccessListTxType:
+			if !pool.berlin {
+				return errors.WithMessagef(types.ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+			}
+		case types.DynamicFeeTxType:
+			if !pool.london {
+				return errors.WithMessagef(types.ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+			}
+			if plainTx.GasFeeCap().Cmp(plainTx.GasTipCap()) < 0 {
+				return errors.WithMessagef(ErrTipAboveFeeCap, "transaction tip is %s, fee cap is %s",
+					plainTx.GasTipCap().String(), plainTx.GasFeeCap().String())
+			}
+		}
+	}
 	// Ensure the transaction doesn't exceed the current block limit gas.
 	if pool.currentMaxGas < tx.GasLimit() {
 		return errors.WithMessagef(ErrGasLimit, "transaction gas is %d", tx.GasLimit())
@@ -759,7 +816,8 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 			}
 		}
 	}
-	// Drop non-local transactions under our own minimal accepted gas price
+	// Drop non-local transactions under our own minimal accepted gas price.
+	// The gas price of a dynamic fee transaction is its fee cap.
 	local = local || pool.locals.contains(from) // account may be local even if the transaction arrived from the network
 	if !local && pool.gasPrice.Cmp(tx.GasPrice()) > 0 {
 		gasPrice := new(big.Float).SetInt64(tx.GasPrice().Int64())
@@ -791,9 +849,9 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	}
 	intrGas := uint64(0)
 	if isStakingTx {
-		intrGas, err = vm.IntrinsicGas(tx.Data(), false, pool.homestead, pool.istanbul, stakingTx.StakingType() == staking.DirectiveCreateValidator)
+		intrGas, err = vm.IntrinsicGas(tx.Data(), nil, false, pool.homestead, pool.istanbul, stakingTx.StakingType() == staking.DirectiveCreateValidator)
 	} else {
-		intrGas, err = vm.IntrinsicGas(tx.Data(), tx.To() == nil, pool.homestead, pool.istanbul, false)
+		intrGas, err = vm.IntrinsicGas(tx.Data(), accessList, tx.To() == nil, pool.homestead, pool.istanbul, false)
 	}
 	if err != nil {
 		return err
@@ -326,6 +326,29 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that the fork rules of the current head are applied as soon as the pool
+// is created, so typed transactions are accepted right after a restart.
+func TestTxPoolRulesFromCurrentHead(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.BerlinEpoch = big.NewInt(0)
+	config.LondonEpoch = big.NewInt(0)
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	pool := NewTxPool(testTxPoolConfig, &config, blockchain, dummyErrorSink)
+	defer pool.Stop()
+
+	if !pool.berlin {
+		t.Error("expected berlin rules to be enabled")
+	}
+	if !pool.london {
+		t.Error("expected london rules to be enabled")
+	}
+}
+
 func TestErrorSink(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,147 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccessListTx is the data of an EIP-2930 access list transaction.
+//
+// It is also the RLP payload of a harmony access list transaction which,
+// unlike the ethereum one, carries the source and destination shard IDs.
+type AccessListTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasPrice   *big.Int
+	Gas        uint64
+	ShardID    uint32
+	ToShardID  uint32
+	To         *common.Address `rlp:"nil"` // nil means contract creation
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// ethAccessListTx is the RLP payload of an ethereum access list transaction.
+type ethAccessListTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasPrice   *big.Int
+	Gas        uint64
+	To         *common.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// NewAccessListTransaction returns a new, unsigned access list transaction.
+func NewAccessListTransaction(inner *AccessListTx) *Transaction {
+	var tx Transaction
+	tx.data.setAccessListTx(inner)
+	tx.time = time.Now()
+	return &tx
+}
+
+// NewEthAccessListTransaction returns a new, unsigned ethereum access list
+// transaction. The shard IDs of inner are ignored; the shard of an ethereum
+// transaction is derived from its chain ID.
+func NewEthAccessListTransaction(inner *AccessListTx) *EthTransaction {
+	var tx EthTransaction
+	tx.data.setEthAccessListTx(&ethAccessListTx{
+		ChainID:    inner.ChainID,
+		Nonce:      inner.Nonce,
+		GasPrice:   inner.GasPrice,
+		Gas:        inner.Gas,
+		To:         inner.To,
+		Value:      inner.Value,
+		Data:       inner.Data,
+		AccessList: inner.AccessList,
+		V:          inner.V,
+		R:          inner.R,
+		S:          inner.S,
+	})
+	tx.time = time.Now()
+	return &tx
+}
+
+func (d *txdata) accessListTx() *AccessListTx {
+	return &AccessListTx{
+		ChainID:    d.ChainID,
+		Nonce:      d.AccountNonce,
+		GasPrice:   d.Price,
+		Gas:        d.GasLimit,
+		ShardID:    d.ShardID,
+		ToShardID:  d.ToShardID,
+		To:         d.Recipient,
+		Value:      d.Amount,
+		Data:       d.Payload,
+		AccessList: d.AccessList,
+		V:          d.V,
+		R:          d.R,
+		S:          d.S,
+	}
+}
+
+func (d *txdata) setAccessListTx(inner *AccessListTx) {
+	*d = txdata{
+		Type:         AccessListTxType,
+		ChainID:      newBig(inner.ChainID),
+		AccountNonce: inner.Nonce,
+		Price:        newBig(inner.GasPrice),
+		GasLimit:     inner.Gas,
+		ShardID:      inner.ShardID,
+		ToShardID:    inner.ToShardID,
+		Recipient:    copyAddr(inner.To),
+		Amount:       newBig(inner.Value),
+		Payload:      common.CopyBytes(inner.Data),
+		AccessList:   inner.AccessList.Copy(),
+		V:            newBig(inner.V),
+		R:            newBig(inner.R),
+		S:            newBig(inner.S),
+	}
+}
+
+func (d *ethTxdata) ethAccessListTx() *ethAccessListTx {
+	return &ethAccessListTx{
+		ChainID:    d.ChainID,
+		Nonce:      d.AccountNonce,
+		GasPrice:   d.Price,
+		Gas:        d.GasLimit,
+		To:         d.Recipient,
+		Value:      d.Amount,
+		Data:       d.Payload,
+		AccessList: d.AccessList,
+		V:          d.V,
+		R:          d.R,
+		S:          d.S,
+	}
+}
+
+func (d *ethTxdata) setEthAccessListTx(inner *ethAccessListTx) {
+	*d = ethTxdata{
+		Type:         AccessListTxType,
+		ChainID:      newBig(inner.ChainID),
+		AccountNonce: inner.Nonce,
+		Price:        newBig(inner.GasPrice),
+		GasLimit:     inner.Gas,
+		Recipient:    copyAddr(inner.To),
+		Amount:       newBig(inner.Value),
+		Payload:      common.CopyBytes(inner.Data),
+		AccessList:   inner.AccessList.Copy(),
+		V:            newBig(inner.V),
+		R:            newBig(inner.R),
+		S:            newBig(inner.S),
+	}
+}
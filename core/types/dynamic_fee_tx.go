@@ -0,0 +1,307 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Transaction types, as defined by EIP-2718.
+const (
+	LegacyTxType     = 0x00
+	AccessListTxType = 0x01
+	DynamicFeeTxType = 0x02
+)
+
+var (
+	// ErrTxTypeNotSupported is returned if a transaction is not supported in the
+	// current network configuration.
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+	// ErrGasFeeCapTooLow is returned if the transaction fee cap is less than the
+	// base fee of the block.
+	ErrGasFeeCapTooLow = errors.New("max fee per gas less than block base fee")
+
+	errEmptyTypedTx = errors.New("empty typed transaction bytes")
+)
+
+// DynamicFeeTx is the data of an EIP-1559 dynamic fee transaction.
+//
+// It is also the RLP payload of a harmony dynamic fee transaction which,
+// unlike the ethereum one, carries the source and destination shard IDs.
+type DynamicFeeTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap  *big.Int // a.k.a. maxFeePerGas
+	Gas        uint64
+	ShardID    uint32
+	ToShardID  uint32
+	To         *common.Address `rlp:"nil"` // nil means contract creation
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// ethDynamicFeeTx is the RLP payload of an ethereum dynamic fee transaction.
+type ethDynamicFeeTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         *common.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// NewDynamicFeeTransaction returns a new, unsigned dynamic fee transaction.
+func NewDynamicFeeTransaction(inner *DynamicFeeTx) *Transaction {
+	var tx Transaction
+	tx.data.setDynamicFeeTx(inner)
+	tx.time = time.Now()
+	return &tx
+}
+
+// NewEthDynamicFeeTransaction returns a new, unsigned ethereum dynamic fee
+// transaction. The shard IDs of inner are ignored; the shard of an ethereum
+// transaction is derived from its chain ID.
+func NewEthDynamicFeeTransaction(inner *DynamicFeeTx) *EthTransaction {
+	var tx EthTransaction
+	tx.data.setEthDynamicFeeTx(&ethDynamicFeeTx{
+		ChainID:    inner.ChainID,
+		Nonce:      inner.Nonce,
+		GasTipCap:  inner.GasTipCap,
+		GasFeeCap:  inner.GasFeeCap,
+		Gas:        inner.Gas,
+		To:         inner.To,
+		Value:      inner.Value,
+		Data:       inner.Data,
+		AccessList: inner.AccessList,
+		V:          inner.V,
+		R:          inner.R,
+		S:          inner.S,
+	})
+	tx.time = time.Now()
+	return &tx
+}
+
+// EffectiveGasPrice returns the price per gas paid by a transaction with the
+// given tip and fee caps in a block with the given base fee, which is
+// min(gasFeeCap, baseFee+gasTipCap). A nil baseFee means the block predates
+// EIP-1559, where the fee cap is the gas price.
+func EffectiveGasPrice(gasTipCap, gasFeeCap, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int).Set(gasFeeCap)
+	}
+	price := new(big.Int).Add(gasTipCap, baseFee)
+	if price.Cmp(gasFeeCap) > 0 {
+		price.Set(gasFeeCap)
+	}
+	return price
+}
+
+// effectiveGasTip returns the tip per gas paid to the block producer for the
+// given base fee, or ErrGasFeeCapTooLow if the fee cap does not cover it.
+func effectiveGasTip(gasTipCap, gasFeeCap, baseFee *big.Int) (*big.Int, error) {
+	if baseFee == nil {
+		return new(big.Int).Set(gasTipCap), nil
+	}
+	if gasFeeCap.Cmp(baseFee) < 0 {
+		return nil, ErrGasFeeCapTooLow
+	}
+	tip := new(big.Int).Sub(gasFeeCap, baseFee)
+	if tip.Cmp(gasTipCap) > 0 {
+		tip.Set(gasTipCap)
+	}
+	return tip, nil
+}
+
+func copyBig(x *big.Int) *big.Int {
+	if x == nil {
+		return nil
+	}
+	return new(big.Int).Set(x)
+}
+
+func newBig(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(x)
+}
+
+func (d *txdata) dynamicFeeTx() *DynamicFeeTx {
+	return &DynamicFeeTx{
+		ChainID:    d.ChainID,
+		Nonce:      d.AccountNonce,
+		GasTipCap:  d.GasTipCap,
+		GasFeeCap:  d.Price,
+		Gas:        d.GasLimit,
+		ShardID:    d.ShardID,
+		ToShardID:  d.ToShardID,
+		To:         d.Recipient,
+		Value:      d.Amount,
+		Data:       d.Payload,
+		AccessList: d.AccessList,
+		V:          d.V,
+		R:          d.R,
+		S:          d.S,
+	}
+}
+
+func (d *txdata) setDynamicFeeTx(inner *DynamicFeeTx) {
+	*d = txdata{
+		Type:         DynamicFeeTxType,
+		ChainID:      newBig(inner.ChainID),
+		AccountNonce: inner.Nonce,
+		GasTipCap:    newBig(inner.GasTipCap),
+		Price:        newBig(inner.GasFeeCap),
+		GasLimit:     inner.Gas,
+		ShardID:      inner.ShardID,
+		ToShardID:    inner.ToShardID,
+		Recipient:    copyAddr(inner.To),
+		Amount:       newBig(inner.Value),
+		Payload:      common.CopyBytes(inner.Data),
+		AccessList:   inner.AccessList.Copy(),
+		V:            newBig(inner.V),
+		R:            newBig(inner.R),
+		S:            newBig(inner.S),
+	}
+}
+
+// encodeTyped returns the EIP-2718 envelope of a typed transaction, which is
+// the transaction type followed by the RLP encoding of its payload.
+func (d *txdata) encodeTyped() ([]byte, error) {
+	var payload interface{}
+	switch d.Type {
+	case AccessListTxType:
+		payload = d.accessListTx()
+	case DynamicFeeTxType:
+		payload = d.dynamicFeeTx()
+	default:
+		return nil, ErrTxTypeNotSupported
+	}
+	enc, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{d.Type}, enc...), nil
+}
+
+// decodeTyped decodes the EIP-2718 envelope of a typed transaction.
+func (d *txdata) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var inner AccessListTx
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		d.setAccessListTx(&inner)
+		return nil
+	case DynamicFeeTxType:
+		var inner DynamicFeeTx
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		d.setDynamicFeeTx(&inner)
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+func (d *ethTxdata) ethDynamicFeeTx() *ethDynamicFeeTx {
+	return &ethDynamicFeeTx{
+		ChainID:    d.ChainID,
+		Nonce:      d.AccountNonce,
+		GasTipCap:  d.GasTipCap,
+		GasFeeCap:  d.Price,
+		Gas:        d.GasLimit,
+		To:         d.Recipient,
+		Value:      d.Amount,
+		Data:       d.Payload,
+		AccessList: d.AccessList,
+		V:          d.V,
+		R:          d.R,
+		S:          d.S,
+	}
+}
+
+func (d *ethTxdata) setEthDynamicFeeTx(inner *ethDynamicFeeTx) {
+	*d = ethTxdata{
+		Type:         DynamicFeeTxType,
+		ChainID:      newBig(inner.ChainID),
+		AccountNonce: inner.Nonce,
+		GasTipCap:    newBig(inner.GasTipCap),
+		Price:        newBig(inner.GasFeeCap),
+		GasLimit:     inner.Gas,
+		Recipient:    copyAddr(inner.To),
+		Amount:       newBig(inner.Value),
+		Payload:      common.CopyBytes(inner.Data),
+		AccessList:   inner.AccessList.Copy(),
+		V:            newBig(inner.V),
+		R:            newBig(inner.R),
+		S:            newBig(inner.S),
+	}
+}
+
+// encodeTyped returns the EIP-2718 envelope of a typed transaction, which is
+// the transaction type followed by the RLP encoding of its payload.
+func (d *ethTxdata) encodeTyped() ([]byte, error) {
+	var payload interface{}
+	switch d.Type {
+	case AccessListTxType:
+		payload = d.ethAccessListTx()
+	case DynamicFeeTxType:
+		payload = d.ethDynamicFeeTx()
+	default:
+		return nil, ErrTxTypeNotSupported
+	}
+	enc, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{d.Type}, enc...), nil
+}
+
+// decodeTyped decodes the EIP-2718 envelope of a typed transaction.
+func (d *ethTxdata) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var inner ethAccessListTx
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		d.setEthAccessListTx(&inner)
+		return nil
+	case DynamicFeeTxType:
+		var inner ethDynamicFeeTx
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		d.setEthDynamicFeeTx(&inner)
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
@@ -64,6 +64,14 @@ type ethTxdata struct {
 
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
+
+	// Typed transaction (EIP-2718) fields. They are not part of the legacy
+	// RLP encoding, typed transactions are encoded as an envelope instead.
+	// Price is the fee cap of a dynamic fee transaction.
+	Type       uint8      `json:"type"                 rlp:"-"`
+	ChainID    *big.Int   `json:"chainId"              rlp:"-"`
+	GasTipCap  *big.Int   `json:"maxPriorityFeePerGas" rlp:"-"`
+	AccessList AccessList `json:"accessList"           rlp:"-"`
 }
 
 func (d *ethTxdata) CopyFrom(d2 *ethTxdata) {
@@ -77,6 +85,10 @@ func (d *ethTxdata) CopyFrom(d2 *ethTxdata) {
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
 	d.Hash = copyHash(d2.Hash)
+	d.Type = d2.Type
+	d.ChainID = copyBig(d2.ChainID)
+	d.GasTipCap = copyBig(d2.GasTipCap)
+	d.AccessList = d2.AccessList.Copy()
 }
 
 type ethTxdataMarshaling struct {
@@ -177,14 +189,53 @@ func (tx *EthTransaction) shardID() uint32 {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *EthTransaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		return tx.data.ChainID
+	}
 	return deriveChainID(tx.data.V)
 }
 
 // Protected returns whether the transaction is protected from replay protection.
+// Typed transactions are always protected.
 func (tx *EthTransaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
+// Type returns the EIP-2718 type of the transaction.
+func (tx *EthTransaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// GasTipCap returns the max priority fee per gas of the transaction.
+// It is the gas price for transactions without a dynamic fee.
+func (tx *EthTransaction) GasTipCap() *big.Int {
+	if tx.data.GasTipCap == nil {
+		return tx.data.Price
+	}
+	return tx.data.GasTipCap
+}
+
+// GasFeeCap returns the max fee per gas of the transaction.
+// It is the gas price for transactions without a dynamic fee.
+func (tx *EthTransaction) GasFeeCap() *big.Int {
+	return tx.data.Price
+}
+
+// AccessList returns the access list of the transaction, which is empty for
+// legacy transactions.
+func (tx *EthTransaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
+// EffectiveGasTip returns the tip per gas paid to the block producer under the
+// given base fee, or ErrGasFeeCapTooLow if the fee cap cannot cover the base fee.
+func (tx *EthTransaction) EffectiveGasTip(baseFee *big.Int) (*big.Int, error) {
+	return effectiveGasTip(tx.GasTipCap(), tx.GasFeeCap(), baseFee)
+}
+
 // Copy returns a copy of the transaction.
 func (tx *EthTransaction) Copy() *EthTransaction {
 	var tx2 EthTransaction
@@ -212,6 +263,11 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	d2.ShardID = tx.ShardID()
 	d2.ToShardID = tx.ToShardID()
 
+	d2.Type = d.Type
+	d2.ChainID = copyBig(d.ChainID)
+	d2.GasTipCap = copyBig(d.GasTipCap)
+	d2.AccessList = d.AccessList.Copy()
+
 	copy := tx2.Hash()
 	d2.Hash = &copy
 
@@ -220,21 +276,71 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	return &tx2
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder. Typed transactions are encoded as an RLP
+// string holding their EIP-2718 envelope.
 func (tx *EthTransaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.data.encodeTyped()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *EthTransaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
-	if err == nil {
+	kind, size, err := s.Kind()
+	switch {
+	case err != nil:
+		return err
+	case kind == rlp.List:
+		var data ethTxdata
+		if err := s.Decode(&data); err != nil {
+			return err
+		}
+		tx.data = data
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
-		tx.time = time.Now()
+	default:
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		if err := tx.data.decodeTyped(b); err != nil {
+			return err
+		}
+		tx.size.Store(common.StorageSize(len(b)))
+	}
+	tx.time = time.Now()
+	return nil
+}
+
+// MarshalBinary returns the canonical encoding of the transaction: the RLP
+// list for legacy transactions and the EIP-2718 envelope for typed ones.
+// This is the format of raw transactions submitted over the ethereum RPC.
+func (tx *EthTransaction) MarshalBinary() ([]byte, error) {
+	if tx.data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
 	}
+	return tx.data.encodeTyped()
+}
 
-	return err
+// UnmarshalBinary decodes the canonical encoding of a transaction.
+func (tx *EthTransaction) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 && b[0] > 0x7f {
+		// legacy transactions are RLP lists
+		var data ethTxdata
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+		tx.data = data
+	} else if err := tx.data.decodeTyped(b); err != nil {
+		return err
+	}
+	tx.size.Store(common.StorageSize(len(b)))
+	tx.time = time.Now()
+	return nil
 }
 
 // MarshalJSON encodes the web3 RPC transaction format.
@@ -255,7 +361,9 @@ func (tx *EthTransaction) UnmarshalJSON(input []byte) error {
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -306,7 +414,13 @@ func (tx *EthTransaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		enc, _ := tx.data.encodeTyped()
+		v = hash.Keccak256Hash(enc)
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -318,7 +432,12 @@ func (tx *EthTransaction) Size() common.StorageSize {
 		return size.(common.StorageSize)
 	}
 	c := writeCounter(0)
-	rlp.Encode(&c, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		rlp.Encode(&c, &tx.data)
+	} else {
+		enc, _ := tx.data.encodeTyped()
+		c = writeCounter(len(enc))
+	}
 	tx.size.Store(common.StorageSize(c))
 	return common.StorageSize(c)
 }
@@ -336,6 +455,8 @@ func (tx *EthTransaction) SenderAddress() (common.Address, error) {
 	var signer Signer
 	if !tx.Protected() {
 		signer = HomesteadSigner{}
+	} else if tx.Type() != LegacyTxType {
+		signer = NewLondonSigner(tx.ChainID())
 	} else {
 		signer = NewEIP155Signer(tx.ChainID())
 	}
@@ -361,9 +482,12 @@ func (tx *EthTransaction) AsMessage(s Signer) (Message, error) {
 		nonce:      tx.data.AccountNonce,
 		gasLimit:   tx.data.GasLimit,
 		gasPrice:   new(big.Int).Set(tx.data.Price),
+		gasFeeCap:  new(big.Int).Set(tx.GasFeeCap()),
+		gasTipCap:  new(big.Int).Set(tx.GasTipCap()),
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		accessList: tx.data.AccessList,
 		checkNonce: true,
 	}
 
@@ -26,6 +26,11 @@ func (e ethTxdata) MarshalJSON() ([]byte, error) {
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
+		Type         hexutil.Uint64  `json:"type,omitempty"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+		GasFeeCap    *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+		AccessList   *AccessList     `json:"accessList,omitempty"`
 	}
 	var enc ethTxdata
 	enc.AccountNonce = hexutil.Uint64(e.AccountNonce)
@@ -38,6 +43,15 @@ func (e ethTxdata) MarshalJSON() ([]byte, error) {
 	enc.R = (*hexutil.Big)(e.R)
 	enc.S = (*hexutil.Big)(e.S)
 	enc.Hash = e.Hash
+	if e.Type != LegacyTxType {
+		enc.Type = hexutil.Uint64(e.Type)
+		enc.ChainID = (*hexutil.Big)(e.ChainID)
+		if e.Type == DynamicFeeTxType {
+			enc.GasTipCap = (*hexutil.Big)(e.GasTipCap)
+			enc.GasFeeCap = (*hexutil.Big)(e.Price)
+		}
+		enc.AccessList = &e.AccessList
+	}
 	return json.Marshal(&enc)
 }
 
@@ -54,6 +68,11 @@ func (e *ethTxdata) UnmarshalJSON(input []byte) error {
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
+		Type         *hexutil.Uint64 `json:"type,omitempty"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+		GasFeeCap    *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+		AccessList   *AccessList     `json:"accessList,omitempty"`
 	}
 	var dec ethTxdata
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -63,6 +82,26 @@ func (e *ethTxdata) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'nonce' for ethTxdata")
 	}
 	e.AccountNonce = uint64(*dec.AccountNonce)
+	if dec.Type != nil && *dec.Type != LegacyTxType {
+		e.Type = uint8(*dec.Type)
+		if dec.ChainID == nil {
+			return errors.New("missing required field 'chainId' for ethTxdata")
+		}
+		e.ChainID = (*big.Int)(dec.ChainID)
+		if e.Type == DynamicFeeTxType {
+			if dec.GasTipCap == nil {
+				return errors.New("missing required field 'maxPriorityFeePerGas' for ethTxdata")
+			}
+			e.GasTipCap = (*big.Int)(dec.GasTipCap)
+			if dec.GasFeeCap == nil {
+				return errors.New("missing required field 'maxFeePerGas' for ethTxdata")
+			}
+			dec.Price = dec.GasFeeCap
+		}
+		if dec.AccessList != nil {
+			e.AccessList = *dec.AccessList
+		}
+	}
 	if dec.Price == nil {
 		return errors.New("missing required field 'gasPrice' for ethTxdata")
 	}
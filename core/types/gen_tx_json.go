@@ -28,6 +28,11 @@ func (t txdata) MarshalJSON() ([]byte, error) {
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
+		Type         hexutil.Uint64  `json:"type,omitempty"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+		GasFeeCap    *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+		AccessList   *AccessList     `json:"accessList,omitempty"`
 	}
 	var enc txdata
 	enc.AccountNonce = hexutil.Uint64(t.AccountNonce)
@@ -42,6 +47,15 @@ func (t txdata) MarshalJSON() ([]byte, error) {
 	enc.R = (*hexutil.Big)(t.R)
 	enc.S = (*hexutil.Big)(t.S)
 	enc.Hash = t.Hash
+	if t.Type != LegacyTxType {
+		enc.Type = hexutil.Uint64(t.Type)
+		enc.ChainID = (*hexutil.Big)(t.ChainID)
+		if t.Type == DynamicFeeTxType {
+			enc.GasTipCap = (*hexutil.Big)(t.GasTipCap)
+			enc.GasFeeCap = (*hexutil.Big)(t.Price)
+		}
+		enc.AccessList = &t.AccessList
+	}
 	return json.Marshal(&enc)
 }
 
@@ -60,6 +74,11 @@ func (t *txdata) UnmarshalJSON(input []byte) error {
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
+		Type         *hexutil.Uint64 `json:"type,omitempty"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+		GasFeeCap    *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+		AccessList   *AccessList     `json:"accessList,omitempty"`
 	}
 	var dec txdata
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -69,6 +88,26 @@ func (t *txdata) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'nonce' for txdata")
 	}
 	t.AccountNonce = uint64(*dec.AccountNonce)
+	if dec.Type != nil && *dec.Type != LegacyTxType {
+		t.Type = uint8(*dec.Type)
+		if dec.ChainID == nil {
+			return errors.New("missing required field 'chainId' for txdata")
+		}
+		t.ChainID = (*big.Int)(dec.ChainID)
+		if t.Type == DynamicFeeTxType {
+			if dec.GasTipCap == nil {
+				return errors.New("missing required field 'maxPriorityFeePerGas' for txdata")
+			}
+			t.GasTipCap = (*big.Int)(dec.GasTipCap)
+			if dec.GasFeeCap == nil {
+				return errors.New("missing required field 'maxFeePerGas' for txdata")
+			}
+			dec.Price = dec.GasFeeCap
+		}
+		if dec.AccessList != nil {
+			t.AccessList = *dec.AccessList
+		}
+	}
 	if dec.Price == nil {
 		return errors.New("missing required field 'gasPrice' for txdata")
 	}
@@ -75,6 +75,11 @@ type InternalTransaction interface {
 
 	IsEthCompatible() bool
 	AsMessage(s Signer) (Message, error)
+
+	// EIP-2718 transaction type and EIP-1559 fee caps
+	Type() uint8
+	GasTipCap() *big.Int
+	GasFeeCap() *big.Int
 }
 
 // CoreTransaction defines the core funcs of any transactions
@@ -145,6 +150,14 @@ type txdata struct {
 
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
+
+	// Typed transaction (EIP-2718) fields. They are not part of the legacy
+	// RLP encoding, typed transactions are encoded as an envelope instead.
+	// Price is the fee cap of a dynamic fee transaction.
+	Type       uint8      `json:"type"                 rlp:"-"`
+	ChainID    *big.Int   `json:"chainId"              rlp:"-"`
+	GasTipCap  *big.Int   `json:"maxPriorityFeePerGas" rlp:"-"`
+	AccessList AccessList `json:"accessList"           rlp:"-"`
 }
 
 func copyAddr(addr *common.Address) *common.Address {
@@ -176,6 +189,10 @@ func (d *txdata) CopyFrom(d2 *txdata) {
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
 	d.Hash = copyHash(d2.Hash)
+	d.Type = d2.Type
+	d.ChainID = copyBig(d2.ChainID)
+	d.GasTipCap = copyBig(d2.GasTipCap)
+	d.AccessList = d2.AccessList.Copy()
 }
 
 type txdataMarshaling struct {
@@ -300,9 +317,44 @@ func (tx *Transaction) Data() []byte {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *Transaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		return tx.data.ChainID
+	}
 	return deriveChainID(tx.data.V)
 }
 
+// Type returns the EIP-2718 type of the transaction.
+func (tx *Transaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// GasTipCap returns the max priority fee per gas of the transaction.
+// It is the gas price for transactions without a dynamic fee.
+func (tx *Transaction) GasTipCap() *big.Int {
+	if tx.data.GasTipCap == nil {
+		return tx.data.Price
+	}
+	return tx.data.GasTipCap
+}
+
+// GasFeeCap returns the max fee per gas of the transaction.
+// It is the gas price for transactions without a dynamic fee.
+func (tx *Transaction) GasFeeCap() *big.Int {
+	return tx.data.Price
+}
+
+// AccessList returns the access list of the transaction, which is empty for
+// legacy transactions.
+func (tx *Transaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
+// EffectiveGasTip returns the tip per gas paid to the block producer under the
+// given base fee, or ErrGasFeeCapTooLow if the fee cap cannot cover the base fee.
+func (tx *Transaction) EffectiveGasTip(baseFee *big.Int) (*big.Int, error) {
+	return effectiveGasTip(tx.GasTipCap(), tx.GasFeeCap(), baseFee)
+}
+
 // ShardID returns which shard id this transaction was signed for (if at all)
 func (tx *Transaction) ShardID() uint32 {
 	return tx.data.ShardID
@@ -319,7 +371,11 @@ func (tx *Transaction) Time() time.Time {
 }
 
 // Protected returns whether the transaction is protected from replay protection.
+// Typed transactions are always protected.
 func (tx *Transaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
@@ -332,21 +388,70 @@ func isProtectedV(V *big.Int) bool {
 	return true
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder. Typed transactions are encoded as an RLP
+// string holding their EIP-2718 envelope.
 func (tx *Transaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.data.encodeTyped()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
-	if err == nil {
+	kind, size, err := s.Kind()
+	switch {
+	case err != nil:
+		return err
+	case kind == rlp.List:
+		var data txdata
+		if err := s.Decode(&data); err != nil {
+			return err
+		}
+		tx.data = data
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
-		tx.time = time.Now()
+	default:
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		if err := tx.data.decodeTyped(b); err != nil {
+			return err
+		}
+		tx.size.Store(common.StorageSize(len(b)))
 	}
+	tx.time = time.Now()
+	return nil
+}
+
+// MarshalBinary returns the canonical encoding of the transaction: the RLP
+// list for legacy transactions and the EIP-2718 envelope for typed ones.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	if tx.data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	return tx.data.encodeTyped()
+}
 
-	return err
+// UnmarshalBinary decodes the canonical encoding of a transaction.
+func (tx *Transaction) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 && b[0] > 0x7f {
+		// legacy transactions are RLP lists
+		var data txdata
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+		tx.data = data
+	} else if err := tx.data.decodeTyped(b); err != nil {
+		return err
+	}
+	tx.size.Store(common.StorageSize(len(b)))
+	tx.time = time.Now()
+	return nil
 }
 
 // MarshalJSON encodes the web3 RPC transaction format.
@@ -367,7 +472,9 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -408,7 +515,13 @@ func (tx *Transaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		enc, _ := tx.data.encodeTyped()
+		v = hash.Keccak256Hash(enc)
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -429,7 +542,12 @@ func (tx *Transaction) Size() common.StorageSize {
 		return size.(common.StorageSize)
 	}
 	c := writeCounter(0)
-	rlp.Encode(&c, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		rlp.Encode(&c, &tx.data)
+	} else {
+		enc, _ := tx.data.encodeTyped()
+		c = writeCounter(len(enc))
+	}
 	tx.size.Store(common.StorageSize(c))
 	return common.StorageSize(c)
 }
@@ -454,6 +572,10 @@ func (tx *Transaction) ConvertToEth() *EthTransaction {
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
+	d2.Type = d.Type
+	d2.ChainID = copyBig(d.ChainID)
+	d2.GasTipCap = copyBig(d.GasTipCap)
+	d2.AccessList = d.AccessList.Copy()
 
 	copy := tx2.Hash()
 	d2.Hash = &copy
@@ -473,9 +595,12 @@ func (tx *Transaction) AsMessage(s Signer) (Message, error) {
 		nonce:      tx.data.AccountNonce,
 		gasLimit:   tx.data.GasLimit,
 		gasPrice:   new(big.Int).Set(tx.data.Price),
+		gasFeeCap:  new(big.Int).Set(tx.GasFeeCap()),
+		gasTipCap:  new(big.Int).Set(tx.GasTipCap()),
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		accessList: tx.data.AccessList,
 		checkNonce: true,
 	}
 
@@ -522,6 +647,8 @@ func (tx *Transaction) SenderAddress() (common.Address, error) {
 	var signer Signer
 	if !tx.Protected() {
 		signer = HomesteadSigner{}
+	} else if tx.Type() != LegacyTxType {
+		signer = NewLondonSigner(tx.ChainID())
 	} else {
 		signer = NewEIP155Signer(tx.ChainID())
 	}
@@ -678,7 +805,10 @@ type Message struct {
 	amount     *big.Int
 	gasLimit   uint64
 	gasPrice   *big.Int
+	gasFeeCap  *big.Int
+	gasTipCap  *big.Int
 	data       []byte
+	accessList AccessList
 	checkNonce bool
 	blockNum   *big.Int
 	txType     TransactionType
@@ -727,6 +857,24 @@ func (m Message) GasPrice() *big.Int {
 	return m.gasPrice
 }
 
+// GasFeeCap returns the max fee per gas from Message.
+// It is the gas price for messages without a dynamic fee.
+func (m Message) GasFeeCap() *big.Int {
+	if m.gasFeeCap == nil {
+		return m.gasPrice
+	}
+	return m.gasFeeCap
+}
+
+// GasTipCap returns the max priority fee per gas from Message.
+// It is the gas price for messages without a dynamic fee.
+func (m Message) GasTipCap() *big.Int {
+	if m.gasTipCap == nil {
+		return m.gasPrice
+	}
+	return m.gasTipCap
+}
+
 // Value returns the value amount from Message.
 func (m Message) Value() *big.Int {
 	return m.amount
@@ -747,6 +895,11 @@ func (m Message) Data() []byte {
 	return m.data
 }
 
+// AccessList returns the EIP-2930 access list of the Message.
+func (m Message) AccessList() AccessList {
+	return m.accessList
+}
+
 // CheckNonce returns checkNonce of Message.
 func (m Message) CheckNonce() bool {
 	return m.checkNonce
@@ -762,6 +915,11 @@ func (m *Message) SetType(typ TransactionType) {
 	m.txType = typ
 }
 
+// SetAccessList set the EIP-2930 access list of message
+func (m *Message) SetAccessList(accessList AccessList) {
+	m.accessList = accessList
+}
+
 // BlockNum returns the blockNum of the tx belongs to
 func (m Message) BlockNum() *big.Int {
 	return m.blockNum
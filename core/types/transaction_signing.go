@@ -24,9 +24,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/harmony-one/harmony/crypto/hash"
-	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/params"
 )
 
@@ -46,14 +46,31 @@ type sigCache struct {
 func MakeSigner(config *params.ChainConfig, epochNumber *big.Int) Signer {
 	var signer Signer
 	switch {
+	case config.IsLondon(epochNumber):
+		signer = LondonSigner{BerlinSigner{newEIP155Signer(config.ChainID, config.EthCompatibleChainID)}}
+	case config.IsBerlin(epochNumber):
+		signer = BerlinSigner{newEIP155Signer(config.ChainID, config.EthCompatibleChainID)}
 	case config.IsEIP155(epochNumber):
-		signer = NewEIP155Signer(config.ChainID)
+		signer = newEIP155Signer(config.ChainID, config.EthCompatibleChainID)
 	default:
 		signer = FrontierSigner{}
 	}
 	return signer
 }
 
+// MakeEthSigner returns the Signer of ethereum compatible transactions based on
+// the given chain config and epoch number.
+func MakeEthSigner(config *params.ChainConfig, epochNumber *big.Int) Signer {
+	switch {
+	case config.IsLondon(epochNumber):
+		return NewLondonSigner(config.EthCompatibleChainID)
+	case config.IsBerlin(epochNumber):
+		return NewBerlinSigner(config.EthCompatibleChainID)
+	default:
+		return NewEIP155Signer(config.EthCompatibleChainID)
+	}
+}
+
 // SignTx signs the transaction using the given signer and private key
 func SignTx(tx *Transaction, s Signer, prv *ecdsa.PrivateKey) (*Transaction, error) {
 	h := s.Hash(tx)
@@ -114,43 +131,264 @@ type Signer interface {
 	Equal(Signer) bool
 }
 
+// LondonSigner implements Signer using the EIP-1559 rules. It accepts dynamic
+// fee transactions as well as all the transactions accepted by BerlinSigner.
+type LondonSigner struct {
+	BerlinSigner
+}
+
+// NewLondonSigner creates a LondonSigner given chainID.
+func NewLondonSigner(chainID *big.Int) LondonSigner {
+	return LondonSigner{NewBerlinSigner(chainID)}
+}
+
+// Equal checks if the given LondonSigner is equal to another Signer.
+func (s LondonSigner) Equal(s2 Signer) bool {
+	london, ok := s2.(LondonSigner)
+	return ok && s.EIP155Signer.Equal(london.EIP155Signer)
+}
+
+// Sender returns the sender address of the given transaction.
+func (s LondonSigner) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != DynamicFeeTxType {
+		return s.BerlinSigner.Sender(tx)
+	}
+	return s.typedSender(tx, s.Hash(tx))
+}
+
+// SignatureValues returns signature values. This signature
+// needs to be in the [R || S || V] format where V is 0 or 1.
+func (s LondonSigner) SignatureValues(tx InternalTransaction, sig []byte) (R, S, V *big.Int, err error) {
+	if tx.Type() != DynamicFeeTxType {
+		return s.BerlinSigner.SignatureValues(tx, sig)
+	}
+	return s.typedSignatureValues(tx, sig)
+}
+
+// Hash returns the hash to be signed by the sender.
+// It does not uniquely identify the transaction.
+func (s LondonSigner) Hash(tx InternalTransaction) common.Hash {
+	if tx.Type() != DynamicFeeTxType {
+		return s.BerlinSigner.Hash(tx)
+	}
+	if params.IsEthCompatible(tx.ChainID()) {
+		return prefixedRLPHash(tx.Type(), []interface{}{
+			tx.ChainID(),
+			tx.Nonce(),
+			tx.GasTipCap(),
+			tx.GasFeeCap(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			accessListOf(tx),
+		})
+	}
+	return prefixedRLPHash(tx.Type(), []interface{}{
+		tx.ChainID(),
+		tx.Nonce(),
+		tx.GasTipCap(),
+		tx.GasFeeCap(),
+		tx.GasLimit(),
+		tx.ShardID(),
+		tx.ToShardID(),
+		tx.To(),
+		tx.Value(),
+		tx.Data(),
+		accessListOf(tx),
+	})
+}
+
+// BerlinSigner implements Signer using the EIP-2930 rules. It accepts access
+// list transactions as well as all the transactions accepted by EIP155Signer.
+type BerlinSigner struct {
+	EIP155Signer
+}
+
+// NewBerlinSigner creates a BerlinSigner given chainID.
+func NewBerlinSigner(chainID *big.Int) BerlinSigner {
+	return BerlinSigner{NewEIP155Signer(chainID)}
+}
+
+// Equal checks if the given BerlinSigner is equal to another Signer.
+func (s BerlinSigner) Equal(s2 Signer) bool {
+	berlin, ok := s2.(BerlinSigner)
+	return ok && s.EIP155Signer.Equal(berlin.EIP155Signer)
+}
+
+// Sender returns the sender address of the given transaction.
+func (s BerlinSigner) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != AccessListTxType {
+		return s.EIP155Signer.Sender(tx)
+	}
+	return s.typedSender(tx, s.Hash(tx))
+}
+
+// SignatureValues returns signature values. This signature
+// needs to be in the [R || S || V] format where V is 0 or 1.
+func (s BerlinSigner) SignatureValues(tx InternalTransaction, sig []byte) (R, S, V *big.Int, err error) {
+	if tx.Type() != AccessListTxType {
+		return s.EIP155Signer.SignatureValues(tx, sig)
+	}
+	return s.typedSignatureValues(tx, sig)
+}
+
+// Hash returns the hash to be signed by the sender.
+// It does not uniquely identify the transaction.
+func (s BerlinSigner) Hash(tx InternalTransaction) common.Hash {
+	if tx.Type() != AccessListTxType {
+		return s.EIP155Signer.Hash(tx)
+	}
+	if params.IsEthCompatible(tx.ChainID()) {
+		return prefixedRLPHash(tx.Type(), []interface{}{
+			tx.ChainID(),
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			accessListOf(tx),
+		})
+	}
+	return prefixedRLPHash(tx.Type(), []interface{}{
+		tx.ChainID(),
+		tx.Nonce(),
+		tx.GasPrice(),
+		tx.GasLimit(),
+		tx.ShardID(),
+		tx.ToShardID(),
+		tx.To(),
+		tx.Value(),
+		tx.Data(),
+		accessListOf(tx),
+	})
+}
+
+// typedSender recovers the sender of a typed transaction from its signing hash.
+func (s EIP155Signer) typedSender(tx InternalTransaction, sighash common.Hash) (common.Address, error) {
+	if err := s.checkChainID(tx.ChainID()); err != nil {
+		return common.Address{}, err
+	}
+	// typed transactions use 0 and 1 as signature V
+	V := new(big.Int).Add(tx.V(), big27)
+	return recoverPlain(sighash, tx.R(), tx.S(), V, true)
+}
+
+// typedSignatureValues returns the signature values of a typed transaction.
+func (s EIP155Signer) typedSignatureValues(tx InternalTransaction, sig []byte) (R, S, V *big.Int, err error) {
+	if err := s.checkChainID(tx.ChainID()); err != nil {
+		return nil, nil, nil, err
+	}
+	R, S, _, err = HomesteadSigner{}.SignatureValues(tx, sig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	V = big.NewInt(int64(sig[64]))
+	return R, S, V, nil
+}
+
+// checkChainID checks that a transaction is signed for the chain of the
+// signer, or for the ethereum compatible chain the signer was created with.
+func (s EIP155Signer) checkChainID(chainID *big.Int) error {
+	if chainID == nil {
+		return ErrInvalidChainID
+	}
+	if chainID.Cmp(s.chainID) == 0 {
+		return nil
+	}
+	if s.ethChainID != nil && chainID.Cmp(s.ethChainID) == 0 {
+		return nil
+	}
+	return ErrInvalidChainID
+}
+
+// accessListOf returns the access list of a typed transaction.
+func accessListOf(tx InternalTransaction) AccessList {
+	switch t := tx.(type) {
+	case *Transaction:
+		return t.data.AccessList
+	case *EthTransaction:
+		return t.data.AccessList
+	}
+	return nil
+}
+
+// prefixedRLPHash returns the hash of the EIP-2718 typed envelope of x.
+func prefixedRLPHash(prefix byte, x interface{}) common.Hash {
+	enc, _ := rlp.EncodeToBytes(x)
+	return hash.Keccak256Hash([]byte{prefix}, enc)
+}
+
 // EIP155Signer implements Signer using the EIP155 rules.
 type EIP155Signer struct {
 	chainID, chainIDMul *big.Int
+	// ethChainID is the ethereum compatible chain id also accepted by the
+	// signer when recovering senders, nil if only chainID is accepted.
+	ethChainID *big.Int
 }
 
 // NewEIP155Signer creates a EIP155Signer given chainID.
 func NewEIP155Signer(chainID *big.Int) EIP155Signer {
+	return newEIP155Signer(chainID, nil)
+}
+
+// NewEIP155SignerWithEthChainID creates a EIP155Signer given chainID, which also
+// accepts transactions signed for the ethereum compatible ethChainID.
+func NewEIP155SignerWithEthChainID(chainID, ethChainID *big.Int) EIP155Signer {
+	return newEIP155Signer(chainID, ethChainID)
+}
+
+func newEIP155Signer(chainID, ethChainID *big.Int) EIP155Signer {
 	if chainID == nil {
 		chainID = new(big.Int)
 	}
 	return EIP155Signer{
 		chainID:    chainID,
 		chainIDMul: new(big.Int).Mul(chainID, big.NewInt(2)),
+		ethChainID: ethChainID,
 	}
 }
 
 // Equal checks if the given EIP155Signer is equal to another Signer.
 func (s EIP155Signer) Equal(s2 Signer) bool {
 	eip155, ok := s2.(EIP155Signer)
-	return ok && eip155.chainID.Cmp(s.chainID) == 0
+	if !ok || eip155.chainID.Cmp(s.chainID) != 0 {
+		return false
+	}
+	if s.ethChainID == nil || eip155.ethChainID == nil {
+		return s.ethChainID == eip155.ethChainID
+	}
+	return eip155.ethChainID.Cmp(s.ethChainID) == 0
 }
 
-var big8 = big.NewInt(8)
+var (
+	big8  = big.NewInt(8)
+	big27 = big.NewInt(27)
+)
 
 // Sender returns the sender address of the given signer.
 func (s EIP155Signer) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != LegacyTxType {
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	if !tx.Protected() {
 		return HomesteadSigner{}.Sender(tx)
 	}
 
-	ethChainID := nodeconfig.GetDefaultConfig().GetNetworkType().ChainConfig().EthCompatibleChainID
-	if tx.ChainID().Cmp(ethChainID) != 0 && tx.ChainID().Cmp(s.chainID) != 0 {
-		return common.Address{}, ErrInvalidChainID
+	chainID := tx.ChainID()
+	if err := s.checkChainID(chainID); err != nil {
+		return common.Address{}, err
+	}
+	// the transaction may be signed for the ethereum compatible chain id,
+	// recover it with the multiplier and hash of the chain id it carries
+	signer := s
+	if chainID.Cmp(s.chainID) != 0 {
+		signer = NewEIP155Signer(chainID)
 	}
-	V := new(big.Int).Sub(tx.V(), s.chainIDMul)
+	V := new(big.Int).Sub(tx.V(), signer.chainIDMul)
 	V.Sub(V, big8)
-	return recoverPlain(s.Hash(tx), tx.R(), tx.S(), V, true)
+	return recoverPlain(signer.Hash(tx), tx.R(), tx.S(), V, true)
 }
 
 // SignatureValues returns signature values. This signature
@@ -96,3 +96,138 @@ func TestChainID(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+func TestEthChainIDSigner(t *testing.T) {
+	key, _ := defaultTestKey()
+
+	tx := NewTransaction(0, common.Address{}, 0, new(big.Int), 0, new(big.Int), nil)
+
+	var err error
+	tx, err = SignTx(tx, NewEIP155Signer(big.NewInt(2)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Sender(NewEIP155Signer(big.NewInt(1)), tx)
+	if err != ErrInvalidChainID {
+		t.Error("expected error:", ErrInvalidChainID)
+	}
+
+	_, err = Sender(NewEIP155SignerWithEthChainID(big.NewInt(1), big.NewInt(2)), tx)
+	if err != nil {
+		t.Error("expected no error")
+	}
+
+	_, err = Sender(NewEIP155SignerWithEthChainID(big.NewInt(1), big.NewInt(3)), tx)
+	if err != ErrInvalidChainID {
+		t.Error("expected error:", ErrInvalidChainID)
+	}
+}
+
+func TestLondonSigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	signer := NewLondonSigner(big.NewInt(18))
+	tx, err := SignTx(NewDynamicFeeTransaction(&DynamicFeeTx{
+		ChainID:   big.NewInt(18),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &addr,
+		Value:     big.NewInt(10),
+	}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type() != DynamicFeeTxType {
+		t.Fatalf("expected type %d, got %d", DynamicFeeTxType, tx.Type())
+	}
+	if !tx.Protected() {
+		t.Fatal("expected tx to be protected")
+	}
+
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+
+	// The EIP155 signer does not know about typed transactions.
+	if _, err := NewEIP155Signer(big.NewInt(18)).Sender(tx); err != ErrTxTypeNotSupported {
+		t.Errorf("expected %v, got %v", ErrTxTypeNotSupported, err)
+	}
+}
+
+func TestLondonSigningEth(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	signer := NewLondonSigner(big.NewInt(18))
+	tx, err := SignEthTx(NewEthDynamicFeeTransaction(&DynamicFeeTx{
+		ChainID:   big.NewInt(18),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &addr,
+	}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+}
+
+func TestBerlinSigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	signer := NewBerlinSigner(big.NewInt(18))
+	tx, err := SignTx(NewAccessListTransaction(&AccessListTx{
+		ChainID:  big.NewInt(18),
+		GasPrice: big.NewInt(100),
+		Gas:      25000,
+		To:       &addr,
+		AccessList: AccessList{
+			{Address: addr, StorageKeys: []common.Hash{{1}}},
+		},
+	}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Access list transactions are accepted by both the Berlin and London signers.
+	for _, s := range []Signer{signer, NewLondonSigner(big.NewInt(18))} {
+		from, err := Sender(s, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if from != addr {
+			t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+		}
+	}
+
+	// Dynamic fee transactions are not.
+	dynTx, err := SignTx(NewDynamicFeeTransaction(&DynamicFeeTx{
+		ChainID:   big.NewInt(18),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &addr,
+	}), NewLondonSigner(big.NewInt(18)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.Sender(dynTx); err != ErrTxTypeNotSupported {
+		t.Errorf("expected %v, got %v", ErrTxTypeNotSupported, err)
+	}
+}
@@ -17,6 +17,7 @@
 package types
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"encoding/json"
 	"math/big"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/internal/params"
 )
 
@@ -188,3 +190,100 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// Tests that dynamic fee transactions survive the binary, RLP and JSON
+// encodings, and that the legacy encoding is left untouched.
+func TestDynamicFeeTransactionEncoding(t *testing.T) {
+	key, _ := defaultTestKey()
+	to := common.Address{1}
+	signer := NewLondonSigner(common.Big1)
+	tx, err := SignTx(NewDynamicFeeTransaction(&DynamicFeeTx{
+		ChainID:   common.Big1,
+		Nonce:     3,
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(10),
+		Gas:       50000,
+		ShardID:   0,
+		ToShardID: 1,
+		To:        &to,
+		Value:     big.NewInt(7),
+		Data:      []byte("abcdef"),
+		AccessList: AccessList{
+			{Address: to, StorageKeys: []common.Hash{{1}}},
+		},
+	}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bin[0] != DynamicFeeTxType {
+		t.Fatalf("expected envelope type %d, got %d", DynamicFeeTxType, bin[0])
+	}
+	var binTx Transaction
+	if err := binTx.UnmarshalBinary(bin); err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rlpTx Transaction
+	if err := rlp.DecodeBytes(enc, &rlpTx); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jsonTx Transaction
+	if err := json.Unmarshal(data, &jsonTx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, parsed := range []*Transaction{&binTx, &rlpTx, &jsonTx} {
+		if parsed.Hash() != tx.Hash() {
+			t.Errorf("parsed tx differs from original tx, want %v, got %v", tx, parsed)
+		}
+		if parsed.GasTipCap().Cmp(tx.GasTipCap()) != 0 || parsed.GasFeeCap().Cmp(tx.GasFeeCap()) != 0 {
+			t.Errorf("fee caps differ, want %v/%v, got %v/%v",
+				tx.GasTipCap(), tx.GasFeeCap(), parsed.GasTipCap(), parsed.GasFeeCap())
+		}
+		if parsed.ToShardID() != 1 || parsed.AccessList().StorageKeys() != 1 {
+			t.Errorf("parsed tx lost its fields: %v", parsed)
+		}
+		if from, err := Sender(signer, parsed); err != nil || from != crypto.PubkeyToAddress(key.PublicKey) {
+			t.Errorf("could not recover sender: %v", err)
+		}
+	}
+
+	legacy := NewTransaction(0, to, 0, common.Big0, 1, common.Big2, nil)
+	bin, err = legacy.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, _ = rlp.EncodeToBytes(legacy)
+	if !bytes.Equal(bin, enc) {
+		t.Errorf("legacy binary encoding differs from RLP, want %x, got %x", enc, bin)
+	}
+}
+
+func TestEffectiveGasPrice(t *testing.T) {
+	tests := []struct {
+		tip, feeCap, baseFee, want *big.Int
+	}{
+		{big.NewInt(2), big.NewInt(10), nil, big.NewInt(10)},
+		{big.NewInt(2), big.NewInt(10), big.NewInt(5), big.NewInt(7)},
+		{big.NewInt(2), big.NewInt(10), big.NewInt(9), big.NewInt(10)},
+	}
+	for i, test := range tests {
+		if have := EffectiveGasPrice(test.tip, test.feeCap, test.baseFee); have.Cmp(test.want) != 0 {
+			t.Errorf("test %d: want %v, got %v", i, test.want, have)
+		}
+	}
+}
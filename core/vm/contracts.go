@@ -153,6 +153,54 @@ func init() {
 	}
 }
 
+// precompilesFor returns the read only and the write capable precompiled
+// contracts that are enabled by the given rules.
+func precompilesFor(rules params.Rules) (
+	map[common.Address]PrecompiledContract, map[common.Address]WriteCapablePrecompiledContract,
+) {
+	precompiles := PrecompiledContractsHomestead
+	// assign empty write capable precompiles till they are available in the fork
+	var writeCapablePrecompiles map[common.Address]WriteCapablePrecompiledContract
+	if rules.IsS3 {
+		precompiles = PrecompiledContractsByzantium
+	}
+	if rules.IsIstanbul {
+		precompiles = PrecompiledContractsIstanbul
+	}
+	if rules.IsVRF {
+		precompiles = PrecompiledContractsVRF
+	}
+	if rules.IsSHA3 {
+		precompiles = PrecompiledContractsSHA3FIPS
+	}
+	if rules.IsStakingPrecompile {
+		precompiles = PrecompiledContractsStaking
+		writeCapablePrecompiles = WriteCapablePrecompiledContractsStaking
+	}
+	if rules.IsCrossShardXferPrecompile {
+		writeCapablePrecompiles = WriteCapablePrecompiledContractsCrossXfer
+	}
+	return precompiles, writeCapablePrecompiles
+}
+
+// ActivePrecompiles returns the addresses of the precompiled contracts, read
+// only and write capable, that are enabled by the given rules.
+func ActivePrecompiles(rules params.Rules) []common.Address {
+	precompiles, writeCapablePrecompiles := precompilesFor(rules)
+	addresses := make([]common.Address, 0, len(precompiles)+len(writeCapablePrecompiles))
+	for address, contract := range precompiles {
+		if contract != nil {
+			addresses = append(addresses, address)
+		}
+	}
+	for address, contract := range writeCapablePrecompiles {
+		if contract != nil {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
 func RunPrecompiledContract(p PrecompiledContract, input []byte, contract *Contract) (ret []byte, err error) {
 	gas := p.RequiredGas(input)
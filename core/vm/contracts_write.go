@@ -101,6 +101,7 @@ func (c *stakingPrecompile) RequiredGas(
 	}
 	if gas, err := IntrinsicGas(
 		payload,
+		nil,                                     // accessList
 		false,                                   // contractCreation
 		evm.ChainConfig().IsS3(evm.EpochNumber), // homestead
 		evm.ChainConfig().IsIstanbul(evm.EpochNumber), // istanbul
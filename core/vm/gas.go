@@ -20,6 +20,7 @@ import (
 	"math"
 	"math/big"
 
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/internal/params"
 )
 
@@ -55,8 +56,9 @@ func callGas(isEip150 bool, availableGas, base uint64, callCost *big.Int) (uint6
 	return callCost.Uint64(), nil
 }
 
-// IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, isValidatorCreation bool) (uint64, error) {
+// IntrinsicGas computes the 'intrinsic gas' for a message with the given data
+// and EIP-2930 access list.
+func IntrinsicGas(data []byte, accessList types.AccessList, contractCreation, homestead, istanbul, isValidatorCreation bool) (uint64, error) {
 	// Set the starting gas for the raw transaction
 	var gas uint64
 	if contractCreation && homestead {
@@ -91,5 +93,9 @@ func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, isValidato
 		}
 		gas += z * params.TxDataZeroGas
 	}
+	if accessList != nil {
+		gas += uint64(len(accessList)) * params.TxAccessListAddressGas
+		gas += uint64(accessList.StorageKeys()) * params.TxAccessListStorageKeyGas
+	}
 	return gas, nil
 }
@@ -108,3 +108,52 @@ func TestEIP2200(t *testing.T) {
 		}
 	}
 }
+
+var eip2929Tests = []struct {
+	input  string
+	slots  []common.Hash // storage slots of the contract in the access list
+	used   uint64
+	refund uint64
+}{
+	{"0x6001545060015450", nil, 2210, 0},                                         // cold SLOAD, warm SLOAD
+	{"0x6001545060015450", []common.Hash{common.BigToHash(common.Big1)}, 210, 0}, // warm SLOAD, warm SLOAD
+	{"0x60aa3b5060aa3b50", nil, 2710, 0},                                         // cold EXTCODESIZE, warm EXTCODESIZE
+	{"0x6001600055", nil, 22106, 0},                                              // cold SSTORE 0 -> 1
+	{"0x60016000556000600055", nil, 22212, 19900},                                // cold SSTORE 0 -> 1, warm SSTORE 1 -> 0
+}
+
+func TestEIP2929(t *testing.T) {
+	for i, tt := range eip2929Tests {
+		address := common.BytesToAddress([]byte("contract"))
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, hexutil.MustDecode(tt.input))
+		statedb.Finalise(true) // Push the state into the "original" slot
+
+		var accessList types.AccessList
+		if tt.slots != nil {
+			accessList = types.AccessList{{Address: address, StorageKeys: tt.slots}}
+		}
+		statedb.PrepareAccessList(common.Address{}, &address, nil, accessList)
+
+		vmctx := Context{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int, types.TransactionType) {},
+			IsValidator: func(StateDB, common.Address) bool { return false },
+			EpochNumber: new(big.Int),
+		}
+		vmenv := NewEVM(vmctx, statedb, params.AllProtocolChanges, Config{})
+
+		_, gas, err := vmenv.Call(AccountRef(common.Address{}), address, nil, math.MaxUint64, new(big.Int))
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		}
+		if used := math.MaxUint64 - gas; used != tt.used {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, used, tt.used)
+		}
+		if refund := vmenv.StateDB.GetRefund(); refund != tt.refund {
+			t.Errorf("test %d: gas refund mismatch: have %v, want %v", i, refund, tt.refund)
+		}
+	}
+}
@@ -50,6 +50,10 @@ type Config struct {
 
 	// ExtraEips the additional EIPS that are to be enabled
 	ExtraEips []int
+
+	// NoBaseFee forces the EIP-1559 base fee checks to be skipped for messages
+	// which do not pay any fee, as needed by eth_call and gas estimation
+	NoBaseFee bool
 }
 
 // Interpreter is used to run Ethereum based contracts and will utilise the
@@ -104,6 +108,12 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 	if !cfg.JumpTable[STOP].valid {
 		var jt JumpTable
 		switch {
+		case evm.chainRules.IsShanghai:
+			jt = shanghaiInstructionSet
+		case evm.chainRules.IsLondon:
+			jt = londonInstructionSet
+		case evm.chainRules.IsBerlin:
+			jt = berlinInstructionSet
 		case evm.chainRules.IsIstanbul:
 			jt = istanbulInstructionSet
 		case evm.chainRules.IsS3:
@@ -17,6 +17,7 @@
 package runtime
 
 import (
+	"bytes"
 	"math/big"
 	"strings"
 	"testing"
@@ -56,6 +57,9 @@ func TestDefaults(t *testing.T) {
 	if cfg.BlockNumber == nil {
 		t.Error("expected block number to be non nil")
 	}
+	if cfg.BaseFee == nil {
+		t.Error("expected base fee to be non nil")
+	}
 }
 
 func TestEVM(t *testing.T) {
@@ -118,6 +122,133 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestBaseFee(t *testing.T) {
+	ret, _, err := Execute([]byte{
+		byte(vm.BASEFEE),
+		byte(vm.PUSH1), 0,
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH1), 0,
+		byte(vm.RETURN),
+	}, nil, &Config{ChainConfig: params.AllProtocolChanges, BaseFee: big.NewInt(7)})
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+
+	num := new(big.Int).SetBytes(ret)
+	if num.Cmp(big.NewInt(7)) != 0 {
+		t.Error("Expected 7, got", num)
+	}
+
+	// BASEFEE is an invalid opcode before London
+	_, _, err = Execute([]byte{byte(vm.BASEFEE)}, nil, nil)
+	if err == nil {
+		t.Error("expected BASEFEE to fail before London")
+	}
+}
+
+func TestPush0(t *testing.T) {
+	state, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	address := common.HexToAddress("0x0a")
+	state.SetCode(address, []byte{
+		byte(vm.PUSH0),
+		byte(vm.STOP),
+	})
+
+	cfg := &Config{
+		ChainConfig: params.AllProtocolChanges,
+		State:       state,
+		GasLimit:    100000,
+	}
+	_, leftOverGas, err := Call(address, nil, cfg)
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if used := cfg.GasLimit - leftOverGas; used != vm.GasQuickStep {
+		t.Errorf("gas used mismatch: have %d, want %d", used, vm.GasQuickStep)
+	}
+
+	// PUSH0 is an invalid opcode before Shanghai
+	noShanghai := *params.AllProtocolChanges
+	noShanghai.ShanghaiEpoch = params.EpochTBD
+	_, _, err = Call(address, nil, &Config{ChainConfig: &noShanghai, State: state})
+	if err == nil {
+		t.Error("expected PUSH0 to fail before Shanghai")
+	}
+}
+
+// TestEIP3541 runs the test vectors of EIP-3541, which rejects new contract
+// code starting with the 0xEF byte from London onwards.
+func TestEIP3541(t *testing.T) {
+	for i, tt := range []struct {
+		initCode string
+		err      error
+	}{
+		{"0x60ef60005360016000f3", vm.ErrInvalidCode},
+		{"0x60ef60005360026000f3", vm.ErrInvalidCode},
+		{"0x60ef60005360036000f3", vm.ErrInvalidCode},
+		{"0x60ef60005360206000f3", vm.ErrInvalidCode},
+		{"0x60fe60005360016000f3", nil},
+	} {
+		cfg := &Config{ChainConfig: params.AllProtocolChanges, GasLimit: 100000}
+		code, address, leftOverGas, err := Create(common.FromHex(tt.initCode), cfg)
+		if err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+			continue
+		}
+		if tt.err != nil {
+			if leftOverGas != 0 {
+				t.Errorf("test %d: expected all gas to be consumed, %d left", i, leftOverGas)
+			}
+			if len(cfg.State.GetCode(address)) != 0 {
+				t.Errorf("test %d: expected no code to be deployed", i)
+			}
+			continue
+		}
+		if deployed := cfg.State.GetCode(address); !bytes.Equal(deployed, code) {
+			t.Errorf("test %d: code mismatch: have %x, want %x", i, deployed, code)
+		}
+	}
+
+	// Code starting with 0xEF is still accepted before London
+	_, _, _, err := Create(common.FromHex("0x60ef60005360016000f3"), &Config{GasLimit: 100000})
+	if err != nil {
+		t.Error("didn't expect error before London", err)
+	}
+}
+
+// TestEIP3529 checks that the refund for clearing a storage slot is reduced
+// from London onwards.
+func TestEIP3529(t *testing.T) {
+	berlin := *params.AllProtocolChanges
+	berlin.LondonEpoch = params.EpochTBD
+	berlin.ShanghaiEpoch = params.EpochTBD
+	for i, tt := range []struct {
+		config *params.ChainConfig
+		refund uint64
+	}{
+		{params.AllProtocolChanges, params.SstoreClearsScheduleRefundEIP3529},
+		{&berlin, params.SstoreClearRefundEIP2200},
+	} {
+		state, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		address := common.HexToAddress("0x0a")
+		state.SetCode(address, []byte{
+			byte(vm.PUSH1), 0,
+			byte(vm.PUSH1), 0,
+			byte(vm.SSTORE),
+		})
+		state.SetState(address, common.Hash{}, common.BytesToHash([]byte{1}))
+		state.Commit(false)
+
+		if _, _, err := Call(address, nil, &Config{ChainConfig: tt.config, State: state, GasLimit: 100000}); err != nil {
+			t.Fatalf("test %d: didn't expect error %v", i, err)
+		}
+		if have := state.GetRefund(); have != tt.refund {
+			t.Errorf("test %d: refund mismatch: have %d, want %d", i, have, tt.refund)
+		}
+	}
+}
+
 func BenchmarkCall(b *testing.B) {
 	var definition = `[{"constant":true,"inputs":[],"name":"seller","outputs":[{"name":"","type":"address"}],"type":"function"},{"constant":false,"inputs":[],"name":"abort","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"value","outputs":[{"name":"","type":"uint256"}],"type":"function"},{"constant":false,"inputs":[],"name":"refund","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"buyer","outputs":[{"name":"","type":"address"}],"type":"function"},{"constant":false,"inputs":[],"name":"confirmReceived","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"state","outputs":[{"name":"","type":"uint8"}],"type":"function"},{"constant":false,"inputs":[],"name":"confirmPurchase","outputs":[],"type":"function"},{"inputs":[],"type":"constructor"},{"anonymous":false,"inputs":[],"name":"Aborted","type":"event"},{"anonymous":false,"inputs":[],"name":"PurchaseConfirmed","type":"event"},{"anonymous":false,"inputs":[],"name":"ItemReceived","type":"event"},{"anonymous":false,"inputs":[],"name":"Refunded","type":"event"}]`
 
@@ -61,6 +61,7 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            log.Logger
 	allowSubscribe bool
+	limiter        *limiter // limits of the server, nil for clients
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -71,7 +72,7 @@ type callProc struct {
 	notifiers []*Notifier
 }
 
-func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry) *handler {
+func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry, limiter *limiter) *handler {
 	rootCtx, cancelRoot := context.WithCancel(connCtx)
 	h := &handler{
 		reg:            reg,
@@ -84,6 +85,7 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 		allowSubscribe: true,
 		serverSubs:     make(map[ID]*Subscription),
 		log:            log.Root(),
+		limiter:        limiter,
 	}
 	if conn.remoteAddr() != "" {
 		h.log = h.log.New("conn", conn.remoteAddr())
@@ -102,6 +104,14 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		return
 	}
 
+	// Reject the batches with more requests than allowed as a whole:
+	if limit := h.limiter.batchRequestLimit(); limit > 0 && len(msgs) > limit {
+		h.startCallProc(func(cp *callProc) {
+			h.respondWithBatchTooLarge(cp, msgs)
+		})
+		return
+	}
+
 	// Handle non-call messages first:
 	calls := make([]*jsonrpcMessage, 0, len(msgs))
 	for _, msg := range msgs {
@@ -114,11 +124,34 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 	}
 	// Process calls on a goroutine because they may block indefinitely:
 	h.startCallProc(func(cp *callProc) {
-		answers := make([]*jsonrpcMessage, 0, len(msgs))
+		var (
+			answers      = make([]*jsonrpcMessage, 0, len(msgs))
+			maxSize      = h.limiter.batchResponseMaxSize()
+			responseSize int
+			tooLarge     bool
+		)
 		for _, msg := range calls {
-			if answer := h.handleCallMsg(cp, msg); answer != nil {
-				answers = append(answers, answer)
+			// Once the answers are too large, the remaining calls are not run
+			if tooLarge {
+				if msg.isCall() {
+					answers = append(answers, msg.errorResponse(&responseTooLargeError{}))
+				}
+				continue
 			}
+			answer := h.handleCallMsg(cp, msg)
+			if answer == nil {
+				continue
+			}
+			if maxSize > 0 {
+				size := encodedSize(answer)
+				if responseSize+size > maxSize {
+					tooLarge = true
+					answer = msg.errorResponse(&responseTooLargeError{})
+				} else {
+					responseSize += size
+				}
+			}
+			answers = append(answers, answer)
 		}
 		h.addSubscriptions(cp.notifiers)
 		if len(answers) > 0 {
@@ -130,6 +163,30 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 	})
 }
 
+// encodedSize returns the size of the JSON encoding of an answer, errors
+// included.
+func encodedSize(answer *jsonrpcMessage) int {
+	enc, err := json.Marshal(answer)
+	if err != nil {
+		return 0
+	}
+	return len(enc)
+}
+
+// respondWithBatchTooLarge answers a batch with more requests than allowed by
+// a single error, bearing the ID of its first call as the protocol has no way
+// of reporting an error for a whole batch.
+func (h *handler) respondWithBatchTooLarge(cp *callProc, msgs []*jsonrpcMessage) {
+	resp := errorMessage(&invalidRequestError{"batch too large"})
+	for _, msg := range msgs {
+		if msg.isCall() {
+			resp.ID = msg.ID
+			break
+		}
+	}
+	h.conn.writeJSON(cp.ctx, []*jsonrpcMessage{resp})
+}
+
 // handleMsg handles a single message.
 func (h *handler) handleMsg(msg *jsonrpcMessage) {
 	if ok := h.handleImmediate(msg); ok {
@@ -323,6 +380,9 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if callb == nil {
 		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
 	}
+	if err := h.limiter.allow(msg.Method, h.conn.remoteAddr()); err != nil {
+		return msg.errorResponse(err)
+	}
 	args, err := parsePositionalArguments(msg.Params, callb.argTypes)
 	if err != nil {
 		return msg.errorResponse(&invalidParamsError{err.Error()})
@@ -189,21 +189,23 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 type httpServerConn struct {
 	io.Reader
 	io.Writer
-	r *http.Request
+	r      *http.Request
+	remote string // address of the client
 }
 
-func newHTTPServerConn(r *http.Request, w http.ResponseWriter) ServerCodec {
+func newHTTPServerConn(r *http.Request, w http.ResponseWriter, remote string) ServerCodec {
 	body := io.LimitReader(r.Body, maxRequestContentLength)
-	conn := &httpServerConn{Reader: body, Writer: w, r: r}
+	conn := &httpServerConn{Reader: body, Writer: w, r: r, remote: remote}
 	return NewCodec(conn)
 }
 
 // Close does nothing and always returns nil.
 func (t *httpServerConn) Close() error { return nil }
 
-// RemoteAddr returns the peer address of the underlying connection.
+// RemoteAddr returns the address of the client, which is the peer address of
+// the underlying connection unless it is a trusted reverse proxy.
 func (t *httpServerConn) RemoteAddr() string {
-	return t.r.RemoteAddr
+	return t.remote
 }
 
 // SetWriteDeadline does nothing and always returns nil.
@@ -265,7 +267,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("content-type", contentType)
-	codec := newHTTPServerConn(r, w)
+	codec := newHTTPServerConn(r, w, s.limiter.clientAddr(r))
 	defer codec.close()
 	s.serveSingleRequest(ctx, codec)
 }
@@ -17,6 +17,7 @@
 package rpc
 
 import (
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -52,3 +53,67 @@ func testHTTPErrorResponse(t *testing.T, method, contentType, body string, expec
 		t.Fatalf("response code should be %d not %d", expected, code)
 	}
 }
+
+func TestLimitedHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	tests := []struct {
+		limits   Limits
+		remotes  []string
+		wantCode []int
+	}{
+		{
+			limits:   Limits{MethodRateLimits: map[string]int{"graphql": 1}},
+			remotes:  []string{"1.2.3.4:1000", "5.6.7.8:1000"},
+			wantCode: []int{http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			limits:   Limits{IPRateLimit: 1},
+			remotes:  []string{"1.2.3.4:1000", "5.6.7.8:1000", "1.2.3.4:2000"},
+			wantCode: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+	}
+	for i, test := range tests {
+		handler := NewLimitedHandler(test.limits, "graphql", next)
+		for j, remote := range test.remotes {
+			request := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			request.RemoteAddr = remote
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, request)
+			if w.Code != test.wantCode[j] {
+				t.Errorf("Test %v, request %v: response code %d, want %d", i, j, w.Code, test.wantCode[j])
+			}
+		}
+	}
+}
+
+func TestClientAddr(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+	l := newLimiter(Limits{TrustedProxies: []*net.IPNet{proxies}})
+	tests := []struct {
+		remote    string
+		realIP    string
+		forwarded string
+		want      string
+	}{
+		{remote: "1.2.3.4:1000", want: "1.2.3.4:1000"},
+		{remote: "1.2.3.4:1000", realIP: "5.6.7.8", forwarded: "5.6.7.8", want: "1.2.3.4:1000"},
+		{remote: "127.0.0.1:1000", realIP: "5.6.7.8", want: "127.0.0.1:1000"},
+		{remote: "192.168.0.1:1000", forwarded: "5.6.7.8", want: "192.168.0.1:1000"},
+		{remote: "10.0.0.1:1000", realIP: "5.6.7.8", want: "5.6.7.8"},
+		{remote: "10.0.0.1:1000", forwarded: "9.9.9.9, 5.6.7.8", want: "5.6.7.8"},
+		{remote: "10.0.0.1:1000", want: "10.0.0.1:1000"},
+	}
+	for i, test := range tests {
+		request := httptest.NewRequest(http.MethodPost, "/", nil)
+		request.RemoteAddr = test.remote
+		if test.realIP != "" {
+			request.Header.Set("X-Real-IP", test.realIP)
+		}
+		if test.forwarded != "" {
+			request.Header.Set("X-Forwarded-For", test.forwarded)
+		}
+		if have := l.clientAddr(request); have != test.want {
+			t.Errorf("Test %v: client address %q, want %q", i, have, test.want)
+		}
+	}
+}
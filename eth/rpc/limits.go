@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/time/rate"
+)
+
+// ipLimitersCacheSize is the number of client IPs whose rate limiters are
+// remembered, the least recently seen clients are forgotten first.
+const ipLimitersCacheSize = 8192
+
+// Limits are the limits a server applies to the requests of its clients.
+// Zero values disable the corresponding limit.
+type Limits struct {
+	BatchRequestLimit    int            // Maximum number of requests in a batch
+	BatchResponseMaxSize int            // Maximum number of bytes of results returned for a batch
+	MethodRateLimits     map[string]int // Requests per second allowed for a method, shared by all clients
+	IPRateLimit          int            // Requests per second allowed for a client IP
+	TrustedProxies       []*net.IPNet   // Reverse proxies whose forwarding headers tell the client IP
+}
+
+// limiter enforces the Limits of a server. A nil limiter enforces none.
+type limiter struct {
+	Limits
+
+	methods map[string]*rate.Limiter // read-only once created
+
+	ipsMu sync.Mutex
+	ips   *lru.Cache // client IP -> *rate.Limiter
+}
+
+func newLimiter(limits Limits) *limiter {
+	l := &limiter{
+		Limits:  limits,
+		methods: make(map[string]*rate.Limiter),
+	}
+	for method, rps := range limits.MethodRateLimits {
+		if rps > 0 {
+			l.methods[method] = rate.NewLimiter(rate.Limit(rps), rps)
+		}
+	}
+	if limits.IPRateLimit > 0 {
+		l.ips, _ = lru.New(ipLimitersCacheSize)
+	}
+	return l
+}
+
+// batchRequestLimit returns the maximum number of requests in a batch.
+func (l *limiter) batchRequestLimit() int {
+	if l == nil {
+		return 0
+	}
+	return l.BatchRequestLimit
+}
+
+// batchResponseMaxSize returns the maximum size of the results of a batch.
+func (l *limiter) batchResponseMaxSize() int {
+	if l == nil {
+		return 0
+	}
+	return l.BatchResponseMaxSize
+}
+
+// allow takes a token from the buckets of the method and of the client at the
+// given remote address, returning an error if any of them is empty.
+func (l *limiter) allow(method, remote string) error {
+	if l == nil {
+		return nil
+	}
+	if lim, ok := l.methods[method]; ok && !lim.Allow() {
+		return &limitExceededError{fmt.Sprintf("rate limit exceeded for method %s", method)}
+	}
+	if l.ips == nil {
+		return nil
+	}
+	if ip := remoteIP(remote); ip != "" && !l.ipLimiter(ip).Allow() {
+		return &limitExceededError{fmt.Sprintf("rate limit exceeded for client %s", ip)}
+	}
+	return nil
+}
+
+// ipLimiter returns the rate limiter of the given client IP.
+func (l *limiter) ipLimiter(ip string) *rate.Limiter {
+	l.ipsMu.Lock()
+	defer l.ipsMu.Unlock()
+
+	if lim, ok := l.ips.Get(ip); ok {
+		return lim.(*rate.Limiter)
+	}
+	lim := rate.NewLimiter(rate.Limit(l.IPRateLimit), l.IPRateLimit)
+	l.ips.Add(ip, lim)
+	return lim
+}
+
+// clientAddr returns the address of the client of an HTTP request. When the
+// peer is one of the trusted reverse proxies, it is the one reported by the
+// proxy in the X-Real-IP header or as the last hop of the X-Forwarded-For
+// header, the earlier hops being set by the client.
+func (l *limiter) clientAddr(r *http.Request) string {
+	if l == nil || !l.isTrustedProxy(remoteIP(r.RemoteAddr)) {
+		return r.RemoteAddr
+	}
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+	if hops := r.Header.Get("X-Forwarded-For"); hops != "" {
+		if ip := strings.TrimSpace(hops[strings.LastIndex(hops, ",")+1:]); ip != "" {
+			return ip
+		}
+	}
+	return r.RemoteAddr
+}
+
+// isTrustedProxy returns whether the given peer IP is a trusted proxy.
+func (l *limiter) isTrustedProxy(peer string) bool {
+	ip := net.ParseIP(peer)
+	if ip == nil {
+		return false
+	}
+	for _, proxies := range l.TrustedProxies {
+		if proxies.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns the IP of a remote address, which is empty for the
+// connections without one, e.g. IPC.
+func remoteIP(remote string) string {
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
+}
+
+// NewLimitedHandler wraps an HTTP handler serving requests outside of the
+// JSON-RPC server, e.g. GraphQL, with the rate limits of the given method
+// and of the client IPs. Rejected requests are answered with status 429.
+func NewLimitedHandler(limits Limits, method string, next http.Handler) http.Handler {
+	l := newLimiter(limits)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := l.allow(method, l.clientAddr(r)); err != nil {
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
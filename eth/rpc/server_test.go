@@ -150,3 +150,68 @@ func TestServerShortLivedConn(t *testing.T) {
 		}
 	}
 }
+
+// remoteAddrConn is a connection reporting the given remote address.
+type remoteAddrConn struct {
+	net.Conn
+	remote string
+}
+
+func (c *remoteAddrConn) RemoteAddr() string { return c.remote }
+
+func TestServerLimits(t *testing.T) {
+	tests := []struct {
+		limits   Limits
+		remote   string
+		request  string
+		wantResp string
+	}{
+		{
+			limits:   Limits{BatchRequestLimit: 1},
+			request:  `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["x",2]}]`,
+			wantResp: `[{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"batch too large"}}]`,
+		},
+		{
+			limits:   Limits{BatchResponseMaxSize: 100},
+			request:  `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["x",2]}]`,
+			wantResp: `[{"jsonrpc":"2.0","id":1,"result":{"String":"x","Int":1,"Args":null}},{"jsonrpc":"2.0","id":2,"error":{"code":-32003,"message":"response too large"}}]`,
+		},
+		{
+			limits:   Limits{BatchResponseMaxSize: 10},
+			request:  `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["x",2]}]`,
+			wantResp: `[{"jsonrpc":"2.0","id":1,"error":{"code":-32003,"message":"response too large"}},{"jsonrpc":"2.0","id":2,"error":{"code":-32003,"message":"response too large"}}]`,
+		},
+		{
+			limits:   Limits{MethodRateLimits: map[string]int{"test_echo": 1}},
+			request:  `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["x",2]}]`,
+			wantResp: `[{"jsonrpc":"2.0","id":1,"result":{"String":"x","Int":1,"Args":null}},{"jsonrpc":"2.0","id":2,"error":{"code":-32005,"message":"rate limit exceeded for method test_echo"}}]`,
+		},
+		{
+			limits:   Limits{IPRateLimit: 1},
+			remote:   "1.2.3.4:1000",
+			request:  `[{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]},{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["x",2]}]`,
+			wantResp: `[{"jsonrpc":"2.0","id":1,"result":{"String":"x","Int":1,"Args":null}},{"jsonrpc":"2.0","id":2,"error":{"code":-32005,"message":"rate limit exceeded for client 1.2.3.4"}}]`,
+		},
+	}
+	for i, test := range tests {
+		server := newTestServer()
+		server.SetLimits(test.limits)
+
+		clientConn, serverConn := net.Pipe()
+		go server.ServeCodec(NewCodec(&remoteAddrConn{serverConn, test.remote}), 0)
+
+		clientConn.SetDeadline(time.Now().Add(5 * time.Second))
+		if _, err := io.WriteString(clientConn, test.request+"\n"); err != nil {
+			t.Fatalf("Test %v: write error: %v", i, err)
+		}
+		resp, err := bufio.NewReader(clientConn).ReadString('\n')
+		if err != nil {
+			t.Fatalf("Test %v: read error: %v", i, err)
+		}
+		if resp = strings.TrimRight(resp, "\r\n"); resp != test.wantResp {
+			t.Errorf("Test %v: wrong response\ngot:  %s\nwant: %s", i, resp, test.wantResp)
+		}
+		clientConn.Close()
+		server.Stop()
+	}
+}
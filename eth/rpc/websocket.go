@@ -62,7 +62,8 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			log.Debug("WebSocket upgrade failed", "err", err)
 			return
 		}
-		codec := newWebsocketCodec(conn)
+		codec := newWebsocketCodec(conn).(*jsonCodec)
+		codec.remote = s.limiter.clientAddr(r)
 		s.ServeCodec(codec, 0)
 	})
 }
@@ -171,5 +172,7 @@ func wsClientHeaders(endpoint, origin string) (string, http.Header, error) {
 
 func newWebsocketCodec(conn *websocket.Conn) ServerCodec {
 	conn.SetReadLimit(maxRequestContentLength)
-	return NewFuncCodec(conn, conn.WriteJSON, conn.ReadJSON)
+	codec := NewFuncCodec(conn, conn.WriteJSON, conn.ReadJSON).(*jsonCodec)
+	codec.remote = conn.RemoteAddr().String()
+	return codec
 }
@@ -29,4 +29,5 @@ type blockChain interface {
 
 	InsertChain(chain types.Blocks, verifyHeaders bool) (int, error)
 	WriteCommitSig(blockNum uint64, lastCommits []byte) error
+	ReportBadBlockPeer(hash common.Hash, peer string)
 }
@@ -90,6 +90,7 @@ func (bc *testBlockChain) ShardID() uint32
 func (bc *testBlockChain) ReadShardState(epoch *big.Int) (*shard.State, error)      { return nil, nil }
 func (bc *testBlockChain) Config() *params.ChainConfig                              { return nil }
 func (bc *testBlockChain) WriteCommitSig(blockNum uint64, lastCommits []byte) error { return nil }
+func (bc *testBlockChain) ReportBadBlockPeer(hash common.Hash, peer string)         {}
 func (bc *testBlockChain) GetHeader(hash common.Hash, number uint64) *block.Header  { return nil }
 func (bc *testBlockChain) GetHeaderByNumber(number uint64) *block.Header            { return nil }
 func (bc *testBlockChain) GetHeaderByHash(hash common.Hash) *block.Header           { return nil }
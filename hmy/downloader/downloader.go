@@ -113,6 +113,14 @@ func (d *Downloader) DownloadAsync() {
 	}
 }
 
+// SyncProtocol returns the stream sync protocol of the downloader, which also
+// serves the state sync stage of staged sync. It is nil if the downloader does
+// not run on the stream sync protocol.
+func (d *Downloader) SyncProtocol() *sync.Protocol {
+	sp, _ := d.syncProtocol.(*sync.Protocol)
+	return sp
+}
+
 // NumPeers returns the number of peers connected of a specific shard.
 func (d *Downloader) NumPeers() int {
 	return d.syncProtocol.NumStreams()
@@ -219,6 +219,7 @@ func (lsi *lrSyncIter) processBlocks(results []*blockResult, targetBN uint64) {
 			pl["error"] = err.Error()
 			longRangeFailInsertedBlockCounterVec.With(pl).Inc()
 
+			lsi.bc.ReportBadBlockPeer(block.Hash(), string(results[i].stid))
 			lsi.p.RemoveStream(results[i].stid)
 			lsi.gbm.HandleInsertError(results, i)
 			return
@@ -134,6 +134,9 @@ func (d *Downloader) doShortRangeSyncForEpochSync() (int, error) {
 	n, err := d.bc.InsertChain(blocks, true)
 	numBlocksInsertedShortRangeHistogramVec.With(d.promLabels()).Observe(float64(n))
 	if err != nil {
+		if n < len(blocks) {
+			d.bc.ReportBadBlockPeer(blocks[n].Hash(), string(streamID))
+		}
 		sh.removeStreams([]sttypes.StreamID{streamID}) // Data provided by remote nodes is corrupted
 		return n, err
 	}
@@ -0,0 +1,200 @@
+package hmy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/consensus/misc"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+const (
+	// MaxFeeHistory is the maximum number of blocks that can be retrieved for a
+	// fee history request.
+	MaxFeeHistory = 1024
+)
+
+var (
+	errInvalidPercentile = errors.New("invalid reward percentile")
+	errRequestBeyondHead = errors.New("request beyond head block")
+)
+
+// txGasAndReward is the gas used and the effective tip of a transaction.
+type txGasAndReward struct {
+	gasUsed uint64
+	reward  *big.Int
+}
+
+type sortGasAndReward []txGasAndReward
+
+func (s sortGasAndReward) Len() int      { return len(s) }
+func (s sortGasAndReward) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortGasAndReward) Less(i, j int) bool {
+	return s[i].reward.Cmp(s[j].reward) < 0
+}
+
+// FeeHistory returns data relevant for fee estimation based on the specified
+// range of blocks, which ends at lastBlock and is at most MaxFeeHistory blocks
+// long. For each block it returns:
+//   - the base fee, including the one of the block following the range
+//   - the ratio of gas used to the gas limit
+//   - the effective tips at the given percentiles of the gas used, weighted by
+//     the gas used of each transaction
+//
+// Blocks before the London epoch have a zero base fee. The range is truncated
+// if it reaches beyond the genesis block.
+func (gpo *Oracle) FeeHistory(
+	ctx context.Context, blocks int, lastBlock rpc.BlockNumber, rewardPercentiles []float64,
+) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
+	if blocks < 1 {
+		return common.Big0, nil, nil, nil, nil
+	}
+	if blocks > MaxFeeHistory {
+		utils.Logger().Warn().
+			Int("requested", blocks).
+			Int("truncated", MaxFeeHistory).
+			Msg("Sanitizing fee history length")
+		blocks = MaxFeeHistory
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return common.Big0, nil, nil, nil, errors.WithMessagef(errInvalidPercentile, "%f", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return common.Big0, nil, nil, nil, errors.WithMessagef(errInvalidPercentile, "#%d:%f > #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+
+	head := gpo.backend.CurrentBlock().NumberU64()
+	last := head
+	switch lastBlock {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+	default:
+		if lastBlock < 0 || uint64(lastBlock) > head {
+			return common.Big0, nil, nil, nil, errors.WithMessagef(errRequestBeyondHead, "requested %d, head %d", lastBlock, head)
+		}
+		last = uint64(lastBlock)
+	}
+	if uint64(blocks) > last+1 {
+		blocks = int(last + 1)
+	}
+	oldest := last + 1 - uint64(blocks)
+
+	var (
+		reward       = make([][]*big.Int, blocks)
+		baseFee      = make([]*big.Int, blocks+1)
+		gasUsedRatio = make([]float64, blocks)
+		lastHeader   *block.Header
+	)
+	for i := 0; i < blocks; i++ {
+		if err := ctx.Err(); err != nil {
+			return common.Big0, nil, nil, nil, err
+		}
+		b, err := gpo.backend.BlockByNumber(ctx, rpc.BlockNumber(oldest+uint64(i)))
+		if err != nil {
+			return common.Big0, nil, nil, nil, err
+		}
+		if b == nil {
+			return common.Big0, nil, nil, nil, fmt.Errorf("block %d not found", oldest+uint64(i))
+		}
+		lastHeader = b.Header()
+		baseFee[i] = headerBaseFee(lastHeader)
+		if lastHeader.GasLimit() > 0 {
+			gasUsedRatio[i] = float64(lastHeader.GasUsed()) / float64(lastHeader.GasLimit())
+		}
+		if len(rewardPercentiles) > 0 {
+			if reward[i], err = gpo.blockRewards(ctx, b, rewardPercentiles); err != nil {
+				return common.Big0, nil, nil, nil, err
+			}
+		}
+	}
+
+	// The base fee of the block following the range is known if that block
+	// exists, and estimated from the last block of the range otherwise.
+	if last < head {
+		next, err := gpo.backend.HeaderByNumber(ctx, rpc.BlockNumber(last+1))
+		if err != nil {
+			return common.Big0, nil, nil, nil, err
+		}
+		if next == nil {
+			return common.Big0, nil, nil, nil, fmt.Errorf("header %d not found", last+1)
+		}
+		baseFee[blocks] = headerBaseFee(next)
+	} else {
+		baseFee[blocks] = new(big.Int)
+		if config := gpo.backend.ChainConfig(); config.IsLondon(lastHeader.Epoch()) {
+			baseFee[blocks] = misc.CalcBaseFee(config, lastHeader)
+		}
+	}
+	if len(rewardPercentiles) == 0 {
+		reward = nil
+	}
+	return new(big.Int).SetUint64(oldest), reward, baseFee, gasUsedRatio, nil
+}
+
+// blockRewards returns the effective tips of the block at the given
+// percentiles of its gas used.
+func (gpo *Oracle) blockRewards(
+	ctx context.Context, b *types.Block, percentiles []float64,
+) ([]*big.Int, error) {
+	rewards := make([]*big.Int, len(percentiles))
+	txs := b.Transactions()
+	if len(txs) == 0 {
+		// return an all zero row if there are no transactions to gather data from
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards, nil
+	}
+	receipts, err := gpo.backend.GetReceipts(ctx, b.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) < len(txs) {
+		return nil, fmt.Errorf("receipts of block %d not found", b.NumberU64())
+	}
+
+	// The receipts of the staking transactions follow the ones of the plain
+	// transactions, their gas is left out of the percentile thresholds.
+	var txsGasUsed uint64
+	baseFee := b.Header().BaseFee()
+	sorter := make(sortGasAndReward, len(txs))
+	for i, tx := range txs {
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil {
+			// included transactions always cover the base fee
+			tip = new(big.Int)
+		}
+		sorter[i] = txGasAndReward{gasUsed: receipts[i].GasUsed, reward: tip}
+		txsGasUsed += receipts[i].GasUsed
+	}
+	sort.Stable(sorter)
+
+	var txIndex int
+	sumGasUsed := sorter[0].gasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(txsGasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(txs)-1 {
+			txIndex++
+			sumGasUsed += sorter[txIndex].gasUsed
+		}
+		rewards[i] = sorter[txIndex].reward
+	}
+	return rewards, nil
+}
+
+// headerBaseFee returns the base fee of the header, or zero before London.
+func headerBaseFee(header *block.Header) *big.Int {
+	if baseFee := header.BaseFee(); baseFee != nil {
+		return new(big.Int).Set(baseFee)
+	}
+	return new(big.Int)
+}
@@ -0,0 +1,139 @@
+package hmy
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/harmony-one/harmony/consensus/misc"
+	"github.com/harmony-one/harmony/eth/rpc"
+)
+
+func TestFeeHistory(t *testing.T) {
+	// blocks 0 and 1 are before London, blocks 2 to 4 have a base fee
+	backend := newTestBackend(t, big.NewInt(2))
+	baseFeeOf := func(number int) *big.Int {
+		return headerBaseFee(backend.blocks[number].Header())
+	}
+	gwei := func(n ...int64) []*big.Int {
+		values := make([]*big.Int, len(n))
+		for i, v := range n {
+			values[i] = new(big.Int).Mul(big.NewInt(v), testGwei)
+		}
+		return values
+	}
+	// the staking gas is part of the block gas used, not of the reward percentiles
+	gasUsedRatio := float64(6*testTxGasPerUnit+testStakingGas) / testGasLimit
+
+	tests := []struct {
+		name        string
+		blocks      int
+		lastBlock   rpc.BlockNumber
+		percentiles []float64
+		wantOldest  uint64
+		wantReward  [][]*big.Int
+		wantBaseFee []*big.Int
+		wantRatio   []float64
+		wantErr     bool
+	}{
+		{
+			name:        "percentiles",
+			blocks:      1,
+			lastBlock:   3,
+			percentiles: []float64{0, 25, 50, 75, 100},
+			wantOldest:  3,
+			wantReward:  [][]*big.Int{gwei(1, 2, 2, 3, 3)},
+			wantBaseFee: []*big.Int{baseFeeOf(3), baseFeeOf(4)},
+			wantRatio:   []float64{gasUsedRatio},
+		},
+		{
+			name:        "base fee projection at head",
+			blocks:      2,
+			lastBlock:   rpc.LatestBlockNumber,
+			wantOldest:  3,
+			wantBaseFee: []*big.Int{baseFeeOf(3), baseFeeOf(4), misc.CalcBaseFee(backend.config, backend.blocks[4].Header())},
+			wantRatio:   []float64{gasUsedRatio, gasUsedRatio},
+		},
+		{
+			name:        "pre-London",
+			blocks:      2,
+			lastBlock:   1,
+			percentiles: []float64{50},
+			wantOldest:  0,
+			wantReward:  [][]*big.Int{gwei(0), gwei(2)},
+			wantBaseFee: []*big.Int{new(big.Int), new(big.Int), baseFeeOf(2)},
+			wantRatio:   []float64{0, gasUsedRatio},
+		},
+		{
+			name:        "truncated at genesis",
+			blocks:      10,
+			lastBlock:   0,
+			wantOldest:  0,
+			wantBaseFee: []*big.Int{new(big.Int), new(big.Int)},
+			wantRatio:   []float64{0},
+		},
+		{
+			name:        "decreasing percentiles",
+			blocks:      1,
+			lastBlock:   rpc.LatestBlockNumber,
+			percentiles: []float64{50, 10},
+			wantErr:     true,
+		},
+		{
+			name:      "beyond head",
+			blocks:    1,
+			lastBlock: testHeadNumber + 1,
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oracle := NewOracle(backend, GasPriceConfig{Blocks: 1, Percentile: 60})
+			oldest, reward, baseFee, ratio, err := oracle.FeeHistory(
+				context.Background(), test.blocks, test.lastBlock, test.percentiles,
+			)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if oldest.Uint64() != test.wantOldest {
+				t.Errorf("oldest block mismatch: have %v, want %v", oldest, test.wantOldest)
+			}
+			if len(reward) != len(test.wantReward) {
+				t.Fatalf("reward length mismatch: have %d, want %d", len(reward), len(test.wantReward))
+			}
+			for i := range reward {
+				if len(reward[i]) != len(test.wantReward[i]) {
+					t.Fatalf("block %d: reward length mismatch: have %d, want %d", i, len(reward[i]), len(test.wantReward[i]))
+				}
+				for j := range reward[i] {
+					if reward[i][j].Cmp(test.wantReward[i][j]) != 0 {
+						t.Errorf("block %d, percentile %v: reward mismatch: have %v, want %v",
+							i, test.percentiles[j], reward[i][j], test.wantReward[i][j])
+					}
+				}
+			}
+			if len(baseFee) != len(test.wantBaseFee) {
+				t.Fatalf("base fee length mismatch: have %d, want %d", len(baseFee), len(test.wantBaseFee))
+			}
+			for i := range baseFee {
+				if baseFee[i].Cmp(test.wantBaseFee[i]) != 0 {
+					t.Errorf("base fee %d mismatch: have %v, want %v", i, baseFee[i], test.wantBaseFee[i])
+				}
+			}
+			if len(ratio) != len(test.wantRatio) {
+				t.Fatalf("gas used ratio length mismatch: have %d, want %d", len(ratio), len(test.wantRatio))
+			}
+			for i := range ratio {
+				if ratio[i] != test.wantRatio[i] {
+					t.Errorf("gas used ratio %d mismatch: have %v, want %v", i, ratio[i], test.wantRatio[i])
+				}
+			}
+		})
+	}
+}
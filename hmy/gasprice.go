@@ -28,8 +28,8 @@ import (
 	"github.com/harmony-one/harmony/internal/utils"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/params"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
 )
 
 const sampleNumber = 3 // Number of transactions sampled in a block
@@ -47,13 +47,15 @@ type GasPriceConfig struct {
 type OracleBackend interface {
 	HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*block.Header, error)
 	BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error)
+	CurrentBlock() *types.Block
+	GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error)
 	ChainConfig() *params.ChainConfig
 }
 
 // Oracle recommends gas prices based on the content of recent
 // blocks. Suitable for both light and full clients.
 type Oracle struct {
-	backend   *Harmony
+	backend   OracleBackend
 	lastHead  common.Hash
 	lastPrice *big.Int
 	maxPrice  *big.Int
@@ -66,7 +68,7 @@ type Oracle struct {
 
 // NewOracle returns a new gasprice oracle which can recommend suitable
 // gasprice for newly created transaction.
-func NewOracle(backend *Harmony, params GasPriceConfig) *Oracle {
+func NewOracle(backend OracleBackend, params GasPriceConfig) *Oracle {
 	blocks := params.Blocks
 	if blocks < 1 {
 		blocks = 1
@@ -171,6 +173,30 @@ func (gpo *Oracle) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	return price, nil
 }
 
+// SuggestTipCap returns a priority fee per gas so that newly created dynamic
+// fee transactions have a very high chance to be included in the following
+// blocks. It is the suggested gas price less the base fee of the latest block,
+// or the suggested gas price itself before London.
+func (gpo *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
+	price, err := gpo.SuggestPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head, err := gpo.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	baseFee := head.BaseFee()
+	if baseFee == nil {
+		return price, nil
+	}
+	tip := new(big.Int).Sub(price, baseFee)
+	if tip.Sign() < 0 {
+		tip.SetUint64(0)
+	}
+	return tip, nil
+}
+
 type getBlockPricesResult struct {
 	prices []*big.Int
 	err    error
@@ -184,8 +210,8 @@ func (t transactionsByGasPrice) Less(i, j int) bool {
 	return t[i].GasPrice().Cmp(t[j].GasPrice()) < 0
 }
 
-// getBlockPrices calculates the lowest transaction gas price in a given block
-// and sends it to the result channel. If the block is empty or all transactions
+// getBlockPrices calculates the lowest effective transaction gas price in a
+// given block and sends it to the result channel. If the block is empty or all transactions
 // are sent by the miner itself(it doesn't make any sense to include this kind of
 // transaction prices for sampling), nil gasprice is returned.
 func (gpo *Oracle) getBlockPrices(ctx context.Context, signer types.Signer, blockNum uint64, limit int, result chan getBlockPricesResult, quit chan struct{}) {
@@ -202,11 +228,14 @@ func (gpo *Oracle) getBlockPrices(ctx context.Context, signer types.Signer, bloc
 	copy(txs, blockTxs)
 	sort.Sort(transactionsByGasPrice(txs))
 
-	var prices []*big.Int
+	var (
+		prices  []*big.Int
+		baseFee = block.Header().BaseFee()
+	)
 	for _, tx := range txs {
 		sender, err := types.Sender(signer, tx)
 		if err == nil && sender != block.Coinbase() {
-			prices = append(prices, tx.GasPrice())
+			prices = append(prices, types.EffectiveGasPrice(tx.GasTipCap(), tx.GasFeeCap(), baseFee))
 			if len(prices) >= limit {
 				break
 			}
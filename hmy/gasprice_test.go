@@ -0,0 +1,178 @@
+package hmy
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/consensus/misc"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+const (
+	testHeadNumber   = 4
+	testGasLimit     = 1000000
+	testStakingGas   = 500000
+	testTxsPerBlock  = 3
+	testTxGasPerUnit = 21000
+)
+
+var testGwei = big.NewInt(1e9)
+
+// testBackend is an in memory chain of testHeadNumber+1 blocks, one per epoch.
+// Every block but the genesis has testTxsPerBlock transactions with a tip of
+// 1, 2 and 3 gwei using 21000, 42000 and 63000 gas, followed by the receipt of
+// a staking transaction using testStakingGas.
+type testBackend struct {
+	config   *params.ChainConfig
+	blocks   []*types.Block
+	receipts map[common.Hash]types.Receipts
+}
+
+func newTestBackend(t *testing.T, londonEpoch *big.Int) *testBackend {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := *params.TestChainConfig
+	config.BerlinEpoch = londonEpoch
+	config.LondonEpoch = londonEpoch
+	factory := blockfactory.NewFactory(&config)
+
+	backend := &testBackend{
+		config:   &config,
+		receipts: make(map[common.Hash]types.Receipts),
+	}
+	var parent *block.Header
+	for number := int64(0); number <= testHeadNumber; number++ {
+		epoch := big.NewInt(number)
+		header := factory.NewHeader(epoch).With().
+			Number(big.NewInt(number)).
+			GasLimit(testGasLimit).
+			Header()
+		if config.IsLondon(epoch) {
+			baseFee := new(big.Int).SetUint64(params.InitialBaseFee)
+			if parent != nil {
+				baseFee = misc.CalcBaseFee(&config, parent)
+			}
+			header.SetBaseFee(baseFee)
+		}
+
+		var (
+			txs      []*types.Transaction
+			receipts types.Receipts
+			gasUsed  uint64
+		)
+		if number > 0 {
+			signer := types.MakeSigner(&config, epoch)
+			for i := int64(1); i <= testTxsPerBlock; i++ {
+				tip := new(big.Int).Mul(big.NewInt(i), testGwei)
+				var tx *types.Transaction
+				if config.IsLondon(epoch) {
+					tx = types.NewDynamicFeeTransaction(&types.DynamicFeeTx{
+						ChainID:   config.ChainID,
+						Nonce:     uint64(i),
+						GasTipCap: tip,
+						GasFeeCap: new(big.Int).Add(header.BaseFee(), new(big.Int).Mul(big.NewInt(10), testGwei)),
+						Gas:       testGasLimit,
+					})
+				} else {
+					tx = types.NewTransaction(uint64(i), common.Address{}, 0, new(big.Int), testGasLimit, tip, nil)
+				}
+				if tx, err = types.SignTx(tx, signer, key); err != nil {
+					t.Fatal(err)
+				}
+				txs = append(txs, tx)
+				receipt := &types.Receipt{GasUsed: uint64(i) * testTxGasPerUnit}
+				receipts = append(receipts, receipt)
+				gasUsed += receipt.GasUsed
+			}
+			gasUsed += testStakingGas
+			header.SetGasUsed(gasUsed)
+		}
+		b := types.NewBlock(header, txs, receipts, nil, nil, nil)
+		if number > 0 {
+			receipts = append(receipts, &types.Receipt{GasUsed: testStakingGas})
+		}
+		backend.receipts[b.Hash()] = receipts
+		backend.blocks = append(backend.blocks, b)
+		parent = b.Header()
+	}
+	return backend
+}
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*block.Header, error) {
+	blk, err := b.BlockByNumber(ctx, number)
+	if blk == nil || err != nil {
+		return nil, err
+	}
+	return blk.Header(), nil
+}
+
+func (b *testBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	if number == rpc.LatestBlockNumber {
+		return b.CurrentBlock(), nil
+	}
+	if number < 0 || int(number) >= len(b.blocks) {
+		return nil, nil
+	}
+	return b.blocks[number], nil
+}
+
+func (b *testBackend) CurrentBlock() *types.Block {
+	return b.blocks[len(b.blocks)-1]
+}
+
+func (b *testBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
+	return b.receipts[hash], nil
+}
+
+func (b *testBackend) ChainConfig() *params.ChainConfig {
+	return b.config
+}
+
+func TestSuggestTipCap(t *testing.T) {
+	tests := []struct {
+		name        string
+		londonEpoch *big.Int
+		want        *big.Int
+	}{
+		{
+			// the legacy gas prices of blocks 3 and 4 are 1, 1, 2, 2, 3 and 3
+			// gwei, the suggested price is the one at the 60th percentile
+			name:        "pre-London",
+			londonEpoch: params.EpochTBD,
+			want:        new(big.Int).Mul(big.NewInt(2), testGwei),
+		},
+		{
+			// the base fee rises by more than 2 gwei per block, the price at
+			// the 60th percentile is the lowest tip above the head base fee
+			name:        "London",
+			londonEpoch: big.NewInt(0),
+			want:        new(big.Int).Set(testGwei),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oracle := NewOracle(newTestBackend(t, test.londonEpoch), GasPriceConfig{
+				Blocks:     2,
+				Percentile: 60,
+				Default:    new(big.Int),
+			})
+			got, err := oracle.SuggestTipCap(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Cmp(test.want) != 0 {
+				t.Errorf("tip cap mismatch: have %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -90,8 +90,8 @@ type NodeAPI interface {
 	AddPendingTransaction(newTx *types.Transaction) error
 	Blockchain() core.BlockChain
 	Beaconchain() core.BlockChain
-	GetTransactionsHistory(address, txType, order string) ([]common.Hash, error)
-	GetStakingTransactionsHistory(address, txType, order string) ([]common.Hash, error)
+	GetTransactionsHistory(query TxHistoryQuery) ([]common.Hash, string, error)
+	GetStakingTransactionsHistory(query TxHistoryQuery) ([]common.Hash, string, error)
 	GetTransactionsCount(address, txType string) (uint64, error)
 	GetStakingTransactionsCount(address, txType string) (uint64, error)
 	GetTraceResultByHash(hash common.Hash) (json.RawMessage, error)
@@ -242,7 +242,10 @@ func (hmy *Harmony) GetNodeMetadata() commonRPC.NodeMetadata {
 func (hmy *Harmony) GetEVM(ctx context.Context, msg core.Message, state *state.DB, header *block.Header) (*vm.EVM, error) {
 	state.SetBalance(msg.From(), math.MaxBig256)
 	vmCtx := core.NewEVMContext(msg, header, hmy.BlockChain, nil)
-	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), *hmy.BlockChain.GetVMConfig()), nil
+	vmConfig := *hmy.BlockChain.GetVMConfig()
+	// calls without a gas price are not charged the base fee
+	vmConfig.NoBaseFee = true
+	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), vmConfig), nil
 }
 
 // ChainDb ..
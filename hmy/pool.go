@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
 )
 
 // GetPoolStats returns the number of pending and queued transactions
@@ -51,3 +52,17 @@ func (hmy *Harmony) GetPoolTransactions() (types.PoolTransactions, error) {
 func (hmy *Harmony) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	return hmy.gpo.SuggestPrice(ctx)
 }
+
+// SuggestGasTipCap returns a suggested priority fee per gas for dynamic fee
+// transactions.
+func (hmy *Harmony) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return hmy.gpo.SuggestTipCap(ctx)
+}
+
+// FeeHistory returns the base fees, gas used ratios and reward percentiles of
+// up to blockCount blocks ending at lastBlock.
+func (hmy *Harmony) FeeHistory(
+	ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64,
+) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
+	return hmy.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+}
@@ -162,9 +162,10 @@ func (hmy *Harmony) SendStakingTx(ctx context.Context, signedStakingTx *staking.
 	return ErrFinalizedTransaction
 }
 
-// GetStakingTransactionsHistory returns list of staking transactions hashes of address.
-func (hmy *Harmony) GetStakingTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
-	return hmy.NodeAPI.GetStakingTransactionsHistory(address, txType, order)
+// GetStakingTransactionsHistory returns a page of the staking transactions hashes
+// of address, and the cursor of the next page if the page is full.
+func (hmy *Harmony) GetStakingTransactionsHistory(query TxHistoryQuery) ([]common.Hash, string, error) {
+	return hmy.NodeAPI.GetStakingTransactionsHistory(query)
 }
 
 // GetStakingTransactionsCount returns the number of staking transactions of address.
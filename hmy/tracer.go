@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -39,6 +40,7 @@ import (
 	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy/tracers"
+	"github.com/harmony-one/harmony/hmy/tracers/native"
 	"github.com/harmony-one/harmony/internal/utils"
 )
 
@@ -58,9 +60,10 @@ const (
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*vm.LogConfig
-	Tracer  *string
-	Timeout *string
-	Reexec  *uint64
+	Tracer       *string
+	TracerConfig json.RawMessage
+	Timeout      *string
+	Reexec       *uint64
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -164,8 +167,8 @@ func (hmy *Harmony) TraceChain(ctx context.Context, start, end *types.Block, con
 
 			// Fetch and execute the next block trace tasks
 			for task := range tasks {
-				hmySigner := types.MakeSigner(hmy.BlockChain.Config(), task.block.Number())
-				ethSigner := types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+				hmySigner := types.MakeSigner(hmy.BlockChain.Config(), task.block.Epoch())
+				ethSigner := types.MakeEthSigner(hmy.BlockChain.Config(), task.block.Epoch())
 
 				// Trace all the transactions contained within
 				for i, tx := range task.block.Transactions() {
@@ -364,8 +367,8 @@ func (hmy *Harmony) traceBlockNoThread(ctx context.Context, block *types.Block,
 	}
 	// Execute all the transaction contained within the block concurrently
 	var (
-		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-		ethSigner = types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+		ethSigner = types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 		txs       = block.Transactions()
 		results   = make([]*TxTraceResult, len(txs))
 	)
@@ -439,8 +442,8 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 	}
 	// Execute all the transaction contained within the block concurrently
 	var (
-		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-		ethSigner = types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+		ethSigner = types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 		txs       = block.Transactions()
 		results   = make([]*TxTraceResult, len(txs))
 
@@ -512,6 +515,100 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 	return results, nil
 }
 
+// BadBlockReplay is the result of the re-execution of a bad block on the state
+// of its parent.
+type BadBlockReplay struct {
+	Hash   common.Hash `json:"hash"`
+	Number uint64      `json:"number"`
+	// Traces holds the traces of the plain transactions of the block, up to
+	// the first one failing to execute.
+	Traces []*TxTraceResult `json:"traces"`
+	// Error is the error the processing or the validation of the block failed
+	// with, empty if the block is valid on top of the local parent state.
+	Error string `json:"error,omitempty"`
+	// Root and GasUsed are computed by the local processing of the block, and
+	// are missing if the processing failed.
+	Root            *common.Hash `json:"root,omitempty"`
+	ExpectedRoot    common.Hash  `json:"expectedRoot"`
+	GasUsed         *uint64      `json:"gasUsed,omitempty"`
+	ExpectedGasUsed uint64       `json:"expectedGasUsed"`
+}
+
+// ReplayBadBlock re-executes a block on the state of its parent, tracing its
+// transactions one by one until one fails, and processes the whole block again
+// to report where the local state diverged from the one claimed by its header.
+// The staking transactions are processed but not traced.
+func (hmy *Harmony) ReplayBadBlock(ctx context.Context, block *types.Block, config *TraceConfig) (*BadBlockReplay, error) {
+	parent := hmy.BlockChain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, err := hmy.ComputeStateDB(parent, reexec)
+	if err != nil {
+		return nil, err
+	}
+	replay := &BadBlockReplay{
+		Hash:            block.Hash(),
+		Number:          block.NumberU64(),
+		Traces:          []*TxTraceResult{},
+		ExpectedRoot:    block.Root(),
+		ExpectedGasUsed: block.GasUsed(),
+	}
+
+	// Process and validate the whole block the way the chain did on insertion
+	processState := statedb.Copy()
+	receipts, cxReceipts, _, _, usedGas, _, _, err := hmy.BlockChain.Processor().Process(
+		block, processState, vm.Config{}, false,
+	)
+	if err == nil {
+		err = hmy.BlockChain.Validator().ValidateState(block, processState, receipts, cxReceipts, usedGas)
+		root := processState.IntermediateRoot(hmy.BlockChain.Config().IsS3(block.Epoch()))
+		replay.Root, replay.GasUsed = &root, &usedGas
+	}
+	if err != nil {
+		replay.Error = err.Error()
+	}
+
+	// Trace the transactions until the first failing one
+	var (
+		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+		ethSigner = types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
+		blockHash = block.Hash()
+	)
+	for i, tx := range block.Transactions() {
+		select {
+		case <-ctx.Done():
+			return nil, errors.New("trace task was canceled!")
+		default:
+		}
+		signer := hmySigner
+		if tx.IsEthCompatible() {
+			signer = ethSigner
+		}
+		msg, err := tx.AsMessage(signer)
+		if err != nil {
+			replay.Traces = append(replay.Traces, &TxTraceResult{Error: err.Error()})
+			break
+		}
+		statedb.Prepare(tx.Hash(), blockHash, i)
+		statedb.SetTxHashETH(tx.ConvertToEth().Hash())
+		vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
+		res, err := hmy.TraceTx(ctx, msg, vmctx, statedb, config)
+		if err != nil {
+			replay.Traces = append(replay.Traces, &TxTraceResult{Error: err.Error()})
+			break
+		}
+		replay.Traces = append(replay.Traces, &TxTraceResult{Result: res})
+		// Finalize the state so any modifications are written to the trie
+		statedb.Finalise(true)
+	}
+	return replay, nil
+}
+
 // standardTraceBlockToFile configures a new tracer which uses standard JSON output,
 // and traces either a full block or an individual transaction. The return value will
 // be one filename per transaction traced.
@@ -553,8 +650,8 @@ func (hmy *Harmony) standardTraceBlockToFile(ctx context.Context, block *types.B
 
 	// Execute transaction, either tracing all or just the requested one
 	var (
-		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-		ethSigner = types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+		ethSigner = types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 		dumps     []string
 	)
 	for i, tx := range block.Transactions() {
@@ -710,7 +807,7 @@ func (hmy *Harmony) ComputeStateDB(block *types.Block, reexec uint64) (*state.DB
 // be tracer dependent.
 // NOTE: Only support default StructLogger tracer
 func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.DB, config *TraceConfig) (interface{}, error) {
-	// Assemble the structured logger or the JavaScript tracer
+	// Assemble the structured logger, the native or the JavaScript tracer
 	var (
 		tracer vm.Tracer
 		err    error
@@ -731,15 +828,27 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 				return nil, err
 			}
 		}
-		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
+		// Construct the native tracer by name, or the JavaScript tracer to
+		// execute with if there is none
+		var stop func(err error)
+		nativeTracer, ok, err := native.New(*config.Tracer, &native.Context{GasLimit: message.Gas()}, config.TracerConfig)
+		if err != nil {
 			return nil, err
 		}
+		if ok {
+			tracer, stop = nativeTracer, nativeTracer.Stop
+		} else {
+			jsTracer, err := tracers.New(*config.Tracer)
+			if err != nil {
+				return nil, err
+			}
+			tracer, stop = jsTracer, jsTracer.Stop
+		}
 		// Handle timeouts and RPC cancellations
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 		go func() {
 			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
+			stop(errors.New("execution timeout"))
 		}()
 		defer cancel()
 
@@ -768,6 +877,8 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 
 	case *tracers.Tracer:
 		return tracer.GetResult()
+	case native.Tracer:
+		return tracer.GetResult()
 	case *tracers.ParityBlockTracer:
 		return tracer.GetResult()
 	case *tracers.RosettaBlockTracer:
@@ -795,8 +906,8 @@ func (hmy *Harmony) ComputeTxEnv(block *types.Block, txIndex int, reexec uint64)
 	}
 
 	// Recompute transactions up to the target index.
-	hmySigner := types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-	ethSigner := types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+	hmySigner := types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+	ethSigner := types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 
 	for idx, tx := range block.Transactions() {
 		signer := hmySigner
@@ -834,8 +945,8 @@ func (hmy *Harmony) ComputeTxEnvEachBlockWithoutApply(block *types.Block, reexec
 	}
 
 	// Recompute transactions up to the target index.
-	hmySigner := types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-	ethSigner := types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+	hmySigner := types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+	ethSigner := types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 
 	for idx, tx := range block.Transactions() {
 		signer := hmySigner
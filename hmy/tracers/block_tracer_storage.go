@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/crypto/hash"
 )
 
@@ -204,3 +205,49 @@ func (ts *TraceBlockStorage) ToJson() (json.RawMessage, error) {
 	}
 	return json.Marshal(results)
 }
+
+// InternalCall is a call, contract creation or self destruct made during the
+// execution of a transaction, decoded from the trace storage.
+type InternalCall struct {
+	TxHash       common.Hash
+	TxIndex      int
+	TraceAddress []uint // position in the call tree, empty for the transaction itself
+	Op           vm.OpCode
+	From         common.Address
+	To           common.Address // the created contract of contract creations
+	Value        *big.Int
+	Output       []byte // the return data of calls, the code of the created contracts
+	Reverted     bool
+}
+
+// InternalCalls returns the actions of all the transactions of the block, in
+// the order of the transactions and of the calls.
+func (ts *TraceBlockStorage) InternalCalls() ([]*InternalCall, error) {
+	var calls []*InternalCall
+	for index, b := range ts.TraceStorages {
+		var txStorage TxStorage
+		if err := rlp.DecodeBytes(b, &txStorage); err != nil {
+			return nil, err
+		}
+		for _, acStorage := range txStorage.Storages {
+			ac := &action{}
+			ac.fromStorage(ts, acStorage)
+			value := ac.value
+			if value == nil {
+				value = big.NewInt(0)
+			}
+			calls = append(calls, &InternalCall{
+				TxHash:       txStorage.Hash,
+				TxIndex:      index,
+				TraceAddress: acStorage.TraceAddress,
+				Op:           ac.op,
+				From:         ac.from,
+				To:           ac.to,
+				Value:        value,
+				Output:       ac.output,
+				Reverted:     ac.err != nil,
+			})
+		}
+	}
+	return calls, nil
+}
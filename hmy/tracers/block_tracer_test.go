@@ -294,3 +294,46 @@ func TestActionMarshal(t *testing.T) {
 		}
 	}
 }
+
+func TestInternalCalls(t *testing.T) {
+	testJsons := make(map[string]json.RawMessage)
+	json.Unmarshal(TestJsonsMock, &testJsons)
+
+	block := &TraceBlockStorage{
+		addressIndex: make(map[common.Address]int),
+		dataIndex:    make(map[common.Hash]int),
+	}
+	initFromJson(block, testJsons["14833359"])
+	calls, err := block.InternalCalls()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls got %d", len(calls))
+	}
+	create, call := calls[0], calls[1]
+	if create.Op != vm.CREATE || create.TxIndex != 0 || len(create.TraceAddress) != 0 ||
+		create.From != common.HexToAddress("0x8520021f89450394244cd4abda4cfe2f1b0ef61c") ||
+		create.To != common.HexToAddress("0xf29fcf3a375ce5dd1c58f0e8a584ab5d782cc12b") {
+		t.Errorf("unexpected create %+v", create)
+	}
+	if call.Op != vm.CALL || call.TxIndex != 1 || call.Value.Sign() != 0 || call.Reverted ||
+		call.TxHash != common.HexToHash("0xc3b81fa2f6786ffd11a588b9d951a39adb46b6e29abad819b0cb09ee32ea7072") ||
+		call.To != common.HexToAddress("0x4596817192fbbf0142c576ed3e7cfc0e8f40bbbe") {
+		t.Errorf("unexpected call %+v", call)
+	}
+
+	block = &TraceBlockStorage{
+		addressIndex: make(map[common.Address]int),
+		dataIndex:    make(map[common.Hash]int),
+	}
+	initFromJson(block, testJsons["14054302"])
+	calls, err = block.InternalCalls()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 || calls[0].Op != vm.SELFDESTRUCT || len(calls[0].TraceAddress) != 1 ||
+		calls[0].To != common.HexToAddress("0x12e49d93588e0056bd25530c3b1e8aac68f4b70a") {
+		t.Errorf("unexpected self destruct %+v", calls)
+	}
+}
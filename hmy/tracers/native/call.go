@@ -0,0 +1,270 @@
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func init() {
+	register("callTracer", newCallTracer)
+}
+
+// callFrame is a call of the trace, along with all the calls it made.
+type callFrame struct {
+	Type    string         `json:"type"`
+	From    common.Address `json:"from"`
+	To      common.Address `json:"to"`
+	Value   *hexutil.Big   `json:"value,omitempty"`
+	Gas     hexutil.Uint64 `json:"gas"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Input   hexutil.Bytes  `json:"input"`
+	Output  hexutil.Bytes  `json:"output,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Calls   []*callFrame   `json:"calls,omitempty"`
+
+	// gas available and cost of the opcode which made the call
+	gasIn, gasCost uint64
+	// memory area the output of the call is copied to
+	outOff, outLen int64
+	// whether the call executed code, i.e. its gas is known
+	stepped bool
+}
+
+type callTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // If true, call tracer won't collect any subcalls
+}
+
+// callTracer is the native counterpart of the call_tracer.js tracer. It
+// reports the tree of calls made by a transaction, skipping the calls to
+// precompiled contracts.
+type callTracer struct {
+	config      callTracerConfig
+	callstack   []*callFrame
+	precompiles map[common.Address]struct{}
+	interrupt   uint32 // Atomic flag to signal execution interruption
+	reason      error  // Textual reason for the interruption
+}
+
+func newCallTracer(ctx *Context, cfg json.RawMessage) (Tracer, error) {
+	var config callTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	return &callTracer{config: config}, nil
+}
+
+// CaptureStart implements the vm.Tracer interface to initialize the tracing
+// operation with the top-level call.
+func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	t.callstack = []*callFrame{{
+		Type:  typ.String(),
+		From:  from,
+		To:    to,
+		Value: (*hexutil.Big)(new(big.Int).Set(value)),
+		Gas:   hexutil.Uint64(gas),
+		Input: common.CopyBytes(input),
+	}}
+	t.precompiles = activePrecompiles(env)
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface to trace a single step of
+// VM execution. Calls are entered when a call opcode is executed and exited
+// in the hook run once the opcode has returned.
+func (t *callTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return nil, nil
+	}
+	if err != nil {
+		t.fault(depth, err)
+		return nil, nil
+	}
+	// The first step of a call reveals the gas it was given
+	if top := t.callstack[len(t.callstack)-1]; depth == len(t.callstack) && !top.stepped {
+		top.stepped = true
+		if depth > 1 {
+			top.Gas = hexutil.Uint64(gas)
+		}
+	}
+
+	switch op {
+	case vm.REVERT:
+		if depth == len(t.callstack) {
+			top := t.callstack[depth-1]
+			top.Error = vm.ErrExecutionReverted.Error()
+			top.Output = memory.GetCopy(stack.Back(0).Int64(), stack.Back(1).Int64())
+		}
+		return nil, nil
+
+	case vm.SELFDESTRUCT:
+		if t.config.OnlyTopCall {
+			return nil, nil
+		}
+		top := t.callstack[len(t.callstack)-1]
+		top.Calls = append(top.Calls, &callFrame{
+			Type:  op.String(),
+			From:  contract.Address(),
+			To:    common.BigToAddress(stack.Back(0)),
+			Value: (*hexutil.Big)(env.StateDB.GetBalance(contract.Address())),
+			Input: []byte{},
+		})
+		return nil, nil
+
+	case vm.CREATE, vm.CREATE2:
+		if t.config.OnlyTopCall {
+			return nil, nil
+		}
+		frame := &callFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			Value:   (*hexutil.Big)(new(big.Int).Set(stack.Back(0))),
+			Input:   memory.GetCopy(stack.Back(1).Int64(), stack.Back(2).Int64()),
+			gasIn:   gas,
+			gasCost: cost,
+		}
+		t.callstack = append(t.callstack, frame)
+		return func(memory *vm.Memory, stack *vm.Stack) {
+			t.exit()
+			// The gas given to the creation, less the unused part refunded
+			// to the caller, is deducted after the cost of the opcode.
+			frame.GasUsed = hexutil.Uint64(frame.gasIn - frame.gasCost - contract.Gas)
+			if !frame.stepped {
+				// creations without init code use no gas
+				frame.Gas = frame.GasUsed
+			}
+			if addr := stack.Back(0); addr.Sign() != 0 {
+				frame.To = common.BigToAddress(addr)
+				frame.Output = env.StateDB.GetCode(frame.To)
+			} else if frame.Error == "" {
+				frame.Error = "internal failure"
+			}
+		}, nil
+
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		if t.config.OnlyTopCall {
+			return nil, nil
+		}
+		to := common.BigToAddress(stack.Back(1))
+		// Skip any pre-compile invocations, those are just fancy opcodes
+		if _, ok := t.precompiles[to]; ok {
+			return nil, nil
+		}
+		off := 1
+		if op == vm.DELEGATECALL || op == vm.STATICCALL {
+			off = 0
+		}
+		frame := &callFrame{
+			Type:    op.String(),
+			From:    contract.Address(),
+			To:      to,
+			Input:   memory.GetCopy(stack.Back(2+off).Int64(), stack.Back(3+off).Int64()),
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  stack.Back(4 + off).Int64(),
+			outLen:  stack.Back(5 + off).Int64(),
+		}
+		if op == vm.CALL || op == vm.CALLCODE {
+			frame.Value = (*hexutil.Big)(new(big.Int).Set(stack.Back(2)))
+		}
+		t.callstack = append(t.callstack, frame)
+		return func(memory *vm.Memory, stack *vm.Stack) {
+			t.exit()
+			// The cost of the call includes the gas it was given, of which
+			// the unused part has been refunded to the caller.
+			refunded := contract.Gas + frame.gasCost - frame.gasIn
+			if !frame.stepped {
+				// calls without code return all their gas
+				frame.Gas = hexutil.Uint64(refunded)
+			}
+			if uint64(frame.Gas) > refunded {
+				frame.GasUsed = frame.Gas - hexutil.Uint64(refunded)
+			}
+			if stack.Back(0).Sign() != 0 {
+				frame.Output = memory.GetCopy(frame.outOff, frame.outLen)
+			} else if frame.Error == "" {
+				frame.Error = "internal failure"
+			}
+		}, nil
+	}
+	return nil, nil
+}
+
+// exit pops the innermost call and adds it to the calls of its parent.
+func (t *callTracer) exit() {
+	size := len(t.callstack)
+	if size <= 1 {
+		return
+	}
+	call := t.callstack[size-1]
+	t.callstack = t.callstack[:size-1]
+	parent := t.callstack[size-2]
+	parent.Calls = append(parent.Calls, call)
+}
+
+// fault records the error of the call executing at the given depth.
+func (t *callTracer) fault(depth int, err error) {
+	if depth != len(t.callstack) {
+		return
+	}
+	if top := t.callstack[depth-1]; top.Error == "" {
+		top.Error = err.Error()
+	}
+}
+
+// CaptureFault implements the vm.Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *callTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	t.fault(depth, err)
+	return nil
+}
+
+// CaptureEnd is called after the top-level call finishes to finalize the
+// tracing.
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	if len(t.callstack) == 0 {
+		return nil
+	}
+	root := t.callstack[0]
+	root.GasUsed = hexutil.Uint64(gasUsed)
+	if err != nil {
+		root.Error = err.Error()
+		if err == vm.ErrExecutionReverted && len(output) > 0 {
+			root.Output = common.CopyBytes(output)
+		}
+		return nil
+	}
+	root.Output = common.CopyBytes(output)
+	return nil
+}
+
+// GetResult returns the json-encoded nested list of call traces, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *callTracer) GetResult() (json.RawMessage, error) {
+	if len(t.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	res, err := json.Marshal(t.callstack[0])
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *callTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
@@ -0,0 +1,67 @@
+// Package native is a collection of transaction tracers written in Go, which
+// are much faster than their JavaScript counterparts and can run concurrently.
+package native
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// Tracer is a native transaction tracer, whose result is available as JSON
+// once the traced message has been applied.
+type Tracer interface {
+	vm.Tracer
+	// GetResult returns the JSON encoded result of the trace, or the reason
+	// the trace was stopped for.
+	GetResult() (json.RawMessage, error)
+	// Stop terminates the trace at the next step, e.g. upon timeout.
+	Stop(err error)
+}
+
+// Context contains the fields of the traced message which a tracer can not
+// observe through the vm.Tracer hooks.
+type Context struct {
+	// GasLimit is the gas limit of the traced message, which is paid for
+	// before the execution starts.
+	GasLimit uint64
+}
+
+type ctorFn func(ctx *Context, cfg json.RawMessage) (Tracer, error)
+
+// ctors contains the constructors of all the native tracers by name.
+var ctors = make(map[string]ctorFn)
+
+// register makes a native tracer available under the given name.
+func register(name string, ctor ctorFn) {
+	ctors[name] = ctor
+}
+
+// New returns the native tracer of the given name, configured with cfg.
+// The boolean is false if there is no native tracer by that name.
+func New(name string, ctx *Context, cfg json.RawMessage) (Tracer, bool, error) {
+	ctor, ok := ctors[name]
+	if !ok {
+		return nil, false, nil
+	}
+	if ctx == nil {
+		ctx = new(Context)
+	}
+	tracer, err := ctor(ctx, cfg)
+	if err != nil {
+		return nil, true, err
+	}
+	return tracer, true, nil
+}
+
+// activePrecompiles returns the set of precompiled contracts of the epoch
+// the EVM runs in.
+func activePrecompiles(env *vm.EVM) map[common.Address]struct{} {
+	addresses := vm.ActivePrecompiles(env.ChainConfig().Rules(env.EpochNumber))
+	precompiles := make(map[common.Address]struct{}, len(addresses))
+	for _, addr := range addresses {
+		precompiles[addr] = struct{}{}
+	}
+	return precompiles
+}
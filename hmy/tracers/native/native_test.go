@@ -0,0 +1,204 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/core/vm/runtime"
+)
+
+var (
+	origin = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	caller = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	callee = common.HexToAddress("0x3000000000000000000000000000000000000003")
+)
+
+// callerCode calls the callee with the 0xdeadbeef selector and one word of
+// arguments, then stops.
+func callerCode() []byte {
+	code := []byte{byte(vm.PUSH32), 0xde, 0xad, 0xbe, 0xef}
+	code = append(code, make([]byte, 28)...)
+	code = append(code,
+		byte(vm.PUSH1), 0, byte(vm.MSTORE), // selector at memory[0:4]
+		byte(vm.PUSH1), 0, // retSize
+		byte(vm.PUSH1), 0, // retOffset
+		byte(vm.PUSH1), 36, // inSize
+		byte(vm.PUSH1), 0, // inOffset
+		byte(vm.PUSH1), 0, // value
+		byte(vm.PUSH20),
+	)
+	code = append(code, callee.Bytes()...)
+	code = append(code,
+		byte(vm.PUSH2), 0xff, 0xff, // gas
+		byte(vm.CALL),
+		byte(vm.STOP),
+	)
+	return code
+}
+
+// revertCode reverts without any data.
+var revertCode = []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT)}
+
+func runTracer(t *testing.T, name string, cfg json.RawMessage, statedb *state.DB, to common.Address, input []byte) json.RawMessage {
+	tracer, ok, err := New(name, nil, cfg)
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", name, err)
+	}
+	if !ok {
+		t.Fatalf("no native tracer named %s", name)
+	}
+	runtime.Call(to, input, &runtime.Config{
+		Origin:    origin,
+		State:     statedb,
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to get %s result: %v", name, err)
+	}
+	return res
+}
+
+func newCallState() *state.DB {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.SetCode(caller, callerCode())
+	statedb.SetCode(callee, revertCode)
+	return statedb
+}
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{"callTracer", "prestateTracer", "4byteTracer"} {
+		if _, ok, err := New(name, nil, nil); !ok || err != nil {
+			t.Errorf("%s: ok %v, err %v", name, ok, err)
+		}
+	}
+	if tracer, ok, err := New("unknownTracer", nil, nil); ok || err != nil || tracer != nil {
+		t.Errorf("unknownTracer: tracer %v, ok %v, err %v", tracer, ok, err)
+	}
+	if _, ok, err := New("callTracer", nil, json.RawMessage(`{"onlyTopCall":`)); !ok || err == nil {
+		t.Errorf("expected the malformed config to be rejected")
+	}
+}
+
+func TestCallTracer(t *testing.T) {
+	res := runTracer(t, "callTracer", nil, newCallState(), caller, nil)
+
+	var root callFrame
+	if err := json.Unmarshal(res, &root); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if root.Type != "CALL" || root.From != origin || root.To != caller || root.Error != "" {
+		t.Errorf("unexpected top call: %+v", root)
+	}
+	if len(root.Calls) != 1 {
+		t.Fatalf("expected 1 subcall, got %d", len(root.Calls))
+	}
+	call := root.Calls[0]
+	if call.Type != "CALL" || call.From != caller || call.To != callee {
+		t.Errorf("unexpected subcall: %+v", call)
+	}
+	if len(call.Input) != 36 || !(call.Input[0] == 0xde && call.Input[3] == 0xef) {
+		t.Errorf("unexpected subcall input: %x", call.Input)
+	}
+	if want := vm.ErrExecutionReverted.Error(); call.Error != want {
+		t.Errorf("expected subcall error %q, got %q", want, call.Error)
+	}
+	if call.Gas != 0xffff {
+		t.Errorf("expected subcall gas %#x, got %#x", 0xffff, uint64(call.Gas))
+	}
+	// PUSH1, PUSH1, REVERT
+	if call.GasUsed != 6 {
+		t.Errorf("expected subcall gas used 6, got %d", call.GasUsed)
+	}
+}
+
+func TestCallTracerOnlyTopCall(t *testing.T) {
+	res := runTracer(t, "callTracer", json.RawMessage(`{"onlyTopCall":true}`), newCallState(), caller, nil)
+
+	var root callFrame
+	if err := json.Unmarshal(res, &root); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if len(root.Calls) != 0 {
+		t.Errorf("expected no subcalls, got %d", len(root.Calls))
+	}
+}
+
+func TestFourByteTracer(t *testing.T) {
+	res := runTracer(t, "4byteTracer", nil, newCallState(), caller, []byte{1, 2, 3, 4})
+
+	var ids map[string]int
+	if err := json.Unmarshal(res, &ids); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	want := map[string]int{"0x01020304-0": 1, "0xdeadbeef-32": 1}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for id, count := range want {
+		if ids[id] != count {
+			t.Errorf("expected %s to be counted %d times, got %d", id, count, ids[id])
+		}
+	}
+}
+
+func TestPrestateTracer(t *testing.T) {
+	var (
+		slot     = common.Hash{}
+		oldValue = common.BigToHash(big.NewInt(2))
+		newValue = common.BigToHash(big.NewInt(1))
+		// sstore(0, 1)
+		code = []byte{byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.SSTORE), byte(vm.STOP)}
+	)
+	newState := func() *state.DB {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb.SetCode(callee, code)
+		statedb.SetState(callee, slot, oldValue)
+		return statedb
+	}
+
+	t.Run("prestate", func(t *testing.T) {
+		res := runTracer(t, "prestateTracer", nil, newState(), callee, nil)
+
+		var pre stateMap
+		if err := json.Unmarshal(res, &pre); err != nil {
+			t.Fatalf("failed to decode result: %v", err)
+		}
+		for _, addr := range []common.Address{origin, callee} {
+			if _, ok := pre[addr]; !ok {
+				t.Errorf("expected %x in the prestate", addr)
+			}
+		}
+		if got := pre[callee]; got == nil || hexutil.Encode(got.Code) != hexutil.Encode(code) || got.Storage[slot] != oldValue {
+			t.Errorf("unexpected callee prestate: %+v", got)
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		res := runTracer(t, "prestateTracer", json.RawMessage(`{"diffMode":true}`), newState(), callee, nil)
+
+		var diff struct {
+			Pre  stateMap `json:"pre"`
+			Post stateMap `json:"post"`
+		}
+		if err := json.Unmarshal(res, &diff); err != nil {
+			t.Fatalf("failed to decode result: %v", err)
+		}
+		// Only the modified storage slot is expected in the diff
+		if len(diff.Pre) != 1 || len(diff.Post) != 1 {
+			t.Fatalf("expected only the callee in the diff, got pre %v, post %v", diff.Pre, diff.Post)
+		}
+		if pre := diff.Pre[callee]; pre == nil || pre.Storage[slot] != oldValue {
+			t.Errorf("unexpected callee prestate: %+v", pre)
+		}
+		if post := diff.Post[callee]; post == nil || post.Storage[slot] != newValue || len(post.Code) != 0 {
+			t.Errorf("unexpected callee poststate: %+v", post)
+		}
+	})
+}
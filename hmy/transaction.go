@@ -8,6 +8,7 @@ import (
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
+	staking "github.com/harmony-one/harmony/staking/types"
 )
 
 // SendTx ...
@@ -54,9 +55,23 @@ func (hmy *Harmony) GetReceipts(ctx context.Context, hash common.Hash) (types.Re
 	return hmy.BlockChain.GetReceiptsByHash(hash), nil
 }
 
-// GetTransactionsHistory returns list of transactions hashes of address.
-func (hmy *Harmony) GetTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
-	return hmy.NodeAPI.GetTransactionsHistory(address, txType, order)
+// TxHistoryQuery selects a page of the transaction history of an address.
+type TxHistoryQuery struct {
+	Address   string             // bech32 address
+	TxType    string             // ALL, SENT or RECEIVED
+	Directive *staking.Directive // staking transactions of the directive only
+	FromBlock uint64
+	ToBlock   uint64 // the current block if zero
+	Order     string // ASC or DESC
+	Cursor    string // cursor returned with the previous page
+	PageIndex uint32 // page to read when there is no cursor
+	PageSize  uint32
+}
+
+// GetTransactionsHistory returns a page of the transactions hashes of address,
+// and the cursor of the next page if the page is full.
+func (hmy *Harmony) GetTransactionsHistory(query TxHistoryQuery) ([]common.Hash, string, error) {
+	return hmy.NodeAPI.GetTransactionsHistory(query)
 }
 
 // GetAccountNonce returns the nonce value of the given address for the given block number
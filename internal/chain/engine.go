@@ -17,6 +17,7 @@ import (
 
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/consensus/misc"
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/consensus/reward"
 	"github.com/harmony-one/harmony/consensus/signature"
@@ -62,6 +63,9 @@ func (e *engineImpl) VerifyHeader(chain engine.ChainReader, header *block.Header
 	if parentHeader == nil {
 		return engine.ErrUnknownAncestor
 	}
+	if err := misc.VerifyEIP1559Header(chain.Config(), parentHeader, header); err != nil {
+		return err
+	}
 	if seal {
 		if err := e.VerifySeal(chain, header); err != nil {
 			return err
@@ -15,6 +15,8 @@ type HarmonyConfig struct {
 	P2P        P2pConfig
 	HTTP       HttpConfig
 	WS         WsConfig
+	GraphQL    GraphQLConfig
+	IPC        IpcConfig
 	RPCOpt     RpcOptConfig
 	BLSKeys    BlsConfig
 	TxPool     TxPoolConfig
@@ -24,6 +26,8 @@ type HarmonyConfig struct {
 	Sys        *SysConfig        `toml:",omitempty"`
 	Consensus  *ConsensusConfig  `toml:",omitempty"`
 	Devnet     *DevnetConfig     `toml:",omitempty"`
+	Dev        *DevConfig        `toml:",omitempty"`
+	Explorer   *ExplorerConfig   `toml:",omitempty"`
 	Revert     *RevertConfig     `toml:",omitempty"`
 	Legacy     *LegacyConfig     `toml:",omitempty"`
 	Prometheus *PrometheusConfig `toml:",omitempty"`
@@ -68,6 +72,9 @@ type GeneralConfig struct {
 	DataDir                string
 	TraceEnable            bool
 	EnablePruneBeaconChain bool
+	EnablePruneState       bool
+	AncientThreshold       uint64 // number of recent blocks kept out of the ancient store, 0 to disable it
+	DBEngine               string // key-value store of the chain databases, leveldb or pebble
 	RunElasticMode         bool
 }
 
@@ -183,6 +190,17 @@ type WsConfig struct {
 	AuthPort int
 }
 
+type GraphQLConfig struct {
+	Enabled bool
+	IP      string
+	Port    int
+}
+
+type IpcConfig struct {
+	Enabled bool
+	Path    string // relative paths are resolved against the data directory
+}
+
 type RpcOptConfig struct {
 	DebugEnabled       bool   // Enables PrivateDebugService APIs, including the EVM tracer
 	EthRPCsEnabled     bool   // Expose Eth RPCs
@@ -191,13 +209,31 @@ type RpcOptConfig struct {
 	RpcFilterFile      string // Define filters to enable/disable RPC exposure
 	RateLimterEnabled  bool   // Enable Rate limiter for RPC
 	RequestsPerSecond  int    // for RPC rate limiter
+
+	BatchRequestLimit    int            // Maximum number of requests in a batch, 0 for no limit
+	BatchResponseMaxSize int            // Maximum size in bytes of the results of a batch, 0 for no limit
+	IPRateLimit          int            // Requests per second allowed for each client IP, 0 for no limit
+	MethodRateLimits     map[string]int `toml:",omitempty"` // Requests per second allowed for the listed methods, across all clients
+	TrustedProxies       []string       `toml:",omitempty"` // CIDRs of the reverse proxies whose X-Real-IP and X-Forwarded-For headers are trusted
 }
 
 type DevnetConfig struct {
 	NumShards   int
 	ShardSize   int
 	HmyNodeSize int
-	SlotsLimit  int // HIP-16: The absolute number of maximum effective slots per shard limit for each validator. 0 means no limit.
+	SlotsLimit  int    // HIP-16: The absolute number of maximum effective slots per shard limit for each validator. 0 means no limit.
+	ForkURL     string `toml:",omitempty"` // RPC endpoint of the shard 0 node the beacon chain state is forked from
+	ForkBlock   uint64 `toml:",omitempty"` // upstream block the forked state is pinned at, 0 means its latest block
+}
+
+type DevConfig struct {
+	Period   int      // seconds between the sealed blocks, 0 seals a block as soon as a transaction is pending
+	Accounts []string `toml:",omitempty"` // accounts funded in the genesis block, the default dev accounts if empty
+	Balance  int      // ONE funded in each account
+}
+
+type ExplorerConfig struct {
+	SolcPath string // solc binary compiling the verified contract sources, verification disabled if empty
 }
 
 // TODO: make `revert` to a separate command
@@ -245,5 +281,6 @@ type StagedSyncConfig struct {
 	VerifyAllSig           bool   // verify signatures for all blocks regardless of height and batch size
 	VerifyHeaderBatchSize  uint64 // batch size to verify header before insert to chain
 	UseMemDB               bool   // it uses memory by default. set it to false to use disk
+	StateSync              bool   // download the state of a recent epoch block instead of executing all blocks (non-archival shard nodes, needs the stream sync service)
 	LogProgress            bool   // log the full sync progress in console
 }
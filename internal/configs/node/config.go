@@ -84,6 +84,7 @@ type ConfigType struct {
 	StagedSync             bool // use staged sync
 	StagedSyncTurboMode    bool // use Turbo mode for staged sync
 	UseMemDB               bool // use mem db for staged sync
+	StagedSyncStateSync    bool // download the state of a recent epoch block instead of executing all blocks
 	DoubleCheckBlockHashes bool
 	MaxBlocksPerSyncCycle  uint64 // Maximum number of blocks per each cycle. if set to zero, all blocks will be  downloaded and synced in one full cycle.
 	MaxMemSyncCycleSize    uint64 // max number of blocks to use a single transaction for staged sync
@@ -120,6 +121,13 @@ type RPCServerConfig struct {
 	WSPort     int
 	WSAuthPort int
 
+	GraphQLEnabled bool
+	GraphQLIp      string
+	GraphQLPort    int
+
+	IPCEnabled bool
+	IPCPath    string
+
 	DebugEnabled bool
 
 	EthRPCsEnabled     bool
@@ -49,6 +49,8 @@ const (
 	DefaultRPCPort = 9500
 	// DefaultAuthRPCPort is the default rpc auth port. The actual port used is 9000+501
 	DefaultAuthRPCPort = 9501
+	// DefaultGraphQLPort is the default graphql port. The actual port used is 9000+600
+	DefaultGraphQLPort = 9600
 	// DefaultRosettaPort is the default rosetta port. The actual port used is 9000+700
 	DefaultRosettaPort = 9700
 	// DefaultWSPort is the default port for web socket endpoint. The actual port used is
@@ -70,6 +72,10 @@ const (
 const (
 	// DefaultRateLimit for RPC, the number of requests per second
 	DefaultRPCRateLimit = 1000
+	// DefaultRPCBatchRequestLimit is the maximum number of requests in a RPC batch
+	DefaultRPCBatchRequestLimit = 1000
+	// DefaultRPCBatchResponseMaxSize is the maximum size in bytes of the results of a RPC batch
+	DefaultRPCBatchResponseMaxSize = 25 * 1000 * 1000
 )
 
 const (
@@ -70,6 +70,9 @@ var (
 		CrossShardXferPrecompileEpoch: EpochTBD,
 		AllowlistEpoch:                EpochTBD,
 		FeeCollectEpoch:               EpochTBD,
+		BerlinEpoch:                   EpochTBD,
+		LondonEpoch:                   EpochTBD,
+		ShanghaiEpoch:                 EpochTBD,
 	}
 
 	// TestnetChainConfig contains the chain parameters to run a node on the harmony test network.
@@ -108,6 +111,9 @@ var (
 		CrossShardXferPrecompileEpoch: big.NewInt(2),
 		AllowlistEpoch:                big.NewInt(2),
 		FeeCollectEpoch:               EpochTBD,
+		BerlinEpoch:                   EpochTBD,
+		LondonEpoch:                   EpochTBD,
+		ShanghaiEpoch:                 EpochTBD,
 	}
 	// PangaeaChainConfig contains the chain parameters for the Pangaea network.
 	// All features except for CrossLink are enabled at launch.
@@ -146,6 +152,9 @@ var (
 		CrossShardXferPrecompileEpoch: big.NewInt(1),
 		AllowlistEpoch:                EpochTBD,
 		FeeCollectEpoch:               EpochTBD,
+		BerlinEpoch:                   EpochTBD,
+		LondonEpoch:                   EpochTBD,
+		ShanghaiEpoch:                 EpochTBD,
 	}
 
 	// PartnerChainConfig contains the chain parameters for the Partner network.
@@ -185,6 +194,9 @@ var (
 		CrossShardXferPrecompileEpoch: big.NewInt(1),
 		AllowlistEpoch:                EpochTBD,
 		FeeCollectEpoch:               big.NewInt(574),
+		BerlinEpoch:                   EpochTBD,
+		LondonEpoch:                   EpochTBD,
+		ShanghaiEpoch:                 EpochTBD,
 	}
 
 	// StressnetChainConfig contains the chain parameters for the Stress test network.
@@ -224,6 +236,9 @@ var (
 		CrossShardXferPrecompileEpoch: big.NewInt(1),
 		AllowlistEpoch:                EpochTBD,
 		FeeCollectEpoch:               EpochTBD,
+		BerlinEpoch:                   EpochTBD,
+		LondonEpoch:                   EpochTBD,
+		ShanghaiEpoch:                 EpochTBD,
 	}
 
 	// LocalnetChainConfig contains the chain parameters to run for local development.
@@ -262,6 +277,9 @@ var (
 		CrossShardXferPrecompileEpoch: big.NewInt(1),
 		AllowlistEpoch:                EpochTBD,
 		FeeCollectEpoch:               big.NewInt(5),
+		BerlinEpoch:                   big.NewInt(5),
+		LondonEpoch:                   big.NewInt(5),
+		ShanghaiEpoch:                 big.NewInt(5),
 	}
 
 	// AllProtocolChanges ...
@@ -302,6 +320,9 @@ var (
 		big.NewInt(1),                      // CrossShardXferPrecompileEpoch
 		big.NewInt(0),                      // AllowlistEpoch
 		big.NewInt(0),                      // FeeCollectEpoch
+		big.NewInt(0),                      // BerlinEpoch
+		big.NewInt(0),                      // LondonEpoch
+		big.NewInt(0),                      // ShanghaiEpoch
 	}
 
 	// TestChainConfig ...
@@ -342,6 +363,9 @@ var (
 		big.NewInt(1),        // CrossShardXferPrecompileEpoch
 		big.NewInt(0),        // AllowlistEpoch
 		big.NewInt(0),        // FeeCollectEpoch
+		EpochTBD,             // BerlinEpoch
+		EpochTBD,             // LondonEpoch
+		EpochTBD,             // ShanghaiEpoch
 	}
 
 	// TestRules ...
@@ -487,11 +511,22 @@ type ChainConfig struct {
 	// Then before FeeCollectEpoch, txn fees are burned.
 	// After FeeCollectEpoch, txn fees paid to FeeCollector account.
 	FeeCollectEpoch *big.Int
+
+	// BerlinEpoch is the first epoch to support EIP-2930 access list transactions
+	// and the EIP-2929 gas costs for state access opcodes.
+	BerlinEpoch *big.Int `json:"berlin-epoch,omitempty"`
+
+	// LondonEpoch is the first epoch to support EIP-1559 dynamic fee transactions
+	// and the base fee in the block header.
+	LondonEpoch *big.Int `json:"london-epoch,omitempty"`
+
+	// ShanghaiEpoch is the first epoch to support the PUSH0 opcode (EIP-3855).
+	ShanghaiEpoch *big.Int `json:"shanghai-epoch,omitempty"`
 }
 
 // String implements the fmt.Stringer interface.
 func (c *ChainConfig) String() string {
-	return fmt.Sprintf("{ChainID: %v EthCompatibleChainID: %v EIP155: %v CrossTx: %v Staking: %v CrossLink: %v ReceiptLog: %v SHA3Epoch: %v StakingPrecompileEpoch: %v ChainIdFixEpoch: %v CrossShardXferPrecompileEpoch: %v}",
+	return fmt.Sprintf("{ChainID: %v EthCompatibleChainID: %v EIP155: %v CrossTx: %v Staking: %v CrossLink: %v ReceiptLog: %v SHA3Epoch: %v StakingPrecompileEpoch: %v ChainIdFixEpoch: %v CrossShardXferPrecompileEpoch: %v BerlinEpoch: %v LondonEpoch: %v ShanghaiEpoch: %v}",
 		c.ChainID,
 		c.EthCompatibleChainID,
 		c.EIP155Epoch,
@@ -503,6 +538,9 @@ func (c *ChainConfig) String() string {
 		c.StakingPrecompileEpoch,
 		c.ChainIdFixEpoch,
 		c.CrossShardXferPrecompileEpoch,
+		c.BerlinEpoch,
+		c.LondonEpoch,
+		c.ShanghaiEpoch,
 	)
 }
 
@@ -692,6 +730,23 @@ func (c *ChainConfig) IsFeeCollectEpoch(epoch *big.Int) bool {
 	return isForked(c.FeeCollectEpoch, epoch)
 }
 
+// IsBerlin determines whether EIP-2930 access list transactions and the
+// EIP-2929 gas costs are enabled.
+func (c *ChainConfig) IsBerlin(epoch *big.Int) bool {
+	return isForked(c.BerlinEpoch, epoch)
+}
+
+// IsLondon determines whether EIP-1559 dynamic fee transactions and the
+// block base fee are enabled.
+func (c *ChainConfig) IsLondon(epoch *big.Int) bool {
+	return isForked(c.LondonEpoch, epoch)
+}
+
+// IsShanghai determines whether the PUSH0 opcode is enabled.
+func (c *ChainConfig) IsShanghai(epoch *big.Int) bool {
+	return isForked(c.ShanghaiEpoch, epoch)
+}
+
 // UpdateEthChainIDByShard update the ethChainID based on shard ID.
 func UpdateEthChainIDByShard(shardID uint32) {
 	once.Do(func() {
@@ -748,7 +803,13 @@ type Rules struct {
 	IsIstanbul, IsVRF, IsPrevVRF, IsSHA3,
 	IsStakingPrecompile, IsCrossShardXferPrecompile,
 	// eip-155 chain id fix
-	IsChainIdFix bool
+	IsChainIdFix,
+	// eip-2929 access lists
+	IsBerlin,
+	// eip-1559 base fee, eip-3198, eip-3529 and eip-3541
+	IsLondon,
+	// eip-3855
+	IsShanghai bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -773,5 +834,8 @@ func (c *ChainConfig) Rules(epoch *big.Int) Rules {
 		IsStakingPrecompile:        c.IsStakingPrecompile(epoch),
 		IsCrossShardXferPrecompile: c.IsCrossShardXferPrecompile(epoch),
 		IsChainIdFix:               c.IsChainIdFix(epoch),
+		IsBerlin:                   c.IsBerlin(epoch),
+		IsLondon:                   c.IsLondon(epoch),
+		IsShanghai:                 c.IsShanghai(epoch),
 	}
 }
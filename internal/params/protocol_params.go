@@ -134,6 +134,24 @@ const (
 	ExtcodeHashGasEIP1884        uint64 = 700  // Cost of EXTCODEHASH after EIP 1884 (part in Istanbul)
 	SelfdestructGasEIP150        uint64 = 5000 // Cost of SELFDESTRUCT post EIP 150 (Tangerine)
 
+	ColdAccountAccessCostEIP2929 = uint64(2600) // COLD_ACCOUNT_ACCESS_COST
+	ColdSloadCostEIP2929         = uint64(2100) // COLD_SLOAD_COST
+	WarmStorageReadCostEIP2929   = uint64(100)  // WARM_STORAGE_READ_COST
+
+	// In EIP-2200: SstoreResetGas was 5000.
+	// In EIP-2929: SstoreResetGas was changed to '5000 - COLD_SLOAD_COST'.
+	// In EIP-3529: SSTORE_CLEARS_SCHEDULE is defined as SSTORE_RESET_GAS + ACCESS_LIST_STORAGE_KEY_COST
+	// Which becomes: 5000 - 2100 + 1900 = 4800
+	SstoreClearsScheduleRefundEIP3529 uint64 = SstoreCleanGasEIP2200 - ColdSloadCostEIP2929 + TxAccessListStorageKeyGas
+
+	// RefundQuotient is the maximum refund quotient; max gas refund is gasUsed / RefundQuotient
+	RefundQuotient uint64 = 2
+	// RefundQuotientEIP3529 is the maximum refund quotient after EIP-3529
+	RefundQuotientEIP3529 uint64 = 5
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address specified in EIP 2930 access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP 2930 access list
+
 	// EXP has a dynamic portion depending on the size of the exponent
 	ExpByteFrontier uint64 = 10 // was set to 10 in Frontier
 	ExpByteEIP158   uint64 = 50 // was raised to 50 during Eip158 (Spurious Dragon)
@@ -171,6 +189,14 @@ const (
 	Bn256PairingPerPointGasByzantium uint64 = 80000  // Byzantium per-point price for an elliptic curve pairing check
 	Bn256PairingPerPointGasIstanbul  uint64 = 34000  // Per-point price for an elliptic curve pairing check
 
+	// BaseFeeChangeDenominator bounds the amount the base fee can change between blocks (EIP-1559).
+	BaseFeeChangeDenominator uint64 = 8
+	// ElasticityMultiplier bounds the maximum gas limit an EIP-1559 block may have.
+	ElasticityMultiplier uint64 = 2
+	// InitialBaseFee is the base fee of the first EIP-1559 block. It is also the
+	// floor of the base fee, matching the default minimum gas price of the pool.
+	InitialBaseFee uint64 = 100e9
+
 	//SHA3-FIPS Precompiled contracts gas price esstimation as per ethereum yellow paper appendix G
 	Sha3FipsGas     uint64 = 30 // Once per SHA3-256 operation.
 	Sha3FipsWordGas uint64 = 6  // Once per word of the SHA3-256 operation's data.
@@ -8,15 +8,21 @@ import (
 
 	"github.com/harmony-one/harmony/internal/shardchain/leveldb_shard"
 	"github.com/harmony-one/harmony/internal/shardchain/local_cache"
+	"github.com/harmony-one/harmony/internal/shardchain/pebble_db"
 
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	hmyrawdb "github.com/harmony-one/harmony/core/rawdb"
 
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
 )
 
 const (
 	LDBDirPrefix      = "harmony_db"
 	LDBShardDirPrefix = "harmony_sharddb"
+	PebbleDirPrefix   = "harmony_pebble"
+	// AncientDir is the directory of the ancient store inside a shard database
+	AncientDir = "ancient"
 )
 
 // DBFactory is a blockchain database factory.
@@ -29,12 +35,53 @@ type DBFactory interface {
 // LDBFactory is a LDB-backed blockchain database factory.
 type LDBFactory struct {
 	RootDir string // directory in which to put shard databases in.
+	// AncientThreshold is the number of recent blocks kept in the LDB, the older
+	// ones being moved to the ancient store of the shard database. Zero
+	// disables the ancient store.
+	AncientThreshold uint64
 }
 
 // NewChainDB returns a new LDB for the blockchain for given shard.
 func (f *LDBFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
 	dir := path.Join(f.RootDir, fmt.Sprintf("%s_%d", LDBDirPrefix, shardID))
-	return rawdb.NewLevelDBDatabase(dir, 256, 1024, "")
+	kvdb, err := leveldb.New(dir, 256, 1024, "")
+	if err != nil {
+		return nil, err
+	}
+	return newChainDB(kvdb, dir, f.AncientThreshold)
+}
+
+// PebbleFactory is a pebble-backed blockchain database factory.
+type PebbleFactory struct {
+	RootDir string // directory in which to put shard databases in.
+	// AncientThreshold is the number of recent blocks kept in pebble, the older
+	// ones being moved to the ancient store of the shard database. Zero
+	// disables the ancient store.
+	AncientThreshold uint64
+}
+
+// NewChainDB returns a new pebble database for the blockchain for given shard.
+func (f *PebbleFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
+	dir := path.Join(f.RootDir, fmt.Sprintf("%s_%d", PebbleDirPrefix, shardID))
+	kvdb, err := pebble_db.New(dir, 256, 1024)
+	if err != nil {
+		return nil, err
+	}
+	return newChainDB(kvdb, dir, f.AncientThreshold)
+}
+
+// newChainDB wraps the key-value store of the shard database in the given
+// directory, with an ancient store if the threshold is set.
+func newChainDB(kvdb ethdb.KeyValueStore, dir string, ancientThreshold uint64) (ethdb.Database, error) {
+	if ancientThreshold == 0 {
+		return rawdb.NewDatabase(kvdb), nil
+	}
+	db, err := hmyrawdb.NewDatabaseWithFreezer(kvdb, path.Join(dir, AncientDir), ancientThreshold)
+	if err != nil {
+		kvdb.Close()
+		return nil, err
+	}
+	return db, nil
 }
 
 // MemDBFactory is a memory-backed blockchain database factory.
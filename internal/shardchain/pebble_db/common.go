@@ -0,0 +1,7 @@
+package pebble_db
+
+import "github.com/pkg/errors"
+
+// ErrNotSupported is returned when opening a pebble database with a binary
+// built without the pebble build tag.
+var ErrNotSupported = errors.New("pebble database not supported, build with the pebble build tag")
@@ -0,0 +1,14 @@
+//go:build !pebble
+// +build !pebble
+
+package pebble_db
+
+import "github.com/ethereum/go-ethereum/ethdb"
+
+// Supported reports whether the binary is built with the pebble support.
+const Supported = false
+
+// New returns ErrNotSupported, the binary being built without pebble.
+func New(dir string, cache int, handles int) (ethdb.KeyValueStore, error) {
+	return nil, ErrNotSupported
+}
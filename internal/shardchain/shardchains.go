@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/pruner"
+	"github.com/harmony-one/harmony/core/state/remote"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	"github.com/harmony-one/harmony/internal/shardchain/tikv_manage"
 
@@ -45,6 +47,7 @@ type CollectionImpl struct {
 	disableCache  map[uint32]bool
 	chainConfig   *params.ChainConfig
 	harmonyconfig *harmonyconfig.HarmonyConfig
+	fork          *remote.State // upstream state of the forked beacon chain, if any
 }
 
 // NewCollection creates and returns a new shard chain collection.
@@ -100,12 +103,21 @@ func (sc *CollectionImpl) ShardChain(shardID uint32, options ...core.Options) (c
 	}
 	var cacheConfig *core.CacheConfig
 	if sc.disableCache[shardID] {
-		cacheConfig = &core.CacheConfig{Disabled: true}
+		cacheConfig = &core.CacheConfig{Disabled: true, SnapshotLimit: core.DefaultSnapshotLimit}
 		utils.Logger().Info().
 			Uint32("shardID", shardID).
 			Msg("disable cache, running in archival mode")
 	}
 
+	if sc.harmonyconfig != nil && sc.harmonyconfig.General.RunElasticMode {
+		// The state of the elastic mode lives in tikv, not in the local database
+		// the flat state snapshot is kept in
+		if cacheConfig == nil {
+			cacheConfig = core.DefaultCacheConfig()
+		}
+		cacheConfig.SnapshotLimit = 0
+	}
+
 	chainConfig := *sc.chainConfig
 
 	if shardID == shard.BeaconChainShardID {
@@ -120,7 +132,13 @@ func (sc *CollectionImpl) ShardChain(shardID uint32, options ...core.Options) (c
 	if opts.EpochChain {
 		bc, err = core.NewEpochChain(db, &chainConfig, sc.engine, vm.Config{})
 	} else {
-		stateCache, err := initStateCache(db, sc, shardID)
+		if sc.harmonyconfig != nil && sc.harmonyconfig.General.EnablePruneState &&
+			!sc.harmonyconfig.General.RunElasticMode && !sc.disableCache[shardID] {
+			// The trie nodes are written through the pruner, so the ones
+			// committed while pruning are kept
+			opts.StatePruner = pruner.NewPruner(db, pruner.DefaultBloomSize)
+		}
+		stateCache, err := initStateCache(db, sc, shardID, opts.StatePruner)
 		if err != nil {
 			return nil, err
 		}
@@ -154,7 +172,7 @@ func (sc *CollectionImpl) ShardChain(shardID uint32, options ...core.Options) (c
 	return bc, nil
 }
 
-func initStateCache(db ethdb.Database, sc *CollectionImpl, shardID uint32) (state.Database, error) {
+func initStateCache(db ethdb.Database, sc *CollectionImpl, shardID uint32, statePruner *pruner.Pruner) (state.Database, error) {
 	if sc.harmonyconfig != nil && sc.harmonyconfig.General.RunElasticMode {
 		// used for tikv mode, init state db using tikv storage
 		stateDB, err := tikv_manage.GetDefaultTiKVFactory().NewStateDB(shardID)
@@ -162,9 +180,30 @@ func initStateCache(db ethdb.Database, sc *CollectionImpl, shardID uint32) (stat
 			return nil, err
 		}
 		return state.NewDatabaseWithCache(stateDB, 64), nil
+	}
+	var stateCache state.Database
+	if statePruner != nil {
+		stateCache = state.NewDatabase(statePruner.Database())
 	} else {
-		return state.NewDatabase(db), nil
+		stateCache = state.NewDatabase(db)
 	}
+	if shardID == shard.BeaconChainShardID && sc.harmonyconfig != nil &&
+		sc.harmonyconfig.Devnet != nil && sc.harmonyconfig.Devnet.ForkURL != "" {
+		// the state missing locally is lazily fetched from the upstream chain
+		if sc.fork == nil {
+			fork, err := remote.New(sc.harmonyconfig.Devnet.ForkURL, sc.harmonyconfig.Devnet.ForkBlock)
+			if err != nil {
+				return nil, err
+			}
+			utils.Logger().Info().
+				Str("url", sc.harmonyconfig.Devnet.ForkURL).
+				Uint64("block", fork.Block()).
+				Msg("forking the beacon chain state")
+			sc.fork = fork
+		}
+		stateCache.SetFork(sc.fork)
+	}
+	return stateCache, nil
 }
 
 // DisableCache disables caching mode for newly opened chains.
@@ -214,5 +253,8 @@ func (sc *CollectionImpl) Close() error {
 			Uint32("shardID", shardID).
 			Msg("closed shard chain")
 	}
+	if sc.fork != nil {
+		sc.fork.Close()
+	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package node
 
 import (
+	"net/http"
+
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
@@ -10,6 +12,7 @@ import (
 	hmy_rpc "github.com/harmony-one/harmony/rpc"
 	rpc_common "github.com/harmony-one/harmony/rpc/common"
 	"github.com/harmony-one/harmony/rpc/filters"
+	"github.com/harmony-one/harmony/rpc/graphql"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -71,7 +74,16 @@ func (node *Node) StartRPC() error {
 	// Gather all the possible APIs to surface
 	apis := node.APIs(harmony)
 
-	return hmy_rpc.StartServers(harmony, apis, node.NodeConfig.RPCServer, node.HarmonyConfig.RPCOpt)
+	var graphqlHandler http.Handler
+	if node.NodeConfig.RPCServer.GraphQLEnabled {
+		handler, err := graphql.NewHandler(harmony)
+		if err != nil {
+			return err
+		}
+		graphqlHandler = handler
+	}
+
+	return hmy_rpc.StartServers(harmony, apis, graphqlHandler, node.NodeConfig.RPCServer, node.HarmonyConfig.RPCOpt)
 }
 
 // StopRPC stop RPC service
@@ -102,7 +114,7 @@ func (node *Node) APIs(harmony *hmy.Harmony) []rpc.API {
 	}
 
 	// Append all the local APIs and return
-	return []rpc.API{
+	apis := []rpc.API{
 		hmy_rpc.NewPublicNetAPI(node.host, harmony.ChainID, hmy_rpc.V1),
 		hmy_rpc.NewPublicNetAPI(node.host, harmony.ChainID, hmy_rpc.V2),
 		hmy_rpc.NewPublicNetAPI(node.host, harmony.ChainID, hmy_rpc.Eth),
@@ -110,6 +122,10 @@ func (node *Node) APIs(harmony *hmy.Harmony) []rpc.API {
 		hmyFilter,
 		ethFilter,
 	}
+	if node.DevSealer != nil {
+		apis = append(apis, hmy_rpc.NewPublicDevAPI(node.DevSealer))
+	}
+	return apis
 }
 
 // GetConsensusMode returns the current consensus mode
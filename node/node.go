@@ -152,6 +152,9 @@ type Node struct {
 
 	Metrics metrics.Registry
 
+	// DevSealer seals the blocks in place of the consensus in dev mode
+	DevSealer *DevSealer
+
 	// context control for pub-sub handling
 	psCtx    context.Context
 	psCancel func()
@@ -0,0 +1,225 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/consensus/signature"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// DevSealer seals the blocks of a dev mode node, the only member of the
+// committee of its chain. The blocks are proposed from the transaction pool
+// and signed by the node's own keys in place of the FBFT rounds.
+type DevSealer struct {
+	node   *Node
+	period time.Duration
+
+	lock       sync.Mutex // serializes the sealing and the rewinds of the chain
+	timeOffset int64
+	snapshots  []devSnapshot
+
+	txsCh       chan core.NewTxsEvent
+	txsSub      event.Subscription
+	stopChan    chan struct{}
+	stoppedChan chan struct{}
+}
+
+// devSnapshot is a chain head the chain can be rewound to.
+type devSnapshot struct {
+	number     uint64
+	timeOffset int64
+}
+
+// NewDevSealer creates the block sealer of a dev mode node. With a zero period
+// a block is sealed as soon as a transaction is pending, otherwise the pending
+// transactions are sealed once per period.
+func NewDevSealer(node *Node, period time.Duration) *DevSealer {
+	return &DevSealer{node: node, period: period}
+}
+
+// Start implements service.Service, sealing the pending transactions.
+func (s *DevSealer) Start() error {
+	s.txsCh = make(chan core.NewTxsEvent, 16)
+	s.txsSub = s.node.TxPool.SubscribeNewTxsEvent(s.txsCh)
+	s.stopChan = make(chan struct{})
+	s.stoppedChan = make(chan struct{})
+	go s.loop()
+	return nil
+}
+
+// Stop implements service.Service.
+func (s *DevSealer) Stop() error {
+	close(s.stopChan)
+	<-s.stoppedChan
+	return nil
+}
+
+func (s *DevSealer) loop() {
+	defer close(s.stoppedChan)
+	defer s.txsSub.Unsubscribe()
+
+	var tick <-chan time.Time
+	if s.period > 0 {
+		ticker := time.NewTicker(s.period)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-s.txsCh:
+			if s.period == 0 {
+				s.sealPending()
+			}
+		case <-tick:
+			s.sealPending()
+		case <-s.txsSub.Err():
+			return
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// sealPending seals blocks until no transaction is pending, or until the
+// pending transactions cannot be included.
+func (s *DevSealer) sealPending() {
+	for {
+		if pending, _ := s.node.TxPool.Stats(); pending == 0 {
+			return
+		}
+		block, err := s.Mine()
+		if err != nil {
+			utils.Logger().Error().Err(err).Msg("[DevSealer] Failed sealing a new block")
+			return
+		}
+		if len(block.Transactions()) == 0 && len(block.StakingTransactions()) == 0 {
+			return
+		}
+	}
+}
+
+// Mine seals a new block on the current head with the pending transactions.
+func (s *DevSealer) Mine() (*types.Block, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	node := s.node
+	bc := node.Blockchain()
+	parent := bc.CurrentBlock()
+	parentSig, err := s.commitSig(parent)
+	if err != nil {
+		return nil, err
+	}
+	commitSigs := make(chan []byte, 1)
+	commitSigs <- parentSig
+
+	node.Worker.SetTimeOffset(s.timeOffset)
+	block, err := node.ProposeNewBlock(commitSigs)
+	if err != nil {
+		return nil, errors.Wrap(err, "propose new block")
+	}
+	if _, err := bc.InsertChain(types.Blocks{block}, true); err != nil {
+		return nil, errors.Wrap(err, "insert new block")
+	}
+	sig, err := s.commitSig(block)
+	if err != nil {
+		return nil, err
+	}
+	if err := bc.WriteCommitSig(block.NumberU64(), sig); err != nil {
+		return nil, err
+	}
+	node.TxPool.Reset()
+	s.resetConsensus()
+
+	utils.Logger().Info().
+		Uint64("blockNum", block.NumberU64()).
+		Str("blockHash", block.Hash().Hex()).
+		Int("numTxs", len(block.Transactions())).
+		Int("numStakingTxs", len(block.StakingTransactions())).
+		Msg("[DevSealer] Sealed new block")
+	return block, nil
+}
+
+// IncreaseTime moves the timestamps of the next blocks forward by the given
+// seconds, and returns the total time offset.
+func (s *DevSealer) IncreaseTime(seconds int64) int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.timeOffset += seconds
+	return s.timeOffset
+}
+
+// Snapshot records the current head of the chain and returns the id to
+// revert to it.
+func (s *DevSealer) Snapshot() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.snapshots = append(s.snapshots, devSnapshot{
+		number:     s.node.Blockchain().CurrentBlock().NumberU64(),
+		timeOffset: s.timeOffset,
+	})
+	return uint64(len(s.snapshots))
+}
+
+// Revert rewinds the chain to the head recorded by the snapshot. The snapshot
+// and the later ones are discarded, and false is returned for an unknown id.
+func (s *DevSealer) Revert(id uint64) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if id == 0 || id > uint64(len(s.snapshots)) {
+		return false, nil
+	}
+	snapshot := s.snapshots[id-1]
+	s.snapshots = s.snapshots[:id-1]
+
+	bc := s.node.Blockchain()
+	if bc.CurrentBlock().NumberU64() > snapshot.number {
+		if err := bc.SetHead(snapshot.number); err != nil {
+			return false, err
+		}
+		s.node.TxPool.Reset()
+	}
+	s.timeOffset = snapshot.timeOffset
+	s.resetConsensus()
+	return true, nil
+}
+
+// commitSig returns the commit signature and bitmap of the block signed by
+// all the keys of the node, the whole committee.
+func (s *DevSealer) commitSig(block *types.Block) ([]byte, error) {
+	consensus := s.node.Consensus
+	mask, err := bls.NewMask(consensus.Decider.Participants(), nil)
+	if err != nil {
+		return nil, err
+	}
+	payload := signature.ConstructCommitPayload(s.node.Blockchain(),
+		block.Epoch(), block.Hash(), block.NumberU64(), block.Header().ViewID().Uint64())
+
+	var sigs []*bls_core.Sign
+	for _, key := range consensus.GetPrivateKeys() {
+		if err := mask.SetKey(key.Pub.Bytes, true); err != nil {
+			return nil, errors.Wrapf(err, "key %s not in committee", key.Pub.Bytes.Hex())
+		}
+		sigs = append(sigs, key.Pri.SignHash(payload))
+	}
+	return append(bls.AggregateSig(sigs).Serialize(), mask.Mask()...), nil
+}
+
+// resetConsensus points the consensus at the block following the head, so
+// that the RPCs reading it and the next proposal stay consistent.
+func (s *DevSealer) resetConsensus() {
+	head := s.node.Blockchain().CurrentBlock()
+	s.node.Consensus.SetBlockNum(head.NumberU64() + 1)
+	s.node.Consensus.SetViewIDs(head.Header().ViewID().Uint64() + 1)
+}
@@ -0,0 +1,133 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harmony-one/harmony/consensus"
+	"github.com/harmony-one/harmony/consensus/quorum"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/chain"
+	common2 "github.com/harmony-one/harmony/internal/common"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
+	"github.com/harmony-one/harmony/internal/genesis"
+	"github.com/harmony-one/harmony/internal/registry"
+	"github.com/harmony-one/harmony/internal/shardchain"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/multibls"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/shard"
+)
+
+func TestDevSealer(t *testing.T) {
+	blsKey := bls.RandPrivateKey()
+	pubKey := blsKey.GetPublicKey()
+	instance, err := shardingconfig.NewInstance(1, 1, 1, 0, numeric.OneDec(), []genesis.DeployAccount{{
+		Index:        "0",
+		Address:      common2.MustAddressToBech32(pubKey.GetAddress()),
+		BLSPublicKey: pubKey.SerializeToHexStr(),
+	}}, nil, shardingconfig.Allowlist{}, common.Address{}, nil, shardingconfig.VLBPE)
+	require.NoError(t, err)
+	defer func(schedule shardingconfig.Schedule, networkType nodeconfig.NetworkType) {
+		shard.Schedule = schedule
+		nodeconfig.SetNetworkType(networkType)
+	}(shard.Schedule, nodeconfig.GetDefaultConfig().GetNetworkType())
+	shard.Schedule = shardingconfig.NewFixedSchedule(instance)
+	nodeconfig.SetNetworkType(nodeconfig.Localnet)
+
+	leader := p2p.Peer{IP: "127.0.0.1", Port: "8883", ConsensusPubKey: pubKey}
+	priKey, _, _ := utils.GenKeyP2P("127.0.0.1", "9903")
+	host, err := p2p.NewHost(p2p.HostConfig{
+		Self:   &leader,
+		BLSKey: priKey,
+	})
+	require.NoError(t, err)
+
+	var (
+		key, _  = crypto.GenerateKey()
+		from    = crypto.PubkeyToAddress(key.PublicKey)
+		to      = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		balance = big.NewInt(1e18)
+		amount  = big.NewInt(1000)
+	)
+	engine := chain.NewEngine()
+	chainConfig := nodeconfig.GetDefaultConfig().GetNetworkType().ChainConfig()
+	collection := shardchain.NewCollection(nil, &shardchain.MemDBFactory{}, &core.GenesisInitializer{
+		NetworkType: nodeconfig.Localnet,
+		Alloc:       core.GenesisAlloc{from: {Balance: balance}},
+	}, engine, &chainConfig)
+	collection.DisableCache(shard.BeaconChainShardID)
+	blockchain, err := collection.ShardChain(shard.BeaconChainShardID)
+	require.NoError(t, err)
+
+	decider := quorum.NewDecider(quorum.SuperMajorityVote, shard.BeaconChainShardID)
+	reg := registry.New().SetBlockchain(blockchain)
+	consensus, err := consensus.New(
+		host, shard.BeaconChainShardID, multibls.GetPrivateKeys(blsKey), reg, decider, 1, false,
+	)
+	require.NoError(t, err)
+	node := New(host, consensus, engine, collection, nil, nil, nil, nil, nil, reg)
+	require.NoError(t, node.InitConsensusWithValidators())
+	consensus.UpdateConsensusInformation()
+
+	sealer := NewDevSealer(node, 0)
+	snapshot := sealer.Snapshot()
+
+	// A pending transaction is sealed as soon as it is added to the pool
+	require.NoError(t, sealer.Start())
+	tx, err := types.SignTx(
+		types.NewTransaction(0, to, shard.BeaconChainShardID, amount, 21000, big.NewInt(1e9), nil),
+		types.NewEIP155Signer(chainConfig.ChainID), key,
+	)
+	require.NoError(t, err)
+	require.NoError(t, node.TxPool.AddLocal(tx))
+	require.Eventually(t, func() bool {
+		return blockchain.CurrentBlock().NumberU64() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, sealer.Stop())
+
+	block := blockchain.CurrentBlock()
+	require.Len(t, block.Transactions(), 1)
+	state, err := blockchain.State()
+	require.NoError(t, err)
+	require.Equal(t, amount, state.GetBalance(to))
+
+	// The time offset applies to the next blocks
+	now := time.Now().Unix()
+	require.EqualValues(t, 3600, sealer.IncreaseTime(3600))
+	block, err = sealer.Mine()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, block.NumberU64())
+	require.GreaterOrEqual(t, block.Time().Int64(), now+3600)
+	sig, err := blockchain.ReadCommitSig(2)
+	require.NoError(t, err)
+	require.NotEmpty(t, sig)
+
+	// Reverting discards the sealed blocks and the time offset
+	ok, err := sealer.Revert(snapshot)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.EqualValues(t, 0, blockchain.CurrentBlock().NumberU64())
+	state, err = blockchain.State()
+	require.NoError(t, err)
+	require.Equal(t, balance, state.GetBalance(from))
+	require.Zero(t, state.GetBalance(to).Sign())
+	require.EqualValues(t, 0, sealer.IncreaseTime(0))
+	ok, err = sealer.Revert(snapshot)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The chain grows again from the reverted head
+	block, err = sealer.Mine()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, block.NumberU64())
+}
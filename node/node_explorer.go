@@ -16,6 +16,7 @@ import (
 	"github.com/harmony-one/harmony/consensus/signature"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/pkg/errors"
 )
@@ -221,40 +222,22 @@ func (node *Node) commitBlockForExplorer(block *types.Block) {
 	}
 }
 
-// GetTransactionsHistory returns list of transactions hashes of address.
-func (node *Node) GetTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
+// GetTransactionsHistory returns a page of the transactions hashes of address.
+func (node *Node) GetTransactionsHistory(query hmy.TxHistoryQuery) ([]common.Hash, string, error) {
 	exp, err := node.getExplorerService()
 	if err != nil {
-		return nil, err
-	}
-	allTxs, tts, err := exp.GetNormalTxHashesByAccount(address)
-	if err != nil {
-		return nil, err
-	}
-	txs := getTargetTxHashes(allTxs, tts, txType)
-
-	if order == "DESC" {
-		reverseTxs(txs)
+		return nil, "", err
 	}
-	return txs, nil
+	return exp.GetNormalTxHashesPage(query)
 }
 
-// GetStakingTransactionsHistory returns list of staking transactions hashes of address.
-func (node *Node) GetStakingTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
+// GetStakingTransactionsHistory returns a page of the staking transactions hashes of address.
+func (node *Node) GetStakingTransactionsHistory(query hmy.TxHistoryQuery) ([]common.Hash, string, error) {
 	exp, err := node.getExplorerService()
 	if err != nil {
-		return nil, err
-	}
-	allTxs, tts, err := exp.GetStakingTxHashesByAccount(address)
-	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	txs := getTargetTxHashes(allTxs, tts, txType)
-
-	if order == "DESC" {
-		reverseTxs(txs)
-	}
-	return txs, nil
+	return exp.GetStakingTxHashesPage(query)
 }
 
 // GetTransactionsCount returns the number of regular transactions hashes of address for input type.
@@ -315,20 +298,3 @@ func (node *Node) getExplorerService() (*explorer.Service, error) {
 func isTargetTxType(tt explorer.TxType, target string) bool {
 	return target == "" || target == "ALL" || target == tt.String()
 }
-
-func getTargetTxHashes(txs []common.Hash, tts []explorer.TxType, target string) []common.Hash {
-	var res []common.Hash
-	for i, tx := range txs {
-		if isTargetTxType(tts[i], target) {
-			res = append(res, tx)
-		}
-	}
-	return res
-}
-
-func reverseTxs(txs []common.Hash) {
-	for i := 0; i < len(txs)/2; i++ {
-		j := len(txs) - i - 1
-		txs[i], txs[j] = txs[j], txs[i]
-	}
-}
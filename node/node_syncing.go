@@ -126,10 +126,24 @@ func (node *Node) createStagedSync(bc core.BlockChain) *stagedsync.StagedSync {
 	role := node.NodeConfig.Role()
 	isExplorer := node.NodeConfig.Role() == nodeconfig.ExplorerNode
 
+	// The state sync stage downloads the state through the sync streams of the
+	// downloader, and verifies the pivot against the committee in the beacon chain.
+	var stateSyncProtocol stagedsync.StateSyncProtocol
+	if ds := node.getDownloaders(); ds != nil {
+		if d := ds.GetShardDownloader(bc.ShardID()); d != nil {
+			if sp := d.SyncProtocol(); sp != nil {
+				stateSyncProtocol = sp
+			}
+		}
+	}
+
 	if s, err := stagedsync.CreateStagedSync(node.SelfPeer.IP, mutatedPort,
 		node.GetSyncID(), bc, role, isExplorer,
 		node.NodeConfig.StagedSyncTurboMode,
 		node.NodeConfig.UseMemDB,
+		node.NodeConfig.StagedSyncStateSync && !node.NodeConfig.GetArchival(),
+		stateSyncProtocol,
+		node.Beaconchain(),
 		node.NodeConfig.DoubleCheckBlockHashes,
 		node.NodeConfig.MaxBlocksPerSyncCycle,
 		node.NodeConfig.MaxBackgroundBlocks,
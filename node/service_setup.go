@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/harmony-one/harmony/api/service"
 	"github.com/harmony-one/harmony/api/service/blockproposal"
@@ -23,6 +24,13 @@ func (node *Node) RegisterValidatorServices() {
 	)
 }
 
+// RegisterDevServices registers the block sealer of a dev mode node, which
+// takes the place of the validator services.
+func (node *Node) RegisterDevServices(period time.Duration) {
+	node.DevSealer = NewDevSealer(node, period)
+	node.serviceManager.Register(service.DevSealer, node.DevSealer)
+}
+
 // RegisterExplorerServices register the explorer services
 func (node *Node) RegisterExplorerServices() {
 	// Register explorer service.
@@ -10,6 +10,7 @@ import (
 	"github.com/harmony-one/harmony/consensus/reward"
 
 	"github.com/harmony-one/harmony/consensus"
+	"github.com/harmony-one/harmony/consensus/misc"
 
 	"github.com/harmony-one/harmony/crypto/bls"
 
@@ -62,6 +63,8 @@ type Worker struct {
 	engine   consensus_engine.Engine
 	gasFloor uint64
 	gasCeil  uint64
+	// timeOffset shifts the timestamps of the proposed blocks, in seconds
+	timeOffset int64
 }
 
 // CommitSortedTransactions commits transactions for new block.
@@ -107,6 +110,16 @@ func (w *Worker) CommitSortedTransactions(
 			continue
 		}
 
+		// Skip the account if the fee cap cannot cover the base fee of the block
+		if baseFee := w.current.header.BaseFee(); baseFee != nil && tx.GasFeeCap().Cmp(baseFee) < 0 {
+			utils.Logger().Info().Str("hash", tx.Hash().Hex()).
+				Str("gasFeeCap", tx.GasFeeCap().String()).
+				Str("baseFee", baseFee.String()).
+				Msg("Skipping transaction with fee cap below base fee")
+			txs.Pop()
+			continue
+		}
+
 		// Start executing the transaction
 		w.current.state.Prepare(tx.Hash(), common.Hash{}, len(w.current.txs))
 		err := w.commitTransaction(tx, coinbase)
@@ -169,6 +182,14 @@ func (w *Worker) CommitTransactions(
 				utils.Logger().Info().Str("hash", tx.Hash().Hex()).Str("eip155Epoch", w.config.EIP155Epoch.String()).Msg("Ignoring reply protected transaction")
 				continue
 			}
+			// Skip the transaction if its gas price cannot cover the base fee of the block
+			if baseFee := w.current.header.BaseFee(); baseFee != nil && tx.GasPrice().Cmp(baseFee) < 0 {
+				utils.Logger().Info().Str("hash", tx.Hash().Hex()).
+					Str("gasPrice", tx.GasPrice().String()).
+					Str("baseFee", baseFee.String()).
+					Msg("Skipping staking transaction with gas price below base fee")
+				continue
+			}
 
 			// Start executing the transaction
 			w.current.state.Prepare(tx.Hash(), common.Hash{}, len(w.current.txs)+len(w.current.stakingTxs))
@@ -302,7 +323,7 @@ func (w *Worker) CommitReceipts(receiptsList []*types.CXReceiptsProof) error {
 func (w *Worker) UpdateCurrent() error {
 	parent := w.chain.CurrentBlock()
 	num := parent.Number()
-	timestamp := time.Now().Unix()
+	timestamp := time.Now().Unix() + w.timeOffset
 
 	epoch := w.GetNewEpoch()
 	header := w.factory.NewHeader(epoch).With().
@@ -315,6 +336,12 @@ func (w *Worker) UpdateCurrent() error {
 	return w.makeCurrent(parent, header)
 }
 
+// SetTimeOffset shifts the timestamps of the next proposed blocks from the
+// current time by the offset in seconds.
+func (w *Worker) SetTimeOffset(offset int64) {
+	w.timeOffset = offset
+}
+
 // GetCurrentHeader returns the current header to propose
 func (w *Worker) GetCurrentHeader() *block.Header {
 	return w.current.header
@@ -327,11 +354,18 @@ func (w *Worker) makeCurrent(parent *types.Block, header *block.Header) error {
 		return err
 	}
 	env := &environment{
-		signer:    types.NewEIP155Signer(w.config.ChainID),
-		ethSigner: types.NewEIP155Signer(w.config.EthCompatibleChainID),
+		signer:    types.NewEIP155SignerWithEthChainID(w.config.ChainID, w.config.EthCompatibleChainID),
+		ethSigner: types.MakeEthSigner(w.config, header.Epoch()),
 		state:     state,
 		header:    header,
 	}
+	switch {
+	case w.config.IsLondon(header.Epoch()):
+		env.signer = types.MakeSigner(w.config, header.Epoch())
+		header.SetBaseFee(misc.CalcBaseFee(w.config, parent.Header()))
+	case w.config.IsBerlin(header.Epoch()):
+		env.signer = types.MakeSigner(w.config, header.Epoch())
+	}
 
 	w.current = env
 	return nil
@@ -1,12 +1,19 @@
 package sync
 
 import (
+	"bytes"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/block"
-	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
 	"github.com/harmony-one/harmony/internal/utils/keylocker"
+	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
 	"github.com/pkg/errors"
 )
 
@@ -16,15 +23,19 @@ type chainHelper interface {
 	getBlockHashes(bns []uint64) []common.Hash
 	getBlocksByNumber(bns []uint64) ([]*types.Block, error)
 	getBlocksByHashes(hs []common.Hash) ([]*types.Block, error)
+	getReceipts(hs []common.Hash) ([]types.Receipts, error)
+	getNodeData(hs []common.Hash) ([][]byte, error)
+	getAccountRange(root common.Hash, origin common.Hash, limit common.Hash, bytes uint64) ([]*syncpb.AccountData, [][]byte, error)
+	getStorageRanges(root common.Hash, accounts []common.Hash, origin common.Hash, limit common.Hash, bytes uint64) ([]*syncpb.StoragesData, [][]byte, error)
 }
 
 type chainHelperImpl struct {
-	chain     engine.ChainReader
+	chain     core.BlockChain
 	schedule  shardingconfig.Schedule
 	keyLocker *keylocker.KeyLocker
 }
 
-func newChainHelper(chain engine.ChainReader, schedule shardingconfig.Schedule) *chainHelperImpl {
+func newChainHelper(chain core.BlockChain, schedule shardingconfig.Schedule) *chainHelperImpl {
 	return &chainHelperImpl{
 		chain:     chain,
 		schedule:  schedule,
@@ -139,3 +150,247 @@ func (ch *chainHelperImpl) getBlockSigFromNextBlock(header *block.Header) []byte
 func (ch *chainHelperImpl) getBlockSigFromDB(header *block.Header) ([]byte, error) {
 	return ch.chain.ReadCommitSig(header.Number().Uint64())
 }
+
+func (ch *chainHelperImpl) getReceipts(hs []common.Hash) ([]types.Receipts, error) {
+	receipts := make([]types.Receipts, 0, len(hs))
+	for _, h := range hs {
+		if ch.chain.GetHeaderByHash(h) == nil {
+			return nil, errors.Errorf("unknown block %v", h.String())
+		}
+		receipts = append(receipts, ch.chain.GetReceiptsByHash(h))
+	}
+	return receipts, nil
+}
+
+// getNodeData returns the state trie nodes and contract codes of the given hashes,
+// with an empty entry for each unknown hash. The result is truncated once it
+// exceeds the soft response limit.
+func (ch *chainHelperImpl) getNodeData(hs []common.Hash) ([][]byte, error) {
+	var (
+		data = make([][]byte, 0, len(hs))
+		size int
+	)
+	for _, h := range hs {
+		entry, err := ch.chain.TrieNode(h)
+		if err != nil {
+			entry = nil
+		}
+		data = append(data, entry)
+
+		if size += len(entry); size >= SoftResponseLimit {
+			break
+		}
+	}
+	return data, nil
+}
+
+// getAccountRange returns the accounts of the state trie at root from origin, up
+// to and including the first account beyond limit, until the given number of
+// bytes is reached. The returned proof holds the trie nodes proving the first and
+// last keys of the range.
+func (ch *chainHelperImpl) getAccountRange(root common.Hash, origin common.Hash, limit common.Hash, responseBytes uint64) ([]*syncpb.AccountData, [][]byte, error) {
+	if responseBytes > SoftResponseLimit {
+		responseBytes = SoftResponseLimit
+	}
+	tr, err := trie.New(root, ch.chain.StateCache().TrieDB())
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "open state trie %v", root.String())
+	}
+
+	var (
+		accounts []*syncpb.AccountData
+		last     []byte
+		size     uint64
+		it       = ch.accountIterator(tr, root, origin)
+	)
+	defer it.Release()
+	for it.Next() {
+		hash := it.Hash().Bytes()
+		value := common.CopyBytes(it.Value())
+		accounts = append(accounts, &syncpb.AccountData{
+			Hash: hash,
+			Body: value,
+		})
+		last = hash
+
+		size += uint64(common.HashLength + len(value))
+		if size >= responseBytes || bytes.Compare(hash, limit[:]) >= 0 {
+			break
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	var proof proofList
+	if err := tr.Prove(origin[:], 0, &proof); err != nil {
+		return nil, nil, err
+	}
+	if last != nil {
+		if err := tr.Prove(last, 0, &proof); err != nil {
+			return nil, nil, err
+		}
+	}
+	return accounts, proof, nil
+}
+
+// getStorageRanges returns the storage slots of the given accounts of the state
+// trie at root, until the given number of bytes is reached. The origin applies to
+// the first account and the limit to the last one. If the storage of an account
+// is only partially returned, no more accounts are served and the returned proof
+// holds the trie nodes proving the boundaries of its range.
+func (ch *chainHelperImpl) getStorageRanges(root common.Hash, accounts []common.Hash, origin common.Hash, limit common.Hash, responseBytes uint64) ([]*syncpb.StoragesData, [][]byte, error) {
+	if responseBytes > SoftResponseLimit {
+		responseBytes = SoftResponseLimit
+	}
+	triedb := ch.chain.StateCache().TrieDB()
+	accTrie, err := trie.New(root, triedb)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "open state trie %v", root.String())
+	}
+
+	var (
+		slots []*syncpb.StoragesData
+		proof proofList
+		size  uint64
+	)
+	for i, account := range accounts {
+		if size >= responseBytes {
+			break
+		}
+		blob, err := accTrie.TryGet(account[:])
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(blob) == 0 {
+			// unknown account without storage
+			slots = append(slots, &syncpb.StoragesData{})
+			continue
+		}
+		var acc state.Account
+		if err := rlp.DecodeBytes(blob, &acc); err != nil {
+			return nil, nil, errors.Wrapf(err, "decode account %x", account)
+		}
+		stTrie, err := trie.New(acc.Root, triedb)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "open storage trie of account %x", account)
+		}
+
+		var (
+			start   common.Hash
+			storage []*syncpb.StorageData
+			last    []byte
+		)
+		if i == 0 {
+			start = origin
+		}
+		partial := start != (common.Hash{})
+		it := ch.storageIterator(stTrie, root, account, start)
+		for it.Next() {
+			if size >= responseBytes {
+				partial = true
+				break
+			}
+			hash := it.Hash().Bytes()
+			value := common.CopyBytes(it.Value())
+			storage = append(storage, &syncpb.StorageData{
+				Hash: hash,
+				Body: value,
+			})
+			last = hash
+
+			size += uint64(common.HashLength + len(value))
+			if i == len(accounts)-1 && bytes.Compare(hash, limit[:]) >= 0 {
+				partial = true
+				break
+			}
+		}
+		it.Release()
+		if err := it.Error(); err != nil {
+			return nil, nil, err
+		}
+		slots = append(slots, &syncpb.StoragesData{Data: storage})
+
+		if partial {
+			if err := stTrie.Prove(start[:], 0, &proof); err != nil {
+				return nil, nil, err
+			}
+			if last != nil {
+				if err := stTrie.Prove(last, 0, &proof); err != nil {
+					return nil, nil, err
+				}
+			}
+			break
+		}
+	}
+	return slots, proof, nil
+}
+
+// stateIterator iterates over the leaves of a state trie in the key order.
+type stateIterator interface {
+	Next() bool
+	Hash() common.Hash
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// accountIterator iterates over the accounts of the state at root from origin,
+// reading the flat state snapshot if available and falling back to the trie.
+func (ch *chainHelperImpl) accountIterator(tr *trie.Trie, root, origin common.Hash) stateIterator {
+	if snaps := ch.chain.StateCache().Snapshots(); snaps != nil {
+		if it, err := snaps.AccountIterator(root, origin); err == nil {
+			return snapAccountIterator{it}
+		}
+	}
+	return &trieIterator{it: trie.NewIterator(tr.NodeIterator(origin[:]))}
+}
+
+// storageIterator iterates over the storage slots of the account of the state at
+// root from origin, reading the flat state snapshot if available and falling back
+// to the storage trie.
+func (ch *chainHelperImpl) storageIterator(tr *trie.Trie, root, account, origin common.Hash) stateIterator {
+	if snaps := ch.chain.StateCache().Snapshots(); snaps != nil {
+		if it, err := snaps.StorageIterator(root, account, origin); err == nil {
+			return snapStorageIterator{it}
+		}
+	}
+	return &trieIterator{it: trie.NewIterator(tr.NodeIterator(origin[:]))}
+}
+
+// trieIterator is the stateIterator over the leaves of a trie.
+type trieIterator struct {
+	it *trie.Iterator
+}
+
+func (it *trieIterator) Next() bool        { return it.it.Next() }
+func (it *trieIterator) Hash() common.Hash { return common.BytesToHash(it.it.Key) }
+func (it *trieIterator) Value() []byte     { return it.it.Value }
+func (it *trieIterator) Error() error      { return it.it.Err }
+func (it *trieIterator) Release()          {}
+
+// snapAccountIterator is the stateIterator over the accounts of a snapshot.
+type snapAccountIterator struct {
+	snapshot.AccountIterator
+}
+
+func (it snapAccountIterator) Value() []byte { return it.Account() }
+
+// snapStorageIterator is the stateIterator over the storage slots of a snapshot.
+type snapStorageIterator struct {
+	snapshot.StorageIterator
+}
+
+func (it snapStorageIterator) Value() []byte { return it.Slot() }
+
+// proofList collects the trie nodes of merkle proofs.
+type proofList [][]byte
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	return errors.New("not supported")
+}
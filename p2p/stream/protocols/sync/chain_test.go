@@ -46,6 +46,40 @@ func (tch *testChainHelper) getBlocksByHashes(hs []common.Hash) ([]*types.Block,
 	return bs, nil
 }
 
+func (tch *testChainHelper) getReceipts(hs []common.Hash) ([]types.Receipts, error) {
+	receipts := make([]types.Receipts, 0, len(hs))
+	for _, h := range hs {
+		receipts = append(receipts, makeTestReceipts(hashToNumber(h)))
+	}
+	return receipts, nil
+}
+
+func (tch *testChainHelper) getNodeData(hs []common.Hash) ([][]byte, error) {
+	data := make([][]byte, 0, len(hs))
+	for _, h := range hs {
+		data = append(data, makeTestNodeData(h))
+	}
+	return data, nil
+}
+
+func (tch *testChainHelper) getAccountRange(root common.Hash, origin common.Hash, limit common.Hash, bytes uint64) ([]*syncpb.AccountData, [][]byte, error) {
+	accounts := []*syncpb.AccountData{
+		{Hash: origin[:], Body: root[:]},
+		{Hash: limit[:], Body: root[:]},
+	}
+	return accounts, [][]byte{root[:]}, nil
+}
+
+func (tch *testChainHelper) getStorageRanges(root common.Hash, accounts []common.Hash, origin common.Hash, limit common.Hash, bytes uint64) ([]*syncpb.StoragesData, [][]byte, error) {
+	slots := make([]*syncpb.StoragesData, 0, len(accounts))
+	for _, acc := range accounts {
+		slots = append(slots, &syncpb.StoragesData{
+			Data: []*syncpb.StorageData{{Hash: common.CopyBytes(acc[:]), Body: root[:]}},
+		})
+	}
+	return slots, [][]byte{root[:]}, nil
+}
+
 func numberToHash(bn uint64) common.Hash {
 	var h common.Hash
 	binary.LittleEndian.PutUint64(h[:], bn)
@@ -84,6 +118,20 @@ func checkBlocksResult(bns []uint64, b []byte) error {
 	return nil
 }
 
+func makeTestReceipts(bn uint64) types.Receipts {
+	return types.Receipts{
+		&types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: bn,
+			Logs:              []*types.Log{},
+		},
+	}
+}
+
+func makeTestNodeData(h common.Hash) []byte {
+	return append([]byte{0x80}, h[:]...)
+}
+
 func makeTestBlock(bn uint64) *types.Block {
 	header := testHeader.Copy()
 	header.SetNumber(big.NewInt(int64(bn)))
@@ -164,3 +212,89 @@ func checkBlocksByHashesResult(b []byte, hs []common.Hash) error {
 	}
 	return nil
 }
+
+func checkReceiptsResult(b []byte, hs []common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	grResp, err := msg.GetReceiptsResponse()
+	if err != nil {
+		return err
+	}
+	if len(hs) != len(grResp.ReceiptsBytes) {
+		return errors.New("unexpected size")
+	}
+	for i, h := range hs {
+		var receipts types.Receipts
+		if err := rlp.DecodeBytes(grResp.ReceiptsBytes[i], &receipts); err != nil {
+			return err
+		}
+		if len(receipts) != 1 {
+			return fmt.Errorf("unexpected receipts number %v", len(receipts))
+		}
+		if receipts[0].CumulativeGasUsed != hashToNumber(h) {
+			return fmt.Errorf("unexpected receipt gas %v != %v", receipts[0].CumulativeGasUsed, hashToNumber(h))
+		}
+	}
+	return nil
+}
+
+func checkNodeDataResult(b []byte, hs []common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	ndResp, err := msg.GetNodeDataResponse()
+	if err != nil {
+		return err
+	}
+	if len(hs) != len(ndResp.DataBytes) {
+		return errors.New("unexpected size")
+	}
+	for i, h := range hs {
+		if !bytes.Equal(ndResp.DataBytes[i], makeTestNodeData(h)) {
+			return errors.New("unexpected node data")
+		}
+	}
+	return nil
+}
+
+func checkAccountRangeResult(b []byte, origin common.Hash, limit common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	arResp, err := msg.GetAccountRangeResponse()
+	if err != nil {
+		return err
+	}
+	if len(arResp.Accounts) != 2 || len(arResp.Proof) != 1 {
+		return errors.New("unexpected size")
+	}
+	if !bytes.Equal(arResp.Accounts[0].Hash, origin[:]) || !bytes.Equal(arResp.Accounts[1].Hash, limit[:]) {
+		return errors.New("unexpected account range")
+	}
+	return nil
+}
+
+func checkStorageRangesResult(b []byte, accounts []common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	srResp, err := msg.GetStorageRangesResponse()
+	if err != nil {
+		return err
+	}
+	if len(accounts) != len(srResp.Slots) {
+		return errors.New("unexpected size")
+	}
+	for i, acc := range accounts {
+		data := srResp.Slots[i].Data
+		if len(data) != 1 || !bytes.Equal(data[0].Hash, acc[:]) {
+			return errors.New("unexpected storage ranges")
+		}
+	}
+	return nil
+}
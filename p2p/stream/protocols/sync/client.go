@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	protobuf "github.com/golang/protobuf/proto"
 	"github.com/harmony-one/harmony/core/types"
@@ -105,6 +106,128 @@ func (p *Protocol) GetBlocksByHashes(ctx context.Context, hs []common.Hash, opts
 	return
 }
 
+// GetReceipts do getReceiptsRequest through sync stream protocol.
+// Return the receipts of the blocks of the given hashes. The request fails if any
+// of the blocks is unknown to the remote node.
+func (p *Protocol) GetReceipts(ctx context.Context, hs []common.Hash, opts ...Option) (receipts []types.Receipts, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getReceipts")
+	defer p.doMetricPostClientRequest("getReceipts", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero receipt hashes requested")
+		return
+	}
+	if len(hs) > GetReceiptsCap {
+		err = fmt.Errorf("number of requested hashes exceed limit")
+		return
+	}
+	req := newGetReceiptsRequest(hs)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	receipts, err = req.getReceiptsFromResponse(resp)
+	return
+}
+
+// GetNodeData do getNodeDataRequest through sync stream protocol.
+// Return the state trie nodes or contract codes of the given hashes. An unknown
+// hash has an empty entry, and the result might be truncated by the remote node.
+// The stream is removed if any entry does not match its hash.
+func (p *Protocol) GetNodeData(ctx context.Context, hs []common.Hash, opts ...Option) (data [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getNodeData")
+	defer p.doMetricPostClientRequest("getNodeData", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero node data hashes requested")
+		return
+	}
+	if len(hs) > GetNodeDataCap {
+		err = fmt.Errorf("number of requested hashes exceed limit")
+		return
+	}
+	req := newGetNodeDataRequest(hs)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	data, err = req.getNodeDataFromResponse(resp)
+	if errors.Is(err, errInvalidResponse) {
+		p.RemoveStream(stid)
+	}
+	return
+}
+
+// GetAccountRange do getAccountRangeRequest through sync stream protocol.
+// Return the accounts of the state trie at root from origin to limit, up to about
+// the given number of bytes, and the proof of the range. The stream is removed if
+// the range does not verify against root.
+func (p *Protocol) GetAccountRange(ctx context.Context, root common.Hash, origin common.Hash, limit common.Hash, bytes uint64, opts ...Option) (accounts []*syncpb.AccountData, proof [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getAccountRange")
+	defer p.doMetricPostClientRequest("getAccountRange", err, timer)
+
+	if bytes == 0 {
+		err = fmt.Errorf("zero account ranges bytes requested")
+		return
+	}
+	if bytes > SoftResponseLimit {
+		err = fmt.Errorf("requested bytes exceed limit")
+		return
+	}
+	req := newGetAccountRangeRequest(root, origin, limit, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	accounts, proof, err = req.getAccountRangeFromResponse(resp)
+	if errors.Is(err, errInvalidResponse) {
+		p.RemoveStream(stid)
+	}
+	return
+}
+
+// GetStorageRanges do getStorageRangesRequest through sync stream protocol.
+// Return the storage slots of the given accounts of the state trie at root, from
+// origin for the first account to limit for the last one, up to about the given
+// number of bytes, and the proof of the range of the last account if partial.
+// The storage roots of the accounts are needed to verify the slots, and the
+// stream is removed if they do not verify.
+func (p *Protocol) GetStorageRanges(ctx context.Context, root common.Hash, accounts []common.Hash, roots []common.Hash, origin common.Hash, limit common.Hash, bytes uint64, opts ...Option) (slots []*syncpb.StoragesData, proof [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getStorageRanges")
+	defer p.doMetricPostClientRequest("getStorageRanges", err, timer)
+
+	if len(accounts) == 0 {
+		err = fmt.Errorf("zero accounts requested")
+		return
+	}
+	if len(accounts) > GetStorageRangesAccountsCap {
+		err = fmt.Errorf("number of requested accounts exceed limit")
+		return
+	}
+	if len(roots) != len(accounts) {
+		err = fmt.Errorf("storage roots size not expected: %v / %v", len(roots), len(accounts))
+		return
+	}
+	if bytes == 0 {
+		err = fmt.Errorf("zero storage ranges bytes requested")
+		return
+	}
+	if bytes > SoftResponseLimit {
+		err = fmt.Errorf("requested bytes exceed limit")
+		return
+	}
+	req := newGetStorageRangesRequest(root, accounts, roots, origin, limit, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	slots, proof, err = req.getStorageRangesFromResponse(resp)
+	if errors.Is(err, errInvalidResponse) {
+		p.RemoveStream(stid)
+	}
+	return
+}
+
 // getBlocksByNumberRequest is the request for get block by numbers which implements
 // sttypes.Request interface
 type getBlocksByNumberRequest struct {
@@ -357,3 +480,291 @@ func (req *getBlocksByHashesRequest) getBlocksFromResponse(resp sttypes.Response
 	}
 	return blocks, nil
 }
+
+type getReceiptsRequest struct {
+	hashes []common.Hash
+	pbReq  *syncpb.Request
+}
+
+func newGetReceiptsRequest(hashes []common.Hash) *getReceiptsRequest {
+	pbReq := syncpb.MakeGetReceiptsRequest(hashes)
+	return &getReceiptsRequest{
+		hashes: hashes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getReceiptsRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getReceiptsRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getReceiptsRequest) String() string {
+	hashStrs := make([]string, 0, len(req.hashes))
+	for _, h := range req.hashes {
+		hashStrs = append(hashStrs, fmt.Sprintf("%x", h[:]))
+	}
+	hStr := strings.Join(hashStrs, ", ")
+	return fmt.Sprintf("REQUEST [GetReceipts: %v]", hStr)
+}
+
+func (req *getReceiptsRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getReceiptsRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getReceiptsRequest) getReceiptsFromResponse(resp sttypes.Response) ([]types.Receipts, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	grResp := sResp.pb.GetGetReceiptsResponse()
+	if grResp == nil {
+		return nil, errors.New("response not GetReceipts")
+	}
+	if len(grResp.ReceiptsBytes) != len(req.hashes) {
+		return nil, fmt.Errorf("receipts size not expected: %v / %v",
+			len(grResp.ReceiptsBytes), len(req.hashes))
+	}
+	receipts := make([]types.Receipts, 0, len(grResp.ReceiptsBytes))
+	for _, rb := range grResp.ReceiptsBytes {
+		var rs types.Receipts
+		if err := rlp.DecodeBytes(rb, &rs); err != nil {
+			return nil, errors.Wrap(err, "[GetReceiptsResponse]")
+		}
+		receipts = append(receipts, rs)
+	}
+	return receipts, nil
+}
+
+type getNodeDataRequest struct {
+	hashes []common.Hash
+	pbReq  *syncpb.Request
+}
+
+func newGetNodeDataRequest(hashes []common.Hash) *getNodeDataRequest {
+	pbReq := syncpb.MakeGetNodeDataRequest(hashes)
+	return &getNodeDataRequest{
+		hashes: hashes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getNodeDataRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getNodeDataRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getNodeDataRequest) String() string {
+	hashStrs := make([]string, 0, len(req.hashes))
+	for _, h := range req.hashes {
+		hashStrs = append(hashStrs, fmt.Sprintf("%x", h[:]))
+	}
+	hStr := strings.Join(hashStrs, ", ")
+	return fmt.Sprintf("REQUEST [GetNodeData: %v]", hStr)
+}
+
+func (req *getNodeDataRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getNodeDataRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getNodeDataRequest) getNodeDataFromResponse(resp sttypes.Response) ([][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	ndResp := sResp.pb.GetGetNodeDataResponse()
+	if ndResp == nil {
+		return nil, errors.New("response not GetNodeData")
+	}
+	if len(ndResp.DataBytes) > len(req.hashes) {
+		return nil, fmt.Errorf("node data size not expected: %v / %v",
+			len(ndResp.DataBytes), len(req.hashes))
+	}
+	for i, data := range ndResp.DataBytes {
+		if len(data) == 0 {
+			continue
+		}
+		if h := crypto.Keccak256Hash(data); h != req.hashes[i] {
+			return nil, errors.Wrapf(errInvalidResponse, "node data %x of hash %x", req.hashes[i], h)
+		}
+	}
+	return ndResp.DataBytes, nil
+}
+
+type getAccountRangeRequest struct {
+	root   common.Hash
+	origin common.Hash
+	limit  common.Hash
+	bytes  uint64
+	pbReq  *syncpb.Request
+}
+
+func newGetAccountRangeRequest(root common.Hash, origin common.Hash, limit common.Hash, bytes uint64) *getAccountRangeRequest {
+	pbReq := syncpb.MakeGetAccountRangeRequest(root, origin, limit, bytes)
+	return &getAccountRangeRequest{
+		root:   root,
+		origin: origin,
+		limit:  limit,
+		bytes:  bytes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getAccountRangeRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getAccountRangeRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getAccountRangeRequest) String() string {
+	return fmt.Sprintf("REQUEST [GetAccountRange: root %x, origin %x, limit %x, bytes %v]",
+		req.root[:], req.origin[:], req.limit[:], req.bytes)
+}
+
+func (req *getAccountRangeRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getAccountRangeRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getAccountRangeRequest) getAccountRangeFromResponse(resp sttypes.Response) ([]*syncpb.AccountData, [][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, errors.New(errResp.Error)
+	}
+	arResp := sResp.pb.GetGetAccountRangeResponse()
+	if arResp == nil {
+		return nil, nil, errors.New("response not GetAccountRange")
+	}
+	keys := make([][]byte, 0, len(arResp.Accounts))
+	values := make([][]byte, 0, len(arResp.Accounts))
+	for _, acc := range arResp.Accounts {
+		keys = append(keys, acc.Hash)
+		values = append(values, acc.Body)
+	}
+	if err := verifyRange(req.root, req.origin, keys, values, arResp.Proof); err != nil {
+		return nil, nil, errors.Wrapf(errInvalidResponse, "account range: %v", err)
+	}
+	return arResp.Accounts, arResp.Proof, nil
+}
+
+type getStorageRangesRequest struct {
+	root     common.Hash
+	accounts []common.Hash
+	roots    []common.Hash
+	origin   common.Hash
+	limit    common.Hash
+	bytes    uint64
+	pbReq    *syncpb.Request
+}
+
+func newGetStorageRangesRequest(root common.Hash, accounts []common.Hash, roots []common.Hash, origin common.Hash, limit common.Hash, bytes uint64) *getStorageRangesRequest {
+	pbReq := syncpb.MakeGetStorageRangesRequest(root, accounts, origin, limit, bytes)
+	return &getStorageRangesRequest{
+		root:     root,
+		accounts: accounts,
+		roots:    roots,
+		origin:   origin,
+		limit:    limit,
+		bytes:    bytes,
+		pbReq:    pbReq,
+	}
+}
+
+func (req *getStorageRangesRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getStorageRangesRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getStorageRangesRequest) String() string {
+	accStrs := make([]string, 0, len(req.accounts))
+	for _, h := range req.accounts {
+		accStrs = append(accStrs, fmt.Sprintf("%x", h[:]))
+	}
+	accStr := strings.Join(accStrs, ", ")
+	return fmt.Sprintf("REQUEST [GetStorageRanges: root %x, accounts %v, origin %x, limit %x, bytes %v]",
+		req.root[:], accStr, req.origin[:], req.limit[:], req.bytes)
+}
+
+func (req *getStorageRangesRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getStorageRangesRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getStorageRangesRequest) getStorageRangesFromResponse(resp sttypes.Response) ([]*syncpb.StoragesData, [][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, errors.New(errResp.Error)
+	}
+	srResp := sResp.pb.GetGetStorageRangesResponse()
+	if srResp == nil {
+		return nil, nil, errors.New("response not GetStorageRanges")
+	}
+	if len(srResp.Slots) > len(req.accounts) {
+		return nil, nil, fmt.Errorf("storage ranges size not expected: %v / %v",
+			len(srResp.Slots), len(req.accounts))
+	}
+	for i, storage := range srResp.Slots {
+		var (
+			origin common.Hash
+			proof  [][]byte
+		)
+		if i == 0 {
+			origin = req.origin
+		}
+		// Only the range of the last account is proven, if partial
+		if i == len(srResp.Slots)-1 {
+			proof = srResp.Proof
+		}
+		keys := make([][]byte, 0, len(storage.GetData()))
+		values := make([][]byte, 0, len(storage.GetData()))
+		for _, slot := range storage.GetData() {
+			keys = append(keys, slot.Hash)
+			values = append(values, slot.Body)
+		}
+		if err := verifyRange(req.roots[i], origin, keys, values, proof); err != nil {
+			return nil, nil, errors.Wrapf(errInvalidResponse, "storage range of account %x: %v", req.accounts[i], err)
+		}
+	}
+	return srResp.Slots, srResp.Proof, nil
+}
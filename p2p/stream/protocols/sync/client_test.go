@@ -1,15 +1,20 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/block"
 	headerV3 "github.com/harmony-one/harmony/block/v3"
 	"github.com/harmony-one/harmony/core/types"
@@ -22,6 +27,10 @@ import (
 var (
 	_ sttypes.Request  = &getBlocksByNumberRequest{}
 	_ sttypes.Request  = &getBlockNumberRequest{}
+	_ sttypes.Request  = &getReceiptsRequest{}
+	_ sttypes.Request  = &getNodeDataRequest{}
+	_ sttypes.Request  = &getAccountRangeRequest{}
+	_ sttypes.Request  = &getStorageRangesRequest{}
 	_ sttypes.Response = &syncResponse{&syncpb.Response{}}
 )
 
@@ -49,6 +58,14 @@ var (
 
 	testBlocksByHashesResponse = syncpb.MakeGetBlocksByHashesResponse(0, [][]byte{testBlockBytes}, make([][]byte, 1))
 
+	testReceiptsBytes, _ = rlp.EncodeToBytes(makeTestReceipts(100))
+	testReceiptsResponse = syncpb.MakeGetReceiptsResponse(0, [][]byte{testReceiptsBytes})
+
+	testNodeData            = makeTestNodeData(testHash)
+	testNodeDataHash        = crypto.Keccak256Hash(testNodeData)
+	testNodeDataResponse    = syncpb.MakeGetNodeDataResponse(0, [][]byte{testNodeData})
+	testBadNodeDataResponse = syncpb.MakeGetNodeDataResponse(0, [][]byte{testHash[:]})
+
 	testErrorResponse = syncpb.MakeErrorResponse(0, errors.New("test error"))
 )
 
@@ -295,6 +312,280 @@ type testHostRequestManager struct {
 	getResponse getResponseFn
 }
 
+func TestProtocol_GetReceipts(t *testing.T) {
+	tests := []struct {
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testReceiptsResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlockResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("response not GetReceipts"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: nil,
+			expErr:      errors.New("get response error"),
+			expStID:     "",
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testErrorResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("test error"),
+			expStID: makeTestStreamID(0),
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		res, stid, err := protocol.GetReceipts(context.Background(), []common.Hash{testHash})
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(res) != 1 || len(res[0]) != 1 || res[0][0].CumulativeGasUsed != 100 {
+				t.Errorf("Test %v: receipts not expected", i)
+			}
+		}
+	}
+}
+
+func TestProtocol_GetNodeData(t *testing.T) {
+	tests := []struct {
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testNodeDataResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlockResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("response not GetNodeData"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBadNodeDataResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errInvalidResponse,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: nil,
+			expErr:      errors.New("get response error"),
+			expStID:     "",
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testErrorResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("test error"),
+			expStID: makeTestStreamID(0),
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		res, stid, err := protocol.GetNodeData(context.Background(), []common.Hash{testNodeDataHash})
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(res) != 1 || !bytes.Equal(res[0], testNodeData) {
+				t.Errorf("Test %v: node data not expected", i)
+			}
+		}
+	}
+}
+
+func TestProtocol_GetAccountRange(t *testing.T) {
+	tr, keys, values := makeTestTrie(16)
+	root := tr.Hash()
+
+	tests := []struct {
+		origin  common.Hash
+		from    int
+		to      int
+		proofs  [][]byte
+		tamper  func(accounts []*syncpb.AccountData)
+		expErr  error
+		expSize int
+	}{
+		{
+			// whole trie with proof
+			from:    0,
+			to:      16,
+			proofs:  [][]byte{{}, keys[15]},
+			expSize: 16,
+		},
+		{
+			// partial range with proof
+			origin:  common.BytesToHash(keys[4]),
+			from:    4,
+			to:      9,
+			proofs:  [][]byte{keys[4], keys[8]},
+			expSize: 5,
+		},
+		{
+			// altered value
+			from:   0,
+			to:     16,
+			proofs: [][]byte{{}, keys[15]},
+			tamper: func(accounts []*syncpb.AccountData) {
+				accounts[15].Body = []byte{0x01}
+			},
+			expErr: errInvalidResponse,
+		},
+		{
+			// keys out of order
+			from:   0,
+			to:     16,
+			proofs: [][]byte{{}, keys[15]},
+			tamper: func(accounts []*syncpb.AccountData) {
+				accounts[2], accounts[3] = accounts[3], accounts[2]
+			},
+			expErr: errInvalidResponse,
+		},
+		{
+			// partial range without proof
+			origin: common.BytesToHash(keys[4]),
+			from:   4,
+			to:     9,
+			expErr: errInvalidResponse,
+		},
+	}
+
+	for i, test := range tests {
+		var accounts []*syncpb.AccountData
+		for j := test.from; j < test.to; j++ {
+			accounts = append(accounts, &syncpb.AccountData{Hash: keys[j], Body: values[j]})
+		}
+		if test.tamper != nil {
+			test.tamper(accounts)
+		}
+		proof := makeTestProof(tr, test.proofs...)
+
+		protocol := makeTestProtocol(func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+			return &syncResponse{
+				pb: syncpb.MakeGetAccountRangeResponse(0, accounts, proof),
+			}, makeTestStreamID(0)
+		})
+		res, _, _, err := protocol.GetAccountRange(context.Background(), root, test.origin, common.Hash{}, SoftResponseLimit)
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if test.expErr == nil && len(res) != test.expSize {
+			t.Errorf("Test %v: unexpected accounts size: %v / %v", i, len(res), test.expSize)
+		}
+	}
+}
+
+func TestProtocol_GetStorageRanges(t *testing.T) {
+	full, fullKeys, fullValues := makeTestTrie(8)
+	part, partKeys, partValues := makeTestTrie(16)
+	accounts := []common.Hash{numberToHash(1), numberToHash(2)}
+	roots := []common.Hash{full.Hash(), part.Hash()}
+
+	makeSlots := func(keys, values [][]byte) *syncpb.StoragesData {
+		storage := &syncpb.StoragesData{}
+		for i := range keys {
+			storage.Data = append(storage.Data, &syncpb.StorageData{Hash: keys[i], Body: values[i]})
+		}
+		return storage
+	}
+
+	tests := []struct {
+		slots  []*syncpb.StoragesData
+		proof  [][]byte
+		expErr error
+	}{
+		{
+			// complete first account, partial last one
+			slots: []*syncpb.StoragesData{
+				makeSlots(fullKeys, fullValues),
+				makeSlots(partKeys[:6], partValues[:6]),
+			},
+			proof: makeTestProof(part, []byte{}, partKeys[5]),
+		},
+		{
+			// missing slot of the complete account
+			slots: []*syncpb.StoragesData{
+				makeSlots(fullKeys[1:], fullValues[1:]),
+				makeSlots(partKeys[:6], partValues[:6]),
+			},
+			proof:  makeTestProof(part, []byte{}, partKeys[5]),
+			expErr: errInvalidResponse,
+		},
+		{
+			// partial last account without proof
+			slots: []*syncpb.StoragesData{
+				makeSlots(fullKeys, fullValues),
+				makeSlots(partKeys[:6], partValues[:6]),
+			},
+			expErr: errInvalidResponse,
+		},
+	}
+
+	for i, test := range tests {
+		slots, proof := test.slots, test.proof
+		protocol := makeTestProtocol(func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+			return &syncResponse{
+				pb: syncpb.MakeGetStorageRangesResponse(0, slots, proof),
+			}, makeTestStreamID(0)
+		})
+		res, _, _, err := protocol.GetStorageRanges(context.Background(), testHash, accounts, roots, common.Hash{}, common.Hash{}, SoftResponseLimit)
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if test.expErr == nil && len(res) != len(slots) {
+			t.Errorf("Test %v: unexpected slots size: %v / %v", i, len(res), len(slots))
+		}
+	}
+}
+
 func makeTestProtocol(f getResponseFn) *Protocol {
 	rm := &testHostRequestManager{f}
 
@@ -379,6 +670,35 @@ func (sm *testStreamManager) GetStreamByID(id sttypes.StreamID) (sttypes.Stream,
 	return nil, false
 }
 
+// makeTestTrie returns a trie of n entries, with the keys in increasing order.
+func makeTestTrie(n int) (*trie.Trie, [][]byte, [][]byte) {
+	tr, _ := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+	var keys, values [][]byte
+	for i := 0; i < n; i++ {
+		key := crypto.Keccak256Hash([]byte{byte(i)})
+		keys = append(keys, key[:])
+		values = append(values, []byte{0x80, byte(i + 1)})
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	for i := range keys {
+		tr.Update(keys[i], values[i])
+	}
+	return tr, keys, values
+}
+
+// makeTestProof returns the trie nodes proving the given keys. An empty key is
+// proven as the zero hash.
+func makeTestProof(tr *trie.Trie, keys ...[]byte) [][]byte {
+	var proof proofList
+	for _, key := range keys {
+		if len(key) == 0 {
+			key = common.Hash{}.Bytes()
+		}
+		tr.Prove(key, 0, &proof)
+	}
+	return proof
+}
+
 func assertError(got, expect error) error {
 	if (got == nil) != (expect == nil) {
 		return fmt.Errorf("unexpected error: %v / %v", got, expect)
@@ -17,6 +17,20 @@ const (
 	// See comments for GetBlocksByNumAmountCap.
 	GetBlocksByHashesAmountCap = 10
 
+	// GetReceiptsCap is the cap of request of single GetReceipts request
+	// This number has an effect on maxMsgBytes as 20MB defined in github.com/harmony-one/harmony/p2p/stream/types.
+	GetReceiptsCap = 10
+
+	// GetNodeDataCap is the cap of request of single GetNodeData request
+	GetNodeDataCap = 256
+
+	// GetStorageRangesAccountsCap is the cap of the accounts of single GetStorageRanges request
+	GetStorageRangesAccountsCap = 128
+
+	// SoftResponseLimit is the target maximum size of the replies to GetNodeData,
+	// GetAccountRange and GetStorageRanges requests.
+	SoftResponseLimit = 2 * 1024 * 1024
+
 	// minAdvertiseInterval is the minimum advertise interval
 	minAdvertiseInterval = 1 * time.Minute
 
@@ -46,6 +46,57 @@ func MakeGetBlocksByHashesRequest(hashes []common.Hash) *Request {
 	}
 }
 
+// MakeGetReceiptsRequest makes the GetReceipts request
+func MakeGetReceiptsRequest(hashes []common.Hash) *Request {
+	return &Request{
+		Request: &Request_GetReceiptsRequest{
+			GetReceiptsRequest: &GetReceiptsRequest{
+				BlockHashes: hashesToBytes(hashes),
+			},
+		},
+	}
+}
+
+// MakeGetNodeDataRequest makes the GetNodeData request
+func MakeGetNodeDataRequest(hashes []common.Hash) *Request {
+	return &Request{
+		Request: &Request_GetNodeDataRequest{
+			GetNodeDataRequest: &GetNodeDataRequest{
+				NodeHashes: hashesToBytes(hashes),
+			},
+		},
+	}
+}
+
+// MakeGetAccountRangeRequest makes the GetAccountRange request
+func MakeGetAccountRangeRequest(root common.Hash, origin common.Hash, limit common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetAccountRangeRequest{
+			GetAccountRangeRequest: &GetAccountRangeRequest{
+				Root:   root[:],
+				Origin: origin[:],
+				Limit:  limit[:],
+				Bytes:  bytes,
+			},
+		},
+	}
+}
+
+// MakeGetStorageRangesRequest makes the GetStorageRanges request
+func MakeGetStorageRangesRequest(root common.Hash, accounts []common.Hash, origin common.Hash, limit common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetStorageRangesRequest{
+			GetStorageRangesRequest: &GetStorageRangesRequest{
+				Root:     root[:],
+				Accounts: hashesToBytes(accounts),
+				Origin:   origin[:],
+				Limit:    limit[:],
+				Bytes:    bytes,
+			},
+		},
+	}
+}
+
 // MakeErrorResponse makes the error response
 func MakeErrorResponseMessage(rid uint64, err error) *Message {
 	resp := MakeErrorResponse(rid, err)
@@ -138,6 +189,80 @@ func MakeGetBlocksByHashesResponse(rid uint64, blocksBytes [][]byte, sigs [][]by
 	}
 }
 
+// MakeGetReceiptsResponseMessage makes the GetReceiptsResponse of Message type
+func MakeGetReceiptsResponseMessage(rid uint64, receiptsBytes [][]byte) *Message {
+	resp := MakeGetReceiptsResponse(rid, receiptsBytes)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetReceiptsResponse make the GetReceiptsResponse of Response type
+func MakeGetReceiptsResponse(rid uint64, receiptsBytes [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetReceiptsResponse{
+			GetReceiptsResponse: &GetReceiptsResponse{
+				ReceiptsBytes: receiptsBytes,
+			},
+		},
+	}
+}
+
+// MakeGetNodeDataResponseMessage makes the GetNodeDataResponse of Message type
+func MakeGetNodeDataResponseMessage(rid uint64, data [][]byte) *Message {
+	resp := MakeGetNodeDataResponse(rid, data)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetNodeDataResponse make the GetNodeDataResponse of Response type
+func MakeGetNodeDataResponse(rid uint64, data [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetNodeDataResponse{
+			GetNodeDataResponse: &GetNodeDataResponse{
+				DataBytes: data,
+			},
+		},
+	}
+}
+
+// MakeGetAccountRangeResponseMessage makes the GetAccountRangeResponse of Message type
+func MakeGetAccountRangeResponseMessage(rid uint64, accounts []*AccountData, proof [][]byte) *Message {
+	resp := MakeGetAccountRangeResponse(rid, accounts, proof)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetAccountRangeResponse make the GetAccountRangeResponse of Response type
+func MakeGetAccountRangeResponse(rid uint64, accounts []*AccountData, proof [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetAccountRangeResponse{
+			GetAccountRangeResponse: &GetAccountRangeResponse{
+				Accounts: accounts,
+				Proof:    proof,
+			},
+		},
+	}
+}
+
+// MakeGetStorageRangesResponseMessage makes the GetStorageRangesResponse of Message type
+func MakeGetStorageRangesResponseMessage(rid uint64, slots []*StoragesData, proof [][]byte) *Message {
+	resp := MakeGetStorageRangesResponse(rid, slots, proof)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetStorageRangesResponse make the GetStorageRangesResponse of Response type
+func MakeGetStorageRangesResponse(rid uint64, slots []*StoragesData, proof [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetStorageRangesResponse{
+			GetStorageRangesResponse: &GetStorageRangesResponse{
+				Slots: slots,
+				Proof: proof,
+			},
+		},
+	}
+}
+
 // MakeMessageFromRequest makes a message from the request
 func MakeMessageFromRequest(req *Request) *Message {
 	return &Message{
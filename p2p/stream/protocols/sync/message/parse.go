@@ -79,3 +79,67 @@ func (msg *Message) GetBlocksByHashesResponse() (*GetBlocksByHashesResponse, err
 	}
 	return gbResp, nil
 }
+
+// GetReceiptsResponse parse the message to GetReceiptsResponse
+func (msg *Message) GetReceiptsResponse() (*GetReceiptsResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gResp := resp.GetGetReceiptsResponse()
+	if gResp == nil {
+		return nil, errors.New("not GetReceiptsResponse")
+	}
+	return gResp, nil
+}
+
+// GetNodeDataResponse parse the message to GetNodeDataResponse
+func (msg *Message) GetNodeDataResponse() (*GetNodeDataResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gResp := resp.GetGetNodeDataResponse()
+	if gResp == nil {
+		return nil, errors.New("not GetNodeDataResponse")
+	}
+	return gResp, nil
+}
+
+// GetAccountRangeResponse parse the message to GetAccountRangeResponse
+func (msg *Message) GetAccountRangeResponse() (*GetAccountRangeResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gResp := resp.GetGetAccountRangeResponse()
+	if gResp == nil {
+		return nil, errors.New("not GetAccountRangeResponse")
+	}
+	return gResp, nil
+}
+
+// GetStorageRangesResponse parse the message to GetStorageRangesResponse
+func (msg *Message) GetStorageRangesResponse() (*GetStorageRangesResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gResp := resp.GetGetStorageRangesResponse()
+	if gResp == nil {
+		return nil, errors.New("not GetStorageRangesResponse")
+	}
+	return gResp, nil
+}
@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/event"
-	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/core"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
 	"github.com/harmony-one/harmony/internal/utils"
@@ -28,9 +28,10 @@ const (
 
 var (
 	version100, _ = version.NewVersion("1.0.0")
+	version110, _ = version.NewVersion("1.1.0")
 
 	// MyVersion is the version of sync protocol of the local node
-	MyVersion = version100
+	MyVersion = version110
 
 	// MinVersion is the minimum version for matching function
 	MinVersion = version100
@@ -39,7 +40,7 @@ var (
 type (
 	// Protocol is the protocol for sync streaming
 	Protocol struct {
-		chain    engine.ChainReader            // provide SYNC data
+		chain    core.BlockChain               // provide SYNC data
 		schedule shardingconfig.Schedule       // provide schedule information
 		rl       ratelimiter.RateLimiter       // limit the incoming request rate
 		sm       streammanager.StreamManager   // stream management
@@ -56,7 +57,7 @@ type (
 
 	// Config is the sync protocol config
 	Config struct {
-		Chain     engine.ChainReader
+		Chain     core.BlockChain
 		Host      libp2p_host.Host
 		Discovery discovery.Discovery
 		ShardID   nodeconfig.ShardID
@@ -210,7 +211,7 @@ func (p *Protocol) supportedProtoIDs() []sttypes.ProtoID {
 }
 
 func (p *Protocol) supportedVersions() []*version.Version {
-	return []*version.Version{version100}
+	return []*version.Version{version100, version110}
 }
 
 func (p *Protocol) protoIDByVersion(v *version.Version) sttypes.ProtoID {
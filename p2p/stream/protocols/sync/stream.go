@@ -182,6 +182,18 @@ func (st *syncStream) handleReq(req *syncpb.Request) error {
 	if bhReq := req.GetGetBlocksByHashesRequest(); bhReq != nil {
 		return st.handleGetBlocksByHashesRequest(req.ReqId, bhReq)
 	}
+	if grReq := req.GetGetReceiptsRequest(); grReq != nil {
+		return st.handleGetReceiptsRequest(req.ReqId, grReq)
+	}
+	if ndReq := req.GetGetNodeDataRequest(); ndReq != nil {
+		return st.handleGetNodeDataRequest(req.ReqId, ndReq)
+	}
+	if arReq := req.GetGetAccountRangeRequest(); arReq != nil {
+		return st.handleGetAccountRangeRequest(req.ReqId, arReq)
+	}
+	if srReq := req.GetGetStorageRangesRequest(); srReq != nil {
+		return st.handleGetStorageRangesRequest(req.ReqId, srReq)
+	}
 	// unsupported request type
 	return st.handleUnknownRequest(req.ReqId)
 }
@@ -260,6 +272,95 @@ func (st *syncStream) handleGetBlocksByHashesRequest(rid uint64, req *syncpb.Get
 	return errors.Wrap(err, "[GetBlocksByHashes]")
 }
 
+func (st *syncStream) handleGetReceiptsRequest(rid uint64, req *syncpb.GetReceiptsRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getReceipts",
+	}).Inc()
+
+	hashes := bytesToHashes(req.BlockHashes)
+	resp, err := st.computeGetReceiptsResp(rid, hashes)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetReceipts]")
+}
+
+func (st *syncStream) handleGetNodeDataRequest(rid uint64, req *syncpb.GetNodeDataRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getNodeData",
+	}).Inc()
+
+	hashes := bytesToHashes(req.NodeHashes)
+	resp, err := st.computeGetNodeDataResp(rid, hashes)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetNodeData]")
+}
+
+func (st *syncStream) handleGetAccountRangeRequest(rid uint64, req *syncpb.GetAccountRangeRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getAccountRange",
+	}).Inc()
+
+	root := common.BytesToHash(req.Root)
+	origin := common.BytesToHash(req.Origin)
+	limit := common.BytesToHash(req.Limit)
+	resp, err := st.computeGetAccountRangeResp(rid, root, origin, limit, req.Bytes)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetAccountRange]")
+}
+
+func (st *syncStream) handleGetStorageRangesRequest(rid uint64, req *syncpb.GetStorageRangesRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getStorageRanges",
+	}).Inc()
+
+	root := common.BytesToHash(req.Root)
+	accounts := bytesToHashes(req.Accounts)
+	origin := common.BytesToHash(req.Origin)
+	limit := common.BytesToHash(req.Limit)
+	resp, err := st.computeGetStorageRangesResp(rid, root, accounts, origin, limit, req.Bytes)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetStorageRanges]")
+}
+
 func (st *syncStream) handleUnknownRequest(rid uint64) error {
 	serverRequestCounterVec.With(prometheus.Labels{
 		"topic":        string(st.ProtoID()),
@@ -367,6 +468,59 @@ func (st *syncStream) computeRespFromBlockHashes(rid uint64, hs []common.Hash) (
 	return syncpb.MakeGetBlocksByHashesResponseMessage(rid, blocksBytes, sigs), nil
 }
 
+func (st *syncStream) computeGetReceiptsResp(rid uint64, hs []common.Hash) (*syncpb.Message, error) {
+	if len(hs) > GetReceiptsCap {
+		err := fmt.Errorf("GetReceipts amount exceed cap: %v > %v", len(hs), GetReceiptsCap)
+		return nil, err
+	}
+	receipts, err := st.chain.getReceipts(hs)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptsBytes := make([][]byte, 0, len(receipts))
+	for _, rs := range receipts {
+		rb, err := rlp.EncodeToBytes(rs)
+		if err != nil {
+			return nil, err
+		}
+		receiptsBytes = append(receiptsBytes, rb)
+	}
+	return syncpb.MakeGetReceiptsResponseMessage(rid, receiptsBytes), nil
+}
+
+func (st *syncStream) computeGetNodeDataResp(rid uint64, hs []common.Hash) (*syncpb.Message, error) {
+	if len(hs) > GetNodeDataCap {
+		err := fmt.Errorf("GetNodeData amount exceed cap: %v > %v", len(hs), GetNodeDataCap)
+		return nil, err
+	}
+	data, err := st.chain.getNodeData(hs)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetNodeDataResponseMessage(rid, data), nil
+}
+
+func (st *syncStream) computeGetAccountRangeResp(rid uint64, root common.Hash, origin common.Hash, limit common.Hash, bytes uint64) (*syncpb.Message, error) {
+	accounts, proof, err := st.chain.getAccountRange(root, origin, limit, bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetAccountRangeResponseMessage(rid, accounts, proof), nil
+}
+
+func (st *syncStream) computeGetStorageRangesResp(rid uint64, root common.Hash, accounts []common.Hash, origin common.Hash, limit common.Hash, bytes uint64) (*syncpb.Message, error) {
+	if len(accounts) > GetStorageRangesAccountsCap {
+		err := fmt.Errorf("GetStorageRanges amount exceed cap: %v > %v", len(accounts), GetStorageRangesAccountsCap)
+		return nil, err
+	}
+	slots, proof, err := st.chain.getStorageRanges(root, accounts, origin, limit, bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetStorageRangesResponseMessage(rid, slots, proof), nil
+}
+
 func bytesToHashes(bs [][]byte) []common.Hash {
 	hs := make([]common.Hash, 0, len(bs))
 	for _, b := range bs {
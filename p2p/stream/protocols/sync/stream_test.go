@@ -40,6 +40,20 @@ var (
 	}
 	testGetBlocksByHashesRequest    = syncpb.MakeGetBlocksByHashesRequest(testGetBlockByHashes)
 	testGetBlocksByHashesRequestMsg = syncpb.MakeMessageFromRequest(testGetBlocksByHashesRequest)
+
+	testGetReceiptsRequest    = syncpb.MakeGetReceiptsRequest(testGetBlockByHashes)
+	testGetReceiptsRequestMsg = syncpb.MakeMessageFromRequest(testGetReceiptsRequest)
+
+	testGetNodeDataRequest    = syncpb.MakeGetNodeDataRequest(testGetBlockByHashes)
+	testGetNodeDataRequestMsg = syncpb.MakeMessageFromRequest(testGetNodeDataRequest)
+
+	testRoot                       = numberToHash(100)
+	testOrigin                     = numberToHash(1)
+	testLimit                      = numberToHash(5)
+	testGetAccountRangeRequest     = syncpb.MakeGetAccountRangeRequest(testRoot, testOrigin, testLimit, SoftResponseLimit)
+	testGetAccountRangeRequestMsg  = syncpb.MakeMessageFromRequest(testGetAccountRangeRequest)
+	testGetStorageRangesRequest    = syncpb.MakeGetStorageRangesRequest(testRoot, testGetBlockByHashes, testOrigin, testLimit, SoftResponseLimit)
+	testGetStorageRangesRequestMsg = syncpb.MakeMessageFromRequest(testGetStorageRangesRequest)
 )
 
 func TestSyncStream_HandleGetBlocksByRequest(t *testing.T) {
@@ -126,6 +140,90 @@ func TestSyncStream_HandleGetBlocksByHashes(t *testing.T) {
 	}
 }
 
+func TestSyncStream_HandleGetReceipts(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetReceiptsRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkReceiptsResult(receivedBytes, testGetBlockByHashes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_HandleGetNodeData(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetNodeDataRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkNodeDataResult(receivedBytes, testGetBlockByHashes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_HandleGetAccountRange(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetAccountRangeRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkAccountRangeResult(receivedBytes, testOrigin, testLimit); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_HandleGetStorageRanges(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetStorageRangesRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkStorageRangesResult(receivedBytes, testGetBlockByHashes); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func makeTestSyncStream() (*syncStream, *testRemoteBaseStream) {
 	localRaw, remoteRaw := makePairP2PStreams()
 	remote := newTestRemoteBaseStream(remoteRaw)
@@ -1,8 +1,13 @@
 package sync
 
 import (
+	"bytes"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/trie"
 	protobuf "github.com/golang/protobuf/proto"
 	"github.com/harmony-one/harmony/p2p/stream/common/requestmanager"
 	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
@@ -11,6 +16,10 @@ import (
 
 var (
 	errUnknownReqType = errors.New("unknown request")
+
+	// errInvalidResponse is the error of a response which fails the verification
+	// against the request. The stream serving it is not to be trusted.
+	errInvalidResponse = errors.New("invalid response")
 )
 
 // syncResponse is the sync protocol response which implements sttypes.Response
@@ -50,3 +59,64 @@ var (
 	// given streamID
 	WithWhitelist = requestmanager.WithWhitelist
 )
+
+// verifyRange checks the sorted key value pairs of a trie range from origin
+// against root. Without proof, the pairs must make up the whole trie. With
+// proof, both the origin and the last key must be proven, and the value of the
+// last key must match.
+func verifyRange(root common.Hash, origin common.Hash, keys [][]byte, values [][]byte, proof [][]byte) error {
+	for i, key := range keys {
+		if len(key) != common.HashLength {
+			return fmt.Errorf("key %x of invalid length", key)
+		}
+		if len(values[i]) == 0 {
+			return fmt.Errorf("empty value of key %x", key)
+		}
+		if i == 0 && bytes.Compare(key, origin[:]) < 0 {
+			return fmt.Errorf("key %x before origin %x", key, origin)
+		}
+		if i > 0 && bytes.Compare(keys[i-1], key) >= 0 {
+			return fmt.Errorf("keys not in increasing order: %x, %x", keys[i-1], key)
+		}
+	}
+	if len(proof) == 0 {
+		if origin != (common.Hash{}) {
+			return errors.New("missing proof of the range")
+		}
+		tr, err := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			if err := tr.TryUpdate(key, values[i]); err != nil {
+				return err
+			}
+		}
+		if got := tr.Hash(); got != root {
+			return fmt.Errorf("root mismatch: %x / %x", got, root)
+		}
+		return nil
+	}
+
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		if err := proofDB.Put(crypto.Keccak256(node), node); err != nil {
+			return err
+		}
+	}
+	if _, _, err := trie.VerifyProof(root, origin[:], proofDB); err != nil {
+		return errors.Wrapf(err, "proof of origin %x", origin)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	last := len(keys) - 1
+	value, _, err := trie.VerifyProof(root, keys[last], proofDB)
+	if err != nil {
+		return errors.Wrapf(err, "proof of key %x", keys[last])
+	}
+	if !bytes.Equal(value, values[last]) {
+		return fmt.Errorf("value of key %x not proven", keys[last])
+	}
+	return nil
+}
@@ -59,14 +59,23 @@ func (s *SearchAPI) SearchTransactions(ctx context.Context, request *types.Searc
 			return nil, &rosetta_common.ErrCallParametersInvalid
 		}
 
-		histories, err := s.hmy.GetTransactionsHistory(address, "", "")
-		if err != nil {
-			return nil, rosetta_common.NewError(rosetta_common.CatchAllError, map[string]interface{}{
-				"message": err.Error(),
-			})
+		query := hmy.TxHistoryQuery{
+			Address:  address,
+			PageSize: 1000,
+		}
+		for {
+			histories, cursor, err := s.hmy.GetTransactionsHistory(query)
+			if err != nil {
+				return nil, rosetta_common.NewError(rosetta_common.CatchAllError, map[string]interface{}{
+					"message": err.Error(),
+				})
+			}
+			filteredHash = append(filteredHash, histories...)
+			if cursor == "" {
+				break
+			}
+			query.Cursor = cursor
 		}
-
-		filteredHash = histories
 	}
 
 	if request.TransactionIdentifier != nil {
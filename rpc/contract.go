@@ -12,8 +12,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/harmony-one/harmony/common/denominations"
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy"
 	hmyCommon "github.com/harmony-one/harmony/internal/common"
@@ -23,6 +26,9 @@ import (
 const (
 	defaultGasPrice    = denominations.Nano
 	defaultFromAddress = "0x0000000000000000000000000000000000000000"
+	// maxAccessListIterations bounds the number of times the transaction is
+	// re-executed while expanding its access list
+	maxAccessListIterations = 32
 )
 
 // PublicContractService provides an API to access Harmony's contract services.
@@ -67,8 +73,10 @@ func (s *PublicContractService) wait(limiter *rate.Limiter, ctx context.Context)
 
 // Call executes the given transaction on the state for the given block number.
 // It doesn't make and changes in the state/blockchain and is useful to execute and retrieve values.
+//
+// Additionally, the caller can specify a batch of contract for fields overriding.
 func (s *PublicContractService) Call(
-	ctx context.Context, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	ctx context.Context, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride,
 ) (hexutil.Bytes, error) {
 	timer := DoMetricRPCRequest(Call)
 	defer DoRPCRequestDuration(Call, timer)
@@ -80,7 +88,7 @@ func (s *PublicContractService) Call(
 	}
 
 	// Execute call
-	result, err := DoEVMCall(ctx, s.hmy, args, blockNrOrHash, CallTimeout)
+	result, err := DoEVMCall(ctx, s.hmy, args, blockNrOrHash, overrides, CallTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -143,10 +151,117 @@ func (s *PublicContractService) GetStorageAt(
 	return res[:], state.Error()
 }
 
-// DoEVMCall executes an EVM call
+// CreateAccessList creates an EIP-2930 type AccessList for the given transaction.
+// Reexec and BlockNrOrHash can be specified to create the accessList on top of a certain state.
+func (s *PublicContractService) CreateAccessList(
+	ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash,
+) (*AccessListResult, error) {
+	timer := DoMetricRPCRequest(CreateAccessList)
+	defer DoRPCRequestDuration(CreateAccessList, timer)
+
+	err := s.wait(s.limiterCall, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(CreateAccessList, RateLimitedNumber)
+		return nil, err
+	}
+
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	acl, gasUsed, vmerr, err := AccessList(ctx, s.hmy, bNrOrHash, args)
+	if err != nil {
+		DoMetricRPCQueryInfo(CreateAccessList, FailedNumber)
+		return nil, err
+	}
+	result := &AccessListResult{Accesslist: &acl, GasUsed: hexutil.Uint64(gasUsed)}
+	if vmerr != nil {
+		result.Error = vmerr.Error()
+	}
+
+	// Response output is the same for all versions
+	return result, nil
+}
+
+// AccessList creates an access list for the given transaction.
+// If the accesslist creation fails an error is returned.
+// If the transaction itself fails, an vmErr is returned.
+func AccessList(
+	ctx context.Context, hmy *hmy.Harmony, blockNrOrHash rpc.BlockNumberOrHash, args CallArgs,
+) (acl types.AccessList, gasUsed uint64, vmErr error, err error) {
+	// Retrieve the execution context
+	db, header, err := hmy.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if db == nil || err != nil {
+		return nil, 0, nil, err
+	}
+	if !hmy.ChainConfig().IsBerlin(header.Epoch()) {
+		return nil, 0, nil, ErrAccessListBeforeBerlin
+	}
+	// If the gas amount is not set, extract this as it will depend on access
+	// lists and we'll need to reestimate every time
+	nogas := args.Gas == nil
+
+	// Ensure any missing fields are filled, extract the recipient and input data
+	var from, to common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	if args.To != nil {
+		to = *args.To
+	} else {
+		to = crypto.CreateAddress(from, db.GetNonce(from))
+	}
+	// Retrieve the precompiles since they don't need to be added to the access list
+	precompiles := vm.ActivePrecompiles(hmy.ChainConfig().Rules(header.Epoch()))
+
+	// Create an initial tracer
+	prevTracer := vm.NewAccessListTracer(nil, from, to, precompiles)
+	if args.AccessList != nil {
+		prevTracer = vm.NewAccessListTracer(*args.AccessList, from, to, precompiles)
+	}
+	for i := 0; i < maxAccessListIterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, nil, err
+		}
+		// Retrieve the current access list to expand
+		accessList := prevTracer.AccessList()
+
+		// If no gas amount was specified, each unique access list needs it's own
+		// gas calculation. This is quite expensive, but we need to be accurate
+		// and it's convered by the sender only anyway.
+		args.AccessList = &accessList
+		if nogas {
+			args.Gas = nil
+			gas, err := EstimateGas(ctx, hmy, args, blockNrOrHash, nil, nil)
+			if err != nil {
+				return nil, 0, nil, err
+			}
+			args.Gas = (*hexutil.Uint64)(&gas)
+		}
+		msg := args.ToMessage(hmy.RPCGasCap)
+
+		// Apply the transaction with the access list tracer
+		tracer := vm.NewAccessListTracer(accessList, from, to, precompiles)
+		config := vm.Config{Tracer: tracer, Debug: true, NoBaseFee: true}
+		vmctx := core.NewEVMContext(msg, header, hmy.BlockChain, nil)
+		vmenv := vm.NewEVM(vmctx, db.Copy(), hmy.BlockChain.Config(), config)
+		res, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas()))
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to apply transaction: %v", err)
+		}
+		if tracer.Equal(prevTracer) {
+			return accessList, res.UsedGas, res.VMErr, nil
+		}
+		prevTracer = tracer
+	}
+	return nil, 0, nil, ErrAccessListNotConverged
+}
+
+// DoEVMCall executes an EVM call, on a copy of the state with the given
+// overrides applied if any
 func DoEVMCall(
 	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
-	timeout time.Duration,
+	overrides *StateOverride, timeout time.Duration,
 ) (core.ExecutionResult, error) {
 	defer func(start time.Time) {
 		utils.Logger().Debug().
@@ -160,6 +275,13 @@ func DoEVMCall(
 		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
 		return core.ExecutionResult{}, err
 	}
+	if overrides != nil {
+		state = state.Copy()
+		if err := overrides.Apply(state); err != nil {
+			DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
+			return core.ExecutionResult{}, err
+		}
+	}
 
 	// Create new call message
 	msg := args.ToMessage(hmy.RPCGasCap)
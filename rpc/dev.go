@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+)
+
+// DevBackend is the block sealer of a dev mode node.
+type DevBackend interface {
+	Mine() (*types.Block, error)
+	IncreaseTime(seconds int64) int64
+	Snapshot() uint64
+	Revert(id uint64) (bool, error)
+}
+
+// PublicDevService offers the evm_* methods of the contract test suites,
+// served by dev mode nodes only.
+type PublicDevService struct {
+	backend DevBackend
+}
+
+// NewPublicDevAPI creates a new evm API instance.
+func NewPublicDevAPI(backend DevBackend) rpc.API {
+	return rpc.API{
+		Namespace: devNamespace,
+		Version:   APIVersion,
+		Service:   &PublicDevService{backend},
+		Public:    true,
+	}
+}
+
+// Mine seals a new block with the pending transactions, if any.
+func (s *PublicDevService) Mine(ctx context.Context) (string, error) {
+	if _, err := s.backend.Mine(); err != nil {
+		return "", err
+	}
+	return "0x0", nil
+}
+
+// IncreaseTime moves the timestamps of the next blocks forward by the given
+// seconds, and returns the total time offset in seconds.
+func (s *PublicDevService) IncreaseTime(ctx context.Context, seconds int64) int64 {
+	return s.backend.IncreaseTime(seconds)
+}
+
+// Snapshot records the current head of the chain and returns the id to revert to it.
+func (s *PublicDevService) Snapshot(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(s.backend.Snapshot())
+}
+
+// Revert rewinds the chain to the head recorded by the snapshot, discarding
+// the snapshot and the later ones.
+func (s *PublicDevService) Revert(ctx context.Context, id hexutil.Uint64) (bool, error) {
+	return s.backend.Revert(uint64(id))
+}
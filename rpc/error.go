@@ -19,4 +19,8 @@ var (
 	ErrUnknownRPCVersion = errors.New("API service has an unknown version")
 	// ErrTransactionNotFound when attempting to get a transaction that does not exist or has not been finalized
 	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrAccessListBeforeBerlin when an access list is requested for a block before the Berlin epoch
+	ErrAccessListBeforeBerlin = errors.New("access lists are not supported before the Berlin epoch")
+	// ErrAccessListNotConverged when the access list keeps changing after the maximum number of iterations
+	ErrAccessListNotConverged = errors.New("access list did not converge")
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy"
 	internal_common "github.com/harmony-one/harmony/internal/common"
@@ -44,3 +45,56 @@ func (s *PublicEthService) GetBalance(
 	}
 	return (*hexutil.Big)(balance), nil
 }
+
+// MaxPriorityFeePerGas returns a suggestion for a gas tip cap for dynamic fee
+// transactions.
+func (s *PublicEthService) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tipCap, err := s.hmy.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tipCap), nil
+}
+
+// FeeHistoryResult is the result of eth_feeHistory.
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// FeeHistory returns the fee market history of up to blockCount blocks ending
+// at lastBlock, with the effective tips at the given reward percentiles.
+func (s *PublicEthService) FeeHistory(
+	ctx context.Context, blockCount math.HexOrDecimal64, lastBlock rpc.BlockNumber, rewardPercentiles []float64,
+) (*FeeHistoryResult, error) {
+	blocks := uint64(blockCount)
+	if blocks > hmy.MaxFeeHistory {
+		blocks = hmy.MaxFeeHistory
+	}
+	oldest, reward, baseFee, gasUsed, err := s.hmy.FeeHistory(ctx, int(blocks), lastBlock, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	results := &FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(oldest),
+		GasUsedRatio: gasUsed,
+	}
+	if reward != nil {
+		results.Reward = make([][]*hexutil.Big, len(reward))
+		for i, w := range reward {
+			results.Reward[i] = make([]*hexutil.Big, len(w))
+			for j, v := range w {
+				results.Reward[i][j] = (*hexutil.Big)(v)
+			}
+		}
+	}
+	if baseFee != nil {
+		results.BaseFee = make([]*hexutil.Big, len(baseFee))
+		for i, v := range baseFee {
+			results.BaseFee[i] = (*hexutil.Big)(v)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,1447 @@
+// Package graphql provides a GraphQL interface to Harmony node data.
+// Adapted from go-ethereum/graphql
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	hmy_rpc "github.com/harmony-one/harmony/rpc"
+	"github.com/harmony-one/harmony/rpc/filters"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+// maxBlocksRange is the maximum number of blocks a blocks query can span, the
+// same as the block range limit of eth_getLogs.
+const maxBlocksRange = 1024
+
+var (
+	errBlockNotFound      = errors.New("block not found")
+	errStateNotFound      = errors.New("state not found")
+	errBlockRangeTooLarge = errors.New("block range too large")
+)
+
+// Account represents an account at a particular block.
+type Account struct {
+	hmy          *hmy.Harmony
+	address      common.Address
+	numberOrHash rpc.BlockNumberOrHash
+}
+
+// getState fetches the state DB object for an account.
+func (a *Account) getState(ctx context.Context) (*state.DB, error) {
+	db, _, err := a.hmy.StateAndHeaderByNumberOrHash(ctx, a.numberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if db == nil {
+		return nil, errStateNotFound
+	}
+	return db, nil
+}
+
+func (a *Account) Address(ctx context.Context) (common.Address, error) {
+	return a.address, nil
+}
+
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	db, err := a.getState(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*db.GetBalance(a.address)), nil
+}
+
+func (a *Account) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+	db, err := a.getState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(db.GetNonce(a.address)), nil
+}
+
+func (a *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	db, err := a.getState(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(db.GetCode(a.address)), nil
+}
+
+func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	db, err := a.getState(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return db.GetState(a.address, args.Slot), nil
+}
+
+// Log represents an individual log message. All arguments are mandatory.
+type Log struct {
+	hmy         *hmy.Harmony
+	transaction *Transaction
+	log         *types.Log
+}
+
+func (l *Log) Transaction(ctx context.Context) *Transaction {
+	return l.transaction
+}
+
+func (l *Log) Account(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{
+		hmy:          l.hmy,
+		address:      l.log.Address,
+		numberOrHash: args.NumberOrLatest(),
+	}
+}
+
+func (l *Log) Index(ctx context.Context) int32 {
+	return int32(l.log.Index)
+}
+
+func (l *Log) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+func (l *Log) Data(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(l.log.Data)
+}
+
+// Transaction represents a plain transaction.
+// hmy and hash are mandatory; all others will be fetched when required.
+type Transaction struct {
+	hmy   *hmy.Harmony
+	hash  common.Hash
+	tx    *types.Transaction
+	block *Block
+	index uint64
+}
+
+// resolve returns the internal transaction object, fetching it if needed.
+func (t *Transaction) resolve(ctx context.Context) (*types.Transaction, error) {
+	if t.tx == nil {
+		tx, blockHash, _, index := rawdb.ReadTransaction(t.hmy.ChainDb(), t.hash)
+		if tx != nil {
+			t.tx = tx
+			t.block = newBlockByHash(t.hmy, blockHash)
+			t.index = index
+		} else if poolTx, ok := t.hmy.GetPoolTransaction(t.hash).(*types.Transaction); ok {
+			t.tx = poolTx
+		}
+	}
+	return t.tx, nil
+}
+
+// blockNumberOrHash returns the block the transaction was mined in, or the
+// latest block for a transaction which is not mined yet.
+func (t *Transaction) blockNumberOrHash() rpc.BlockNumberOrHash {
+	if t.block != nil {
+		return t.block.numberOrHash
+	}
+	return rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+}
+
+func (t *Transaction) Hash(ctx context.Context) common.Hash {
+	return t.hash
+}
+
+func (t *Transaction) EthHash(ctx context.Context) (common.Hash, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return common.Hash{}, err
+	}
+	return tx.ConvertToEth().Hash(), nil
+}
+
+func (t *Transaction) Type(ctx context.Context) (int32, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return int32(tx.Type()), nil
+}
+
+func (t *Transaction) InputData(ctx context.Context) (hexutil.Bytes, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(tx.Data()), nil
+}
+
+func (t *Transaction) Gas(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.GasLimit()), nil
+}
+
+func (t *Transaction) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tx.GasPrice()), nil
+}
+
+func (t *Transaction) MaxFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.Type() != types.DynamicFeeTxType {
+		return nil, err
+	}
+	return (*hexutil.Big)(tx.GasFeeCap()), nil
+}
+
+func (t *Transaction) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.Type() != types.DynamicFeeTxType {
+		return nil, err
+	}
+	return (*hexutil.Big)(tx.GasTipCap()), nil
+}
+
+func (t *Transaction) Value(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tx.Value()), nil
+}
+
+func (t *Transaction) Nonce(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Nonce()), nil
+}
+
+func (t *Transaction) ShardID(ctx context.Context) (int32, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return int32(tx.ShardID()), nil
+}
+
+func (t *Transaction) ToShardID(ctx context.Context) (int32, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return int32(tx.ToShardID()), nil
+}
+
+func (t *Transaction) To(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	to := tx.To()
+	if to == nil {
+		return nil, nil
+	}
+	return &Account{
+		hmy:          t.hmy,
+		address:      *to,
+		numberOrHash: args.NumberOr(t.blockNumberOrHash()),
+	}, nil
+}
+
+func (t *Transaction) From(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	from, err := tx.SenderAddress()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		hmy:          t.hmy,
+		address:      from,
+		numberOrHash: args.NumberOr(t.blockNumberOrHash()),
+	}, nil
+}
+
+func (t *Transaction) Block(ctx context.Context) (*Block, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return t.block, nil
+}
+
+func (t *Transaction) Index(ctx context.Context) (*int32, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	index := int32(t.index)
+	return &index, nil
+}
+
+// getReceipt returns the receipt associated with this transaction, if any.
+func (t *Transaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	receipts, err := t.block.resolveReceipts(ctx)
+	if err != nil || t.index >= uint64(len(receipts)) {
+		return nil, err
+	}
+	return receipts[t.index], nil
+}
+
+func (t *Transaction) Status(ctx context.Context) (*hexutil.Uint64, error) {
+	return receiptStatus(t.getReceipt(ctx))
+}
+
+func (t *Transaction) GasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	return receiptGasUsed(t.getReceipt(ctx))
+}
+
+func (t *Transaction) CumulativeGasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(receipt.CumulativeGasUsed)
+	return &ret, nil
+}
+
+func (t *Transaction) CreatedContract(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil || receipt.ContractAddress == (common.Address{}) {
+		return nil, err
+	}
+	return &Account{
+		hmy:          t.hmy,
+		address:      receipt.ContractAddress,
+		numberOrHash: args.NumberOr(t.blockNumberOrHash()),
+	}, nil
+}
+
+func (t *Transaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		ret = append(ret, &Log{
+			hmy:         t.hmy,
+			transaction: t,
+			log:         log,
+		})
+	}
+	return &ret, nil
+}
+
+// StakingTransaction represents a staking transaction.
+// hmy and hash are mandatory; all others will be fetched when required.
+type StakingTransaction struct {
+	hmy   *hmy.Harmony
+	hash  common.Hash
+	tx    *staking.StakingTransaction
+	block *Block
+	index uint64
+}
+
+// resolve returns the internal staking transaction object, fetching it if
+// needed.
+func (t *StakingTransaction) resolve(ctx context.Context) (*staking.StakingTransaction, error) {
+	if t.tx == nil {
+		tx, blockHash, _, index := rawdb.ReadStakingTransaction(t.hmy.ChainDb(), t.hash)
+		if tx != nil {
+			t.tx = tx
+			t.block = newBlockByHash(t.hmy, blockHash)
+			t.index = index
+		} else if poolTx, ok := t.hmy.GetPoolTransaction(t.hash).(*staking.StakingTransaction); ok {
+			t.tx = poolTx
+		}
+	}
+	return t.tx, nil
+}
+
+// message returns the decoded staking message of the transaction.
+func (t *StakingTransaction) message(ctx context.Context) (interface{}, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return staking.RLPDecodeStakeMsg(tx.Data(), tx.StakingType())
+}
+
+func (t *StakingTransaction) Hash(ctx context.Context) common.Hash {
+	return t.hash
+}
+
+func (t *StakingTransaction) Type(ctx context.Context) (string, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return "", err
+	}
+	return tx.StakingType().String(), nil
+}
+
+func (t *StakingTransaction) Nonce(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Nonce()), nil
+}
+
+func (t *StakingTransaction) Index(ctx context.Context) (*int32, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	index := int32(t.index)
+	return &index, nil
+}
+
+func (t *StakingTransaction) From(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	from, err := tx.SenderAddress()
+	if err != nil {
+		return nil, err
+	}
+	numberOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if t.block != nil {
+		numberOrHash = t.block.numberOrHash
+	}
+	return &Account{
+		hmy:          t.hmy,
+		address:      from,
+		numberOrHash: args.NumberOr(numberOrHash),
+	}, nil
+}
+
+func (t *StakingTransaction) ValidatorAddress(ctx context.Context) (*common.Address, error) {
+	msg, err := t.message(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := msg.(type) {
+	case *staking.CreateValidator:
+		return &msg.ValidatorAddress, nil
+	case *staking.EditValidator:
+		return &msg.ValidatorAddress, nil
+	case *staking.Delegate:
+		return &msg.ValidatorAddress, nil
+	case *staking.Undelegate:
+		return &msg.ValidatorAddress, nil
+	}
+	return nil, nil
+}
+
+func (t *StakingTransaction) DelegatorAddress(ctx context.Context) (*common.Address, error) {
+	msg, err := t.message(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := msg.(type) {
+	case *staking.Delegate:
+		return &msg.DelegatorAddress, nil
+	case *staking.Undelegate:
+		return &msg.DelegatorAddress, nil
+	case *staking.CollectRewards:
+		return &msg.DelegatorAddress, nil
+	}
+	return nil, nil
+}
+
+func (t *StakingTransaction) Amount(ctx context.Context) (*hexutil.Big, error) {
+	msg, err := t.message(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := msg.(type) {
+	case *staking.CreateValidator:
+		return (*hexutil.Big)(msg.Amount), nil
+	case *staking.Delegate:
+		return (*hexutil.Big)(msg.Amount), nil
+	case *staking.Undelegate:
+		return (*hexutil.Big)(msg.Amount), nil
+	}
+	return nil, nil
+}
+
+func (t *StakingTransaction) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tx.GasPrice()), nil
+}
+
+func (t *StakingTransaction) Gas(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.GasLimit()), nil
+}
+
+func (t *StakingTransaction) Block(ctx context.Context) (*Block, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return t.block, nil
+}
+
+// getReceipt returns the receipt associated with this staking transaction,
+// if any. Staking receipts follow the plain transaction receipts of a block.
+func (t *StakingTransaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	blk, err := t.block.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := t.block.resolveReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := uint64(len(blk.Transactions())) + t.index
+	if index >= uint64(len(receipts)) {
+		return nil, nil
+	}
+	return receipts[index], nil
+}
+
+func (t *StakingTransaction) Status(ctx context.Context) (*hexutil.Uint64, error) {
+	return receiptStatus(t.getReceipt(ctx))
+}
+
+func (t *StakingTransaction) GasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	return receiptGasUsed(t.getReceipt(ctx))
+}
+
+func (t *StakingTransaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		ret = append(ret, &Log{
+			hmy:         t.hmy,
+			transaction: &Transaction{hmy: t.hmy, hash: log.TxHash},
+			log:         log,
+		})
+	}
+	return &ret, nil
+}
+
+func receiptStatus(receipt *types.Receipt, err error) (*hexutil.Uint64, error) {
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(receipt.Status)
+	return &ret, nil
+}
+
+func receiptGasUsed(receipt *types.Receipt, err error) (*hexutil.Uint64, error) {
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(receipt.GasUsed)
+	return &ret, nil
+}
+
+// CrossShardReceipt represents a cross-shard transfer received by this shard.
+type CrossShardReceipt struct {
+	receipt *types.CXReceipt
+	block   *Block
+}
+
+func (c *CrossShardReceipt) TransactionHash(ctx context.Context) common.Hash {
+	return c.receipt.TxHash
+}
+
+func (c *CrossShardReceipt) From(ctx context.Context) common.Address {
+	return c.receipt.From
+}
+
+func (c *CrossShardReceipt) To(ctx context.Context) *common.Address {
+	return c.receipt.To
+}
+
+func (c *CrossShardReceipt) ShardID(ctx context.Context) int32 {
+	return int32(c.receipt.ShardID)
+}
+
+func (c *CrossShardReceipt) ToShardID(ctx context.Context) int32 {
+	return int32(c.receipt.ToShardID)
+}
+
+func (c *CrossShardReceipt) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*c.receipt.Amount)
+}
+
+func (c *CrossShardReceipt) Block(ctx context.Context) *Block {
+	return c.block
+}
+
+// Delegation represents a delegation to a validator.
+type Delegation struct {
+	delegation *staking.Delegation
+}
+
+func (d *Delegation) DelegatorAddress(ctx context.Context) common.Address {
+	return d.delegation.DelegatorAddress
+}
+
+func (d *Delegation) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*d.delegation.Amount)
+}
+
+func (d *Delegation) Reward(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*d.delegation.Reward)
+}
+
+// Validator represents a validator at a particular block.
+type Validator struct {
+	wrapper *staking.ValidatorWrapper
+}
+
+func (v *Validator) Address(ctx context.Context) common.Address {
+	return v.wrapper.Address
+}
+
+func (v *Validator) BlsPublicKeys(ctx context.Context) []hexutil.Bytes {
+	ret := make([]hexutil.Bytes, 0, len(v.wrapper.SlotPubKeys))
+	for i := range v.wrapper.SlotPubKeys {
+		ret = append(ret, hexutil.Bytes(v.wrapper.SlotPubKeys[i][:]))
+	}
+	return ret
+}
+
+func (v *Validator) Name(ctx context.Context) string {
+	return v.wrapper.Description.Name
+}
+
+func (v *Validator) Identity(ctx context.Context) string {
+	return v.wrapper.Description.Identity
+}
+
+func (v *Validator) Website(ctx context.Context) string {
+	return v.wrapper.Description.Website
+}
+
+func (v *Validator) SecurityContact(ctx context.Context) string {
+	return v.wrapper.Description.SecurityContact
+}
+
+func (v *Validator) Details(ctx context.Context) string {
+	return v.wrapper.Description.Details
+}
+
+func (v *Validator) CommissionRate(ctx context.Context) string {
+	return v.wrapper.Commission.Rate.String()
+}
+
+func (v *Validator) MaxCommissionRate(ctx context.Context) string {
+	return v.wrapper.Commission.MaxRate.String()
+}
+
+func (v *Validator) MaxChangeRate(ctx context.Context) string {
+	return v.wrapper.Commission.MaxChangeRate.String()
+}
+
+func (v *Validator) MinSelfDelegation(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*v.wrapper.MinSelfDelegation)
+}
+
+func (v *Validator) MaxTotalDelegation(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*v.wrapper.MaxTotalDelegation)
+}
+
+func (v *Validator) TotalDelegation(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*v.wrapper.TotalDelegation())
+}
+
+func (v *Validator) Status(ctx context.Context) string {
+	return v.wrapper.Status.String()
+}
+
+func (v *Validator) LastEpochInCommittee(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(v.wrapper.LastEpochInCommittee.Uint64())
+}
+
+func (v *Validator) CreationHeight(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(v.wrapper.CreationHeight.Uint64())
+}
+
+func (v *Validator) Delegations(ctx context.Context) []*Delegation {
+	ret := make([]*Delegation, 0, len(v.wrapper.Delegations))
+	for i := range v.wrapper.Delegations {
+		ret = append(ret, &Delegation{delegation: &v.wrapper.Delegations[i]})
+	}
+	return ret
+}
+
+// Block represents a block of this shard. hmy and numberOrHash are mandatory,
+// and the numberOrHash of a block whose hash is known must refer to it by
+// hash. All other fields are lazily fetched when required.
+type Block struct {
+	hmy          *hmy.Harmony
+	numberOrHash rpc.BlockNumberOrHash
+	header       *block.Header
+	block        *types.Block
+	receipts     types.Receipts
+}
+
+// newBlockByHash returns a block resolver for the block of the given hash.
+func newBlockByHash(hmy *hmy.Harmony, hash common.Hash) *Block {
+	return &Block{
+		hmy:          hmy,
+		numberOrHash: rpc.BlockNumberOrHashWithHash(hash, false),
+	}
+}
+
+// resolve returns the internal Block object representing this block, fetching
+// it if necessary.
+func (b *Block) resolve(ctx context.Context) (*types.Block, error) {
+	if b.block != nil {
+		return b.block, nil
+	}
+	blk, err := b.hmy.BlockByNumberOrHash(ctx, b.numberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil {
+		return nil, errBlockNotFound
+	}
+	b.block = blk
+	b.header = blk.Header()
+	return b.block, nil
+}
+
+// resolveHeader returns the internal Header object for this block, fetching it
+// if necessary. Call this function instead of `resolve` unless you need the
+// additional data (transactions and receipts proofs).
+func (b *Block) resolveHeader(ctx context.Context) (*block.Header, error) {
+	if b.header != nil {
+		return b.header, nil
+	}
+	if hash, ok := b.numberOrHash.Hash(); ok {
+		b.header = b.hmy.BlockChain.GetHeaderByHash(hash)
+	} else if number, ok := b.numberOrHash.Number(); ok {
+		header, err := b.hmy.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		b.header = header
+	}
+	if b.header == nil {
+		return nil, errBlockNotFound
+	}
+	return b.header, nil
+}
+
+// resolveReceipts returns the list of receipts for this block, fetching them
+// if necessary.
+func (b *Block) resolveReceipts(ctx context.Context) (types.Receipts, error) {
+	if b.receipts != nil {
+		return b.receipts, nil
+	}
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := b.hmy.GetReceipts(ctx, header.Hash())
+	if err != nil {
+		return nil, err
+	}
+	b.receipts = receipts
+	return receipts, nil
+}
+
+// stateNumberOrHash returns the reference to the state of this block.
+func (b *Block) stateNumberOrHash(ctx context.Context) (rpc.BlockNumberOrHash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return rpc.BlockNumberOrHash{}, err
+	}
+	return rpc.BlockNumberOrHashWithHash(header.Hash(), false), nil
+}
+
+func (b *Block) Number(ctx context.Context) (hexutil.Uint64, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.Number().Uint64()), nil
+}
+
+func (b *Block) Hash(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Hash(), nil
+}
+
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if header.Number().Sign() == 0 {
+		return nil, nil
+	}
+	return newBlockByHash(b.hmy, header.ParentHash()), nil
+}
+
+func (b *Block) Epoch(ctx context.Context) (hexutil.Uint64, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.Epoch().Uint64()), nil
+}
+
+func (b *Block) ShardID(ctx context.Context) (int32, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(header.ShardID()), nil
+}
+
+func (b *Block) ViewID(ctx context.Context) (hexutil.Uint64, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.ViewID().Uint64()), nil
+}
+
+func (b *Block) TransactionsRoot(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.TxHash(), nil
+}
+
+func (b *Block) StateRoot(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Root(), nil
+}
+
+func (b *Block) ReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.ReceiptHash(), nil
+}
+
+func (b *Block) OutgoingReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.OutgoingReceiptHash(), nil
+}
+
+func (b *Block) IncomingReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.IncomingReceiptHash(), nil
+}
+
+func (b *Block) Miner(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		hmy:          b.hmy,
+		address:      header.Coinbase(),
+		numberOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (b *Block) ExtraData(ctx context.Context) (hexutil.Bytes, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(header.Extra()), nil
+}
+
+func (b *Block) GasLimit(ctx context.Context) (hexutil.Uint64, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.GasLimit()), nil
+}
+
+func (b *Block) GasUsed(ctx context.Context) (hexutil.Uint64, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.GasUsed()), nil
+}
+
+func (b *Block) BaseFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header.BaseFee() == nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(header.BaseFee()), nil
+}
+
+func (b *Block) Timestamp(ctx context.Context) (hexutil.Uint64, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.Time().Uint64()), nil
+}
+
+func (b *Block) LogsBloom(ctx context.Context) (hexutil.Bytes, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	bloom := header.Bloom()
+	return hexutil.Bytes(bloom.Bytes()), nil
+}
+
+func (b *Block) MixHash(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.MixDigest(), nil
+}
+
+func (b *Block) TransactionCount(ctx context.Context) (*int32, error) {
+	blk, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	count := int32(len(blk.Transactions()))
+	return &count, nil
+}
+
+func (b *Block) Transactions(ctx context.Context) (*[]*Transaction, error) {
+	blk, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(blk.Transactions()))
+	for i, tx := range blk.Transactions() {
+		ret = append(ret, &Transaction{
+			hmy:   b.hmy,
+			hash:  tx.Hash(),
+			tx:    tx,
+			block: b,
+			index: uint64(i),
+		})
+	}
+	return &ret, nil
+}
+
+func (b *Block) TransactionAt(ctx context.Context, args struct{ Index int32 }) (*Transaction, error) {
+	blk, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	txs := blk.Transactions()
+	if args.Index < 0 || int(args.Index) >= len(txs) {
+		return nil, nil
+	}
+	tx := txs[args.Index]
+	return &Transaction{
+		hmy:   b.hmy,
+		hash:  tx.Hash(),
+		tx:    tx,
+		block: b,
+		index: uint64(args.Index),
+	}, nil
+}
+
+func (b *Block) StakingTransactionCount(ctx context.Context) (*int32, error) {
+	blk, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	count := int32(len(blk.StakingTransactions()))
+	return &count, nil
+}
+
+func (b *Block) StakingTransactions(ctx context.Context) (*[]*StakingTransaction, error) {
+	blk, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*StakingTransaction, 0, len(blk.StakingTransactions()))
+	for i, tx := range blk.StakingTransactions() {
+		ret = append(ret, &StakingTransaction{
+			hmy:   b.hmy,
+			hash:  tx.Hash(),
+			tx:    tx,
+			block: b,
+			index: uint64(i),
+		})
+	}
+	return &ret, nil
+}
+
+func (b *Block) StakingTransactionAt(ctx context.Context, args struct{ Index int32 }) (*StakingTransaction, error) {
+	blk, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	txs := blk.StakingTransactions()
+	if args.Index < 0 || int(args.Index) >= len(txs) {
+		return nil, nil
+	}
+	tx := txs[args.Index]
+	return &StakingTransaction{
+		hmy:   b.hmy,
+		hash:  tx.Hash(),
+		tx:    tx,
+		block: b,
+		index: uint64(args.Index),
+	}, nil
+}
+
+func (b *Block) IncomingReceipts(ctx context.Context) (*[]*CrossShardReceipt, error) {
+	blk, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := []*CrossShardReceipt{}
+	for _, proof := range blk.IncomingReceipts() {
+		for _, receipt := range proof.Receipts {
+			ret = append(ret, &CrossShardReceipt{receipt: receipt, block: b})
+		}
+	}
+	return &ret, nil
+}
+
+// BlockFilterCriteria encapsulates criteria passed to a `logs` accessor inside
+// a block.
+type BlockFilterCriteria struct {
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+
+	// The Topic list restricts matches to particular event topics. Each event has a list
+	// of topics. Topics matches a prefix of that list. An empty element slice matches any
+	// topic. Non-empty elements represent an alternative that matches any of the
+	// contained topics.
+	//
+	// Examples:
+	// {} or nil          matches any topic list
+	// {{A}}              matches topic A in first position
+	// {{}, {B}}          matches any topic in first position, B in second position
+	// {{A}, {B}}         matches topic A in first position, B in second position
+	// {{A, B}}, {C, D}}  matches topic (A OR B) in first position, (C OR D) in second position
+	Topics *[][]common.Hash
+}
+
+// runFilter accepts a filter and executes it, returning all its results as
+// `Log` objects.
+func runFilter(ctx context.Context, hmy *hmy.Harmony, filter *filters.Filter) ([]*Log, error) {
+	logs, err := filter.Logs(ctx)
+	if err != nil || logs == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(logs))
+	for _, log := range logs {
+		ret = append(ret, &Log{
+			hmy:         hmy,
+			transaction: &Transaction{hmy: hmy, hash: log.TxHash},
+			log:         log,
+		})
+	}
+	return ret, nil
+}
+
+// filterArgs unpacks the optional addresses and topics of a log filter.
+func filterArgs(addresses *[]common.Address, topics *[][]common.Hash) ([]common.Address, [][]common.Hash) {
+	var (
+		addrs []common.Address
+		tpcs  [][]common.Hash
+	)
+	if addresses != nil {
+		addrs = *addresses
+	}
+	if topics != nil {
+		tpcs = *topics
+	}
+	return addrs, tpcs
+}
+
+func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addresses, topics := filterArgs(args.Filter.Addresses, args.Filter.Topics)
+	filter := filters.NewBlockFilter(b.hmy, header.Hash(), addresses, topics, false)
+	return runFilter(ctx, b.hmy, filter)
+}
+
+func (b *Block) Account(ctx context.Context, args struct {
+	Address common.Address
+}) (*Account, error) {
+	numberOrHash, err := b.stateNumberOrHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		hmy:          b.hmy,
+		address:      args.Address,
+		numberOrHash: numberOrHash,
+	}, nil
+}
+
+func (b *Block) Validator(ctx context.Context, args struct {
+	Address common.Address
+}) (*Validator, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wrapper, err := b.hmy.BlockChain.ReadValidatorInformationAtRoot(args.Address, header.Root())
+	if err == state.ErrAddressNotPresent {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{wrapper: wrapper}, nil
+}
+
+// CallResult encapsulates the result of an invocation of the `call` accessor.
+type CallResult struct {
+	data    hexutil.Bytes  // The return data from the call
+	gasUsed hexutil.Uint64 // The amount of gas used
+	status  hexutil.Uint64 // The return status of the call - 0 for failure or 1 for success.
+}
+
+func (c *CallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+func (c *CallResult) GasUsed() hexutil.Uint64 {
+	return c.gasUsed
+}
+
+func (c *CallResult) Status() hexutil.Uint64 {
+	return c.status
+}
+
+func (b *Block) Call(ctx context.Context, args struct {
+	Data hmy_rpc.CallArgs
+}) (*CallResult, error) {
+	numberOrHash, err := b.stateNumberOrHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := hmy_rpc.DoEVMCall(ctx, b.hmy, args.Data, numberOrHash, nil, hmy_rpc.CallTimeout)
+	if err != nil {
+		return nil, err
+	}
+	status := hexutil.Uint64(1)
+	if result.Failed() {
+		status = 0
+	}
+	return &CallResult{
+		data:    result.ReturnData,
+		gasUsed: hexutil.Uint64(result.UsedGas),
+		status:  status,
+	}, nil
+}
+
+func (b *Block) EstimateGas(ctx context.Context, args struct {
+	Data hmy_rpc.CallArgs
+}) (hexutil.Uint64, error) {
+	numberOrHash, err := b.stateNumberOrHash(ctx)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := hmy_rpc.EstimateGas(ctx, b.hmy, args.Data, numberOrHash, nil, b.hmy.RPCGasCap)
+	return hexutil.Uint64(gas), err
+}
+
+// BlockNumberArgs encapsulates arguments to accessors that specify a block number.
+type BlockNumberArgs struct {
+	// TODO: Ideally we could use input unions to allow the query to specify the
+	// block parameter by hash, block number, or tag but input unions aren't part of the
+	// standard GraphQL schema SDL yet, see: https://github.com/graphql/graphql-spec/issues/488
+	Block *hexutil.Uint64
+}
+
+// NumberOr returns the provided block number argument, or the "current" block number or hash if none
+// was provided.
+func (a BlockNumberArgs) NumberOr(current rpc.BlockNumberOrHash) rpc.BlockNumberOrHash {
+	if a.Block != nil {
+		blockNr := rpc.BlockNumber(*a.Block)
+		return rpc.BlockNumberOrHashWithNumber(blockNr)
+	}
+	return current
+}
+
+// NumberOrLatest returns the provided block number argument, or the "latest" block number if none
+// was provided.
+func (a BlockNumberArgs) NumberOrLatest() rpc.BlockNumberOrHash {
+	return a.NumberOr(rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+}
+
+// Pending represents the transactions waiting in the pool.
+type Pending struct {
+	hmy *hmy.Harmony
+}
+
+func (p *Pending) TransactionCount(ctx context.Context) (int32, error) {
+	txs, err := p.hmy.GetPoolTransactions()
+	return int32(len(txs)), err
+}
+
+func (p *Pending) Transactions(ctx context.Context) (*[]*Transaction, error) {
+	txs, err := p.hmy.GetPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+	ret := []*Transaction{}
+	for _, poolTx := range txs {
+		if tx, ok := poolTx.(*types.Transaction); ok {
+			ret = append(ret, &Transaction{hmy: p.hmy, hash: tx.Hash(), tx: tx})
+		}
+	}
+	return &ret, nil
+}
+
+func (p *Pending) StakingTransactions(ctx context.Context) (*[]*StakingTransaction, error) {
+	txs, err := p.hmy.GetPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+	ret := []*StakingTransaction{}
+	for _, poolTx := range txs {
+		if tx, ok := poolTx.(*staking.StakingTransaction); ok {
+			ret = append(ret, &StakingTransaction{hmy: p.hmy, hash: tx.Hash(), tx: tx})
+		}
+	}
+	return &ret, nil
+}
+
+// Resolver is the top-level object in the GraphQL hierarchy.
+type Resolver struct {
+	hmy *hmy.Harmony
+}
+
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+}) (*Block, error) {
+	var blk *Block
+	switch {
+	case args.Number != nil:
+		blk = &Block{
+			hmy:          r.hmy,
+			numberOrHash: rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(*args.Number)),
+		}
+	case args.Hash != nil:
+		blk = newBlockByHash(r.hmy, *args.Hash)
+	default:
+		blk = &Block{
+			hmy:          r.hmy,
+			numberOrHash: rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber),
+		}
+	}
+	// Resolve the header, return nil if it doesn't exist.
+	if _, err := blk.resolveHeader(ctx); err == errBlockNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return blk, nil
+}
+
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	From hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*Block, error) {
+	from := rpc.BlockNumber(args.From)
+
+	var to rpc.BlockNumber
+	if args.To != nil {
+		to = rpc.BlockNumber(*args.To)
+	} else {
+		to = rpc.BlockNumber(r.hmy.CurrentBlock().Number().Int64())
+	}
+	// block numbers beyond the int64 range wrap around to negative numbers
+	if from < 0 || to < from {
+		return []*Block{}, nil
+	}
+	if to-from >= maxBlocksRange {
+		return nil, errBlockRangeTooLarge
+	}
+	ret := []*Block{}
+	for i := from; i <= to; i++ {
+		blk := &Block{
+			hmy:          r.hmy,
+			numberOrHash: rpc.BlockNumberOrHashWithNumber(i),
+		}
+		// Resolve the header to check for existence.
+		if _, err := blk.resolveHeader(ctx); err == errBlockNotFound {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		ret = append(ret, blk)
+	}
+	return ret, nil
+}
+
+func (r *Resolver) Pending(ctx context.Context) *Pending {
+	return &Pending{r.hmy}
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx := &Transaction{
+		hmy:  r.hmy,
+		hash: args.Hash,
+	}
+	// Resolve the transaction; if it doesn't exist, return nil.
+	t, err := tx.resolve(ctx)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (r *Resolver) StakingTransaction(ctx context.Context, args struct{ Hash common.Hash }) (*StakingTransaction, error) {
+	tx := &StakingTransaction{
+		hmy:  r.hmy,
+		hash: args.Hash,
+	}
+	// Resolve the transaction; if it doesn't exist, return nil.
+	t, err := tx.resolve(ctx)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (r *Resolver) CrossShardReceipt(ctx context.Context, args struct{ Hash common.Hash }) (*CrossShardReceipt, error) {
+	cx, blockHash, _, _ := rawdb.ReadCXReceipt(r.hmy.ChainDb(), args.Hash)
+	if cx == nil {
+		return nil, nil
+	}
+	return &CrossShardReceipt{
+		receipt: cx,
+		block:   newBlockByHash(r.hmy, blockHash),
+	}, nil
+}
+
+func (r *Resolver) Validator(ctx context.Context, args struct {
+	Address common.Address
+	Block   *hexutil.Uint64
+}) (*Validator, error) {
+	blk := &Block{
+		hmy:          r.hmy,
+		numberOrHash: BlockNumberArgs{Block: args.Block}.NumberOrLatest(),
+	}
+	return blk.Validator(ctx, struct{ Address common.Address }{args.Address})
+}
+
+func (r *Resolver) Validators(ctx context.Context) []common.Address {
+	return r.hmy.GetAllValidatorAddresses()
+}
+
+// FilterCriteria encapsulates the arguments to `logs` on the root resolver object.
+type FilterCriteria struct {
+	FromBlock *hexutil.Uint64   // beginning of the queried range, nil means latest block
+	ToBlock   *hexutil.Uint64   // end of the range, nil means latest block
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+
+	// The Topic list restricts matches to particular event topics. Each event has a list
+	// of topics. Topics matches a prefix of that list. An empty element slice matches any
+	// topic. Non-empty elements represent an alternative that matches any of the
+	// contained topics.
+	//
+	// Examples:
+	// {} or nil          matches any topic list
+	// {{A}}              matches topic A in first position
+	// {{}, {B}}          matches any topic in first position, B in second position
+	// {{A}, {B}}         matches topic A in first position, B in second position
+	// {{A, B}}, {C, D}}  matches topic (A OR B) in first position, (C OR D) in second position
+	Topics *[][]common.Hash
+}
+
+func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria }) ([]*Log, error) {
+	// Convert the RPC block numbers into internal representations
+	begin := rpc.LatestBlockNumber.Int64()
+	if args.Filter.FromBlock != nil {
+		begin = int64(*args.Filter.FromBlock)
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if args.Filter.ToBlock != nil {
+		end = int64(*args.Filter.ToBlock)
+	}
+	addresses, topics := filterArgs(args.Filter.Addresses, args.Filter.Topics)
+	// Construct the range filter
+	filter := filters.NewRangeFilter(r.hmy, begin, end, addresses, topics, false)
+	return runFilter(ctx, r.hmy, filter)
+}
+
+func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	price, err := r.hmy.SuggestPrice(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*price), nil
+}
+
+func (r *Resolver) ChainID(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(r.hmy.EthChainID)
+}
+
+func (r *Resolver) ShardID(ctx context.Context) int32 {
+	return int32(r.hmy.ShardID)
+}
+
+func (r *Resolver) ProtocolVersion(ctx context.Context) int32 {
+	return int32(r.hmy.ProtocolVersion())
+}
@@ -0,0 +1,273 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrawdb "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/graph-gophers/graphql-go"
+
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/hmy"
+	chain2 "github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/harmony-one/harmony/staking/effective"
+	"github.com/harmony-one/harmony/staking/network"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+// testShardID is the beacon shard, which needs no separate beacon chain
+const testShardID = 0
+
+var (
+	testKey, _       = crypto.GenerateKey()
+	testAddress      = crypto.PubkeyToAddress(testKey.PublicKey)
+	testValidator    = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testLogAddress   = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	testLogTopic     = common.HexToHash("0x03")
+	testStake        = new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+	testChainConfig  = params.TestChainConfig
+	testBlockFactory = blockfactory.ForTest
+)
+
+// testNodeAPI serves the chain of a single shard, the other node APIs are not
+// used by the resolvers under test.
+type testNodeAPI struct {
+	hmy.NodeAPI
+	chain core.BlockChain
+}
+
+func (n *testNodeAPI) Blockchain() core.BlockChain  { return n.chain }
+func (n *testNodeAPI) Beaconchain() core.BlockChain { return n.chain }
+
+// newTestResolver creates a chain with a genesis block and a block holding a
+// transfer emitting a log and a CollectRewards staking transaction. The state
+// of the block holds a validator.
+func newTestResolver(t *testing.T) (*Resolver, *types.Transaction, *staking.StakingTransaction) {
+	database := ethrawdb.NewMemoryDatabase()
+	gspec := core.Genesis{
+		Config:  testChainConfig,
+		Factory: testBlockFactory,
+		Alloc:   core.GenesisAlloc{testAddress: {Balance: testStake}},
+		ShardID: testShardID,
+	}
+	genesis := gspec.MustCommit(database)
+	if err := rawdb.WriteValidatorList(database, []common.Address{testValidator}); err != nil {
+		t.Fatal(err)
+	}
+	chain, err := core.NewBlockChain(
+		database, state.NewDatabase(database), nil, nil, gspec.Config, chain2.NewEngine(), vm.Config{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(chain.Stop)
+
+	statedb, err := chain.StateAt(genesis.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := statedb.UpdateValidatorWrapper(testValidator, &staking.ValidatorWrapper{
+		Validator: staking.Validator{
+			Address:              testValidator,
+			SlotPubKeys:          []bls.SerializedPublicKey{{0x01}},
+			LastEpochInCommittee: big.NewInt(0),
+			MinSelfDelegation:    testStake,
+			MaxTotalDelegation:   testStake,
+			Status:               effective.Active,
+			Commission: staking.Commission{
+				CommissionRates: staking.CommissionRates{
+					Rate:          numeric.MustNewDecFromStr("0.1"),
+					MaxRate:       numeric.MustNewDecFromStr("0.2"),
+					MaxChangeRate: numeric.MustNewDecFromStr("0.05"),
+				},
+				UpdateHeight: big.NewInt(0),
+			},
+			Description:    staking.Description{Name: "validator"},
+			CreationHeight: big.NewInt(0),
+		},
+		Delegations: staking.Delegations{staking.NewDelegation(testValidator, testStake)},
+		BlockReward: big.NewInt(0),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	root := statedb.IntermediateRoot(testChainConfig.IsS3(common.Big0))
+
+	tx, err := types.SignTx(
+		types.NewTransaction(0, testValidator, testShardID, big.NewInt(1), params.TxGas, big.NewInt(1e9), nil),
+		types.NewEIP155Signer(testChainConfig.ChainID), testKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stx, _ := staking.NewStakingTransaction(1, 1e6, big.NewInt(1e9), func() (staking.Directive, interface{}) {
+		return staking.DirectiveCollectRewards, staking.CollectRewards{DelegatorAddress: testAddress}
+	})
+	if stx, err = staking.Sign(stx, staking.NewEIP155Signer(testChainConfig.ChainID), testKey); err != nil {
+		t.Fatal(err)
+	}
+	receipt := &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: params.TxGas,
+		GasUsed:           params.TxGas,
+		TxHash:            tx.Hash(),
+		Logs: []*types.Log{{
+			Address:     testLogAddress,
+			Topics:      []common.Hash{testLogTopic},
+			Data:        []byte{0x01},
+			BlockNumber: 1,
+			TxHash:      tx.Hash(),
+		}},
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	stakingReceipt := &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 2 * params.TxGas,
+		GasUsed:           params.TxGas,
+		TxHash:            stx.Hash(),
+	}
+	receipts := types.Receipts{receipt, stakingReceipt}
+
+	header := testBlockFactory.NewHeader(common.Big0).With().
+		ParentHash(genesis.Hash()).
+		Number(big.NewInt(1)).
+		ShardID(testShardID).
+		Root(root).
+		GasLimit(genesis.GasLimit()).
+		GasUsed(2 * params.TxGas).
+		Time(new(big.Int).Add(genesis.Time(), common.Big1)).
+		Header()
+	block := types.NewBlock(header, types.Transactions{tx}, receipts, nil, nil, staking.StakingTransactions{stx})
+	if _, err := chain.WriteBlockWithState(block, receipts, nil, nil, network.EmptyPayout, statedb); err != nil {
+		t.Fatal(err)
+	}
+
+	txPool := core.NewTxPool(core.DefaultTxPoolConfig, testChainConfig, chain, types.NewTransactionErrorSink())
+	t.Cleanup(txPool.Stop)
+	harmony := hmy.New(&testNodeAPI{chain: chain}, txPool, core.NewCxPool(core.CxPoolSize), testShardID)
+	return &Resolver{harmony}, tx, stx
+}
+
+func TestResolver(t *testing.T) {
+	resolver, tx, stx := newTestResolver(t)
+	s := graphql.MustParseSchema(schema, resolver)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "block",
+			query: `{ block(number: 1) { number shardID transactionCount stakingTransactionCount } }`,
+			want:  `{"block":{"number":"0x1","shardID":0,"transactionCount":1,"stakingTransactionCount":1}}`,
+		},
+		{
+			name:  "unknown block",
+			query: `{ block(number: 2) { number } }`,
+			want:  `{"block":null}`,
+		},
+		{
+			name:  "blocks",
+			query: `{ blocks(from: 0, to: 1023) { number } }`,
+			want:  `{"blocks":[{"number":"0x0"},{"number":"0x1"}]}`,
+		},
+		{
+			name:    "blocks range cap",
+			query:   `{ blocks(from: 0, to: 1024) { number } }`,
+			wantErr: true,
+		},
+		{
+			name: "transaction",
+			query: fmt.Sprintf(
+				`{ transaction(hash: "%s") { hash index status gasUsed from { address } block { number } logs { data } } }`,
+				tx.Hash().Hex(),
+			),
+			want: fmt.Sprintf(
+				`{"transaction":{"hash":"%s","index":0,"status":"0x1","gasUsed":"0x5208","from":{"address":"%s"},"block":{"number":"0x1"},"logs":[{"data":"0x01"}]}}`,
+				tx.Hash().Hex(), hexutil.Encode(testAddress[:]),
+			),
+		},
+		{
+			name:  "unknown transaction",
+			query: fmt.Sprintf(`{ transaction(hash: "%s") { hash } }`, common.Hash{0x01}.Hex()),
+			want:  `{"transaction":null}`,
+		},
+		{
+			name: "staking transaction",
+			query: fmt.Sprintf(
+				`{ stakingTransaction(hash: "%s") { type nonce index delegatorAddress validatorAddress block { number } } }`,
+				stx.Hash().Hex(),
+			),
+			want: fmt.Sprintf(
+				`{"stakingTransaction":{"type":"CollectRewards","nonce":"0x1","index":0,"delegatorAddress":"%s","validatorAddress":null,"block":{"number":"0x1"}}}`,
+				hexutil.Encode(testAddress[:]),
+			),
+		},
+		{
+			name:  "logs",
+			query: `{ logs(filter: {fromBlock: 0, toBlock: 1}) { data topics account { address } transaction { hash } } }`,
+			want: fmt.Sprintf(
+				`{"logs":[{"data":"0x01","topics":["%s"],"account":{"address":"%s"},"transaction":{"hash":"%s"}}]}`,
+				testLogTopic.Hex(), hexutil.Encode(testLogAddress[:]), tx.Hash().Hex(),
+			),
+		},
+		{
+			name:  "validator",
+			query: fmt.Sprintf(`{ validator(address: "%s") { address name totalDelegation } }`, testValidator.Hex()),
+			want: fmt.Sprintf(
+				`{"validator":{"address":"%s","name":"validator","totalDelegation":"%s"}}`,
+				hexutil.Encode(testValidator[:]), hexutil.EncodeBig(testStake),
+			),
+		},
+		{
+			name:  "unknown validator",
+			query: fmt.Sprintf(`{ validator(address: "%s") { address } }`, testAddress.Hex()),
+			want:  `{"validator":null}`,
+		},
+		{
+			name:  "validators",
+			query: `{ validators }`,
+			want:  fmt.Sprintf(`{"validators":["%s"]}`, hexutil.Encode(testValidator[:])),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := s.Exec(context.Background(), test.query, "", nil)
+			if test.wantErr {
+				if len(resp.Errors) == 0 {
+					t.Fatalf("expected error, got %s", resp.Data)
+				}
+				return
+			}
+			if len(resp.Errors) > 0 {
+				t.Fatalf("unexpected errors: %v", resp.Errors)
+			}
+			var have, want interface{}
+			if err := json.Unmarshal(resp.Data, &have); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(test.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(have, want) {
+				t.Errorf("response mismatch:\nhave %s\nwant %s", resp.Data, test.want)
+			}
+		})
+	}
+}
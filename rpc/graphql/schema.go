@@ -0,0 +1,431 @@
+package graphql
+
+// schema is the EIP-1767 schema, without the fields which have no meaning on
+// Harmony (e.g. ommers and difficulty), extended with the staking
+// transactions, validators and cross-shard receipts.
+const schema string = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    # An empty byte string is represented as '0x'. Byte strings must have an even number of hexadecimal nybbles.
+    scalar Bytes
+    # BigInt is a large integer. Input is accepted as either a JSON number or as a string.
+    # Strings may be either decimal or 0x-prefixed hexadecimal. Output values are all
+    # 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer.
+    scalar Long
+
+    schema {
+        query: Query
+    }
+
+    # Account is an account at a particular block.
+    type Account {
+        # Address is the address owning the account.
+        address: Address!
+        # Balance is the balance of the account, in atto.
+        balance: BigInt!
+        # TransactionCount is the number of transactions sent from this account,
+        # or in the case of a contract, the number of contracts created. Otherwise
+        # known as the nonce.
+        transactionCount: Long!
+        # Code contains the smart contract code for this account, if the account
+        # is a (non-self-destructed) contract.
+        code: Bytes!
+        # Storage provides access to the storage of a contract account, indexed
+        # by its 32 byte slot identifier.
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    # Log is an event log.
+    type Log {
+        # Index is the index of this log in the block.
+        index: Int!
+        # Account is the account which generated this log - this will always
+        # be a contract account.
+        account(block: Long): Account!
+        # Topics is a list of 0-4 indexed topics for the log.
+        topics: [Bytes32!]!
+        # Data is unindexed data for this log.
+        data: Bytes!
+        # Transaction is the transaction that generated this log entry.
+        transaction: Transaction!
+    }
+
+    # Transaction is a plain (non-staking) transaction.
+    type Transaction {
+        # Hash is the hash of this transaction.
+        hash: Bytes32!
+        # EthHash is the hash of this transaction in its Ethereum form.
+        ethHash: Bytes32!
+        # Type is the EIP-2718 type of this transaction.
+        type: Int!
+        # Nonce is the nonce of the account this transaction was generated with.
+        nonce: Long!
+        # Index is the index of this transaction in the parent block. This will
+        # be null if the transaction has not yet been mined.
+        index: Int
+        # From is the account that sent this transaction - this will always be
+        # an externally owned account.
+        from(block: Long): Account!
+        # To is the account the transaction was sent to. This is null for
+        # contract-creating transactions.
+        to(block: Long): Account
+        # ShardID is the shard the transaction was sent from.
+        shardID: Int!
+        # ToShardID is the shard the transaction was sent to. It differs from
+        # shardID for cross-shard transactions.
+        toShardID: Int!
+        # Value is the value, in atto, sent along with this transaction.
+        value: BigInt!
+        # GasPrice is the price offered for gas, in atto per unit.
+        gasPrice: BigInt!
+        # MaxFeePerGas is the maximum fee per gas offered by an EIP-1559
+        # transaction, or null for other transactions.
+        maxFeePerGas: BigInt
+        # MaxPriorityFeePerGas is the maximum tip per gas offered by an
+        # EIP-1559 transaction, or null for other transactions.
+        maxPriorityFeePerGas: BigInt
+        # Gas is the maximum amount of gas this transaction can consume.
+        gas: Long!
+        # InputData is the data supplied to the target of the transaction.
+        inputData: Bytes!
+        # Block is the block this transaction was mined in. This will be null if
+        # the transaction has not yet been mined.
+        block: Block
+
+        # Status is the return status of the transaction. This will be 1 if the
+        # transaction succeeded, or 0 if it failed (due to a revert, or due to
+        # running out of gas). If the transaction has not yet been mined, this
+        # field will be null.
+        status: Long
+        # GasUsed is the amount of gas that was used processing this transaction.
+        # If the transaction has not yet been mined, this field will be null.
+        gasUsed: Long
+        # CumulativeGasUsed is the total gas used in the block up to and including
+        # this transaction. If the transaction has not yet been mined, this field
+        # will be null.
+        cumulativeGasUsed: Long
+        # CreatedContract is the account that was created by a contract creation
+        # transaction. If the transaction was not a contract creation transaction,
+        # or it has not yet been mined, this field will be null.
+        createdContract(block: Long): Account
+        # Logs is a list of log entries emitted by this transaction. If the
+        # transaction has not yet been mined, this field will be null.
+        logs: [Log!]
+    }
+
+    # StakingTransaction is a transaction carrying a staking directive.
+    type StakingTransaction {
+        # Hash is the hash of this staking transaction.
+        hash: Bytes32!
+        # Type is the staking directive, e.g. Delegate or CollectRewards.
+        type: String!
+        # Nonce is the nonce of the account this transaction was generated with.
+        nonce: Long!
+        # Index is the index of this transaction among the staking transactions
+        # of the parent block. This will be null if the transaction has not yet
+        # been mined.
+        index: Int
+        # From is the account that sent this transaction.
+        from(block: Long): Account!
+        # ValidatorAddress is the validator the directive applies to, or null
+        # for CollectRewards.
+        validatorAddress: Address
+        # DelegatorAddress is the delegator of a Delegate, Undelegate or
+        # CollectRewards directive, or null for the other directives.
+        delegatorAddress: Address
+        # Amount is the amount, in atto, staked by a CreateValidator or
+        # Delegate directive or unstaked by an Undelegate directive, or null for
+        # the other directives.
+        amount: BigInt
+        # GasPrice is the price offered for gas, in atto per unit.
+        gasPrice: BigInt!
+        # Gas is the maximum amount of gas this transaction can consume.
+        gas: Long!
+        # Block is the block this transaction was mined in. This will be null if
+        # the transaction has not yet been mined.
+        block: Block
+        # Status is the return status of the transaction, or null if it has not
+        # yet been mined.
+        status: Long
+        # GasUsed is the amount of gas that was used processing this transaction.
+        # If the transaction has not yet been mined, this field will be null.
+        gasUsed: Long
+        # Logs is a list of log entries emitted by this transaction. If the
+        # transaction has not yet been mined, this field will be null.
+        logs: [Log!]
+    }
+
+    # CrossShardReceipt is the receipt of a cross-shard transfer received by
+    # this shard.
+    type CrossShardReceipt {
+        # TransactionHash is the hash of the transaction in the source shard.
+        transactionHash: Bytes32!
+        # From is the sender in the source shard.
+        from: Address!
+        # To is the recipient in this shard.
+        to: Address
+        # ShardID is the source shard.
+        shardID: Int!
+        # ToShardID is the destination shard.
+        toShardID: Int!
+        # Amount is the value, in atto, transferred.
+        amount: BigInt!
+        # Block is the block of this shard which included the receipt.
+        block: Block
+    }
+
+    # Delegation is an amount staked by a delegator on a validator.
+    type Delegation {
+        # DelegatorAddress is the address of the delegator.
+        delegatorAddress: Address!
+        # Amount is the amount, in atto, delegated.
+        amount: BigInt!
+        # Reward is the amount, in atto, of unclaimed rewards.
+        reward: BigInt!
+    }
+
+    # Validator is a staked validator at a particular block.
+    type Validator {
+        # Address is the address of the validator.
+        address: Address!
+        # BLSPublicKeys are the BLS keys the validator signs blocks with.
+        blsPublicKeys: [Bytes!]!
+        # Name is the name the validator describes itself with.
+        name: String!
+        # Identity is the identity the validator describes itself with.
+        identity: String!
+        # Website is the website of the validator.
+        website: String!
+        # SecurityContact is the security contact of the validator.
+        securityContact: String!
+        # Details are the details the validator describes itself with.
+        details: String!
+        # CommissionRate is the commission rate of the validator, as a decimal.
+        commissionRate: String!
+        # MaxCommissionRate is the maximum commission rate of the validator, as
+        # a decimal.
+        maxCommissionRate: String!
+        # MaxChangeRate is the maximum change of the commission rate per
+        # epoch, as a decimal.
+        maxChangeRate: String!
+        # MinSelfDelegation is the minimum amount, in atto, the validator
+        # stakes itself.
+        minSelfDelegation: BigInt!
+        # MaxTotalDelegation is the maximum amount, in atto, which can be
+        # delegated to the validator.
+        maxTotalDelegation: BigInt!
+        # TotalDelegation is the amount, in atto, delegated to the validator.
+        totalDelegation: BigInt!
+        # Status is the eligibility of the validator for elections.
+        status: String!
+        # LastEpochInCommittee is the last epoch the validator was elected in.
+        lastEpochInCommittee: Long!
+        # CreationHeight is the number of the block the validator was created in.
+        creationHeight: Long!
+        # Delegations are the delegations to the validator.
+        delegations: [Delegation!]!
+    }
+
+    # BlockFilterCriteria encapsulates log filter criteria for a filter applied
+    # to a single block.
+    input BlockFilterCriteria {
+        # Addresses is list of addresses that are of interest. If this list is
+        # empty, results will not be filtered by address.
+        addresses: [Address!]
+        # Topics list restricts matches to particular event topics. Each event has a list
+        # of topics. Topics matches a prefix of that list. An empty element array matches any
+        # topic. Non-empty elements represent an alternative that matches any of the
+        # contained topics.
+        #
+        # Examples:
+        #  - [] or nil          matches any topic list
+        #  - [[A]]              matches topic A in first position
+        #  - [[], [B]]          matches any topic in first position, B in second position
+        #  - [[A], [B]]         matches topic A in first position, B in second position
+        #  - [[A, B]], [C, D]]  matches topic (A OR B) in first position, (C OR D) in second position
+        topics: [[Bytes32!]!]
+    }
+
+    # Block is a block of this shard.
+    type Block {
+        # Number is the number of this block, starting at 0 for the genesis block.
+        number: Long!
+        # Hash is the block hash of this block.
+        hash: Bytes32!
+        # Parent is the parent block of this block.
+        parent: Block
+        # Epoch is the epoch of this block.
+        epoch: Long!
+        # ShardID is the shard of this block.
+        shardID: Int!
+        # ViewID is the consensus view this block was proposed in.
+        viewID: Long!
+        # TransactionsRoot is the keccak256 hash of the root of the trie of transactions in this block.
+        transactionsRoot: Bytes32!
+        # TransactionCount is the number of transactions in this block. if
+        # transactions are not available for this block, this field will be null.
+        transactionCount: Int
+        # StateRoot is the keccak256 hash of the state trie after this block was processed.
+        stateRoot: Bytes32!
+        # ReceiptsRoot is the keccak256 hash of the trie of transaction receipts in this block.
+        receiptsRoot: Bytes32!
+        # OutgoingReceiptsRoot is the keccak256 hash of the cross-shard receipts
+        # sent by this block.
+        outgoingReceiptsRoot: Bytes32!
+        # IncomingReceiptsRoot is the keccak256 hash of the cross-shard receipts
+        # received by this block.
+        incomingReceiptsRoot: Bytes32!
+        # Miner is the account of the leader which proposed this block.
+        miner(block: Long): Account!
+        # ExtraData is an arbitrary data field supplied by the leader.
+        extraData: Bytes!
+        # GasLimit is the maximum amount of gas that was available to transactions in this block.
+        gasLimit: Long!
+        # GasUsed is the amount of gas that was used executing transactions in this block.
+        gasUsed: Long!
+        # BaseFeePerGas is the EIP-1559 base fee per gas of this block, or null
+        # before the London fork.
+        baseFeePerGas: BigInt
+        # Timestamp is the unix timestamp at which this block was proposed.
+        timestamp: Long!
+        # LogsBloom is a bloom filter that can be used to check if a block may
+        # contain log entries matching a filter.
+        logsBloom: Bytes!
+        # MixHash is the hash that was used as an input to the VRF.
+        mixHash: Bytes32!
+        # Transactions is a list of transactions associated with this block. If
+        # transactions are unavailable for this block, this field will be null.
+        transactions: [Transaction!]
+        # TransactionAt returns the transaction at the specified index. If
+        # transactions are unavailable for this block, or if the index is out of
+        # bounds, this field will be null.
+        transactionAt(index: Int!): Transaction
+        # StakingTransactionCount is the number of staking transactions in this
+        # block, or null if they are unavailable.
+        stakingTransactionCount: Int
+        # StakingTransactions is a list of staking transactions associated with
+        # this block, or null if they are unavailable.
+        stakingTransactions: [StakingTransaction!]
+        # StakingTransactionAt returns the staking transaction at the specified
+        # index, or null if it is unavailable or the index is out of bounds.
+        stakingTransactionAt(index: Int!): StakingTransaction
+        # IncomingReceipts is a list of the cross-shard receipts received by
+        # this block, or null if they are unavailable.
+        incomingReceipts: [CrossShardReceipt!]
+        # Logs returns a filtered set of logs from this block.
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        # Account fetches an account at the current block's state.
+        account(address: Address!): Account!
+        # Validator fetches a validator at the current block's state, or null
+        # if there is no validator with the given address.
+        validator(address: Address!): Validator
+        # Call executes a local call operation at the current block's state.
+        call(data: CallData!): CallResult
+        # EstimateGas estimates the amount of gas that will be required for
+        # successful execution of a transaction at the current block's state.
+        estimateGas(data: CallData!): Long!
+    }
+
+    # CallData represents the data associated with a local contract call.
+    # All fields are optional.
+    input CallData {
+        # From is the address making the call.
+        from: Address
+        # To is the address the call is sent to.
+        to: Address
+        # Gas is the amount of gas sent with the call.
+        gas: Long
+        # GasPrice is the price, in atto, offered for each unit of gas.
+        gasPrice: BigInt
+        # Value is the value, in atto, sent along with the call.
+        value: BigInt
+        # Data is the data sent to the callee.
+        data: Bytes
+    }
+
+    # CallResult is the result of a local call operation.
+    type CallResult {
+        # Data is the return data of the called contract.
+        data: Bytes!
+        # GasUsed is the amount of gas used by the call, after any refunds.
+        gasUsed: Long!
+        # Status is the result of the call - 1 for success or 0 for failure.
+        status: Long!
+    }
+
+    # FilterCriteria encapsulates log filter criteria for searching log entries.
+    input FilterCriteria {
+        # FromBlock is the block at which to start searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        fromBlock: Long
+        # ToBlock is the block at which to stop searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        toBlock: Long
+        # Addresses is a list of addresses that are of interest. If this list is
+        # empty, results will not be filtered by address.
+        addresses: [Address!]
+        # Topics list restricts matches to particular event topics. Each event has a list
+        # of topics. Topics matches a prefix of that list. An empty element array matches any
+        # topic. Non-empty elements represent an alternative that matches any of the
+        # contained topics.
+        #
+        # Examples:
+        #  - [] or nil          matches any topic list
+        #  - [[A]]              matches topic A in first position
+        #  - [[], [B]]          matches any topic in first position, B in second position
+        #  - [[A], [B]]         matches topic A in first position, B in second position
+        #  - [[A, B]], [C, D]]  matches topic (A OR B) in first position, (C OR D) in second position
+        topics: [[Bytes32!]!]
+    }
+
+    # Pending represents the transactions waiting in the pool.
+    type Pending {
+        # TransactionCount is the number of plain and staking transactions in
+        # the pool.
+        transactionCount: Int!
+        # Transactions is a list of the plain transactions in the pool.
+        transactions: [Transaction!]
+        # StakingTransactions is a list of the staking transactions in the pool.
+        stakingTransactions: [StakingTransaction!]
+    }
+
+    type Query {
+        # Block fetches a block by number or by hash. If neither is
+        # supplied, the most recent known block is returned.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns all the blocks between two numbers, inclusive. If
+        # to is not supplied, it defaults to the most recent known block.
+        blocks(from: Long!, to: Long): [Block!]!
+        # Pending returns the transactions waiting in the pool.
+        pending: Pending!
+        # Transaction returns a transaction specified by its hash.
+        transaction(hash: Bytes32!): Transaction
+        # StakingTransaction returns a staking transaction specified by its hash.
+        stakingTransaction(hash: Bytes32!): StakingTransaction
+        # CrossShardReceipt returns the cross-shard receipt received by this
+        # shard for the transaction of the given hash in the source shard.
+        crossShardReceipt(hash: Bytes32!): CrossShardReceipt
+        # Validator returns the validator with the given address at the given
+        # block, or at the latest block if none is supplied.
+        validator(address: Address!, block: Long): Validator
+        # Validators returns the addresses of the validator candidates for the
+        # next epoch.
+        validators: [Address!]!
+        # Logs returns log entries matching the provided filter.
+        logs(filter: FilterCriteria!): [Log!]!
+        # GasPrice returns the node's estimate of a gas price sufficient to
+        # ensure a transaction is included in a timely fashion.
+        gasPrice: BigInt!
+        # ChainID returns the Ethereum compatible chain ID of this shard.
+        chainID: Long!
+        # ShardID returns the shard of this node.
+        shardID: Int!
+        # ProtocolVersion returns the current wire protocol version number.
+        protocolVersion: Int!
+    }
+`
@@ -0,0 +1,26 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/harmony-one/harmony/hmy"
+)
+
+// NewHandler returns a new `http.Handler` that will answer GraphQL queries
+// on the /graphql endpoint with the data of the given harmony instance.
+func NewHandler(hmy *hmy.Harmony) (http.Handler, error) {
+	q := Resolver{hmy}
+
+	s, err := graphql.ParseSchema(schema, &q)
+	if err != nil {
+		return nil, err
+	}
+	h := &relay.Handler{Schema: s}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", h)
+	mux.Handle("/graphql/", h)
+	return mux, nil
+}
@@ -41,10 +41,11 @@ const (
 	SetNodeToBackupMode      = "SetNodeToBackupMode"
 
 	// contract
-	GetCode      = "GetCode"
-	GetStorageAt = "GetStorageAt"
-	Call         = "Call"
-	DoEvmCall    = "DoEVMCall"
+	GetCode          = "GetCode"
+	GetStorageAt     = "GetStorageAt"
+	Call             = "Call"
+	DoEvmCall        = "DoEVMCall"
+	CreateAccessList = "CreateAccessList"
 
 	// net
 	PeerCount  = "PeerCount"
@@ -88,6 +89,8 @@ const (
 	TraceBlock         = "TraceBlock"
 	TraceTransaction   = "TraceTransaction"
 	TraceCall          = "TraceCall"
+	GetBadBlocks       = "GetBadBlocks"
+	ReplayBadBlock     = "ReplayBadBlock"
 
 	// tracer parity
 	Block       = "Block"
@@ -85,14 +85,14 @@ func (s *PublicPoolService) SendRawTransaction(
 
 	if s.version == Eth {
 		ethTx := new(types.EthTransaction)
-		if err := rlp.DecodeBytes(encodedTx, ethTx); err != nil {
+		if err := ethTx.UnmarshalBinary(encodedTx); err != nil {
 			return common.Hash{}, err
 		}
 		txHash = ethTx.Hash()
 		tx = ethTx.ConvertToHmy()
 	} else {
 		tx = new(types.Transaction)
-		if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		if err := tx.UnmarshalBinary(encodedTx); err != nil {
 			return common.Hash{}, err
 		}
 		txHash = tx.Hash()
@@ -112,7 +112,7 @@ func (s *PublicPoolService) SendRawTransaction(
 	// Log submission
 	if tx.To() == nil {
 		signer := types.MakeSigner(s.hmy.ChainConfig(), s.hmy.CurrentBlock().Epoch())
-		ethSigner := types.NewEIP155Signer(s.hmy.ChainConfig().EthCompatibleChainID)
+		ethSigner := types.MakeEthSigner(s.hmy.ChainConfig(), s.hmy.CurrentBlock().Epoch())
 
 		if tx.IsEthCompatible() {
 			signer = ethSigner
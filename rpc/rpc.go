@@ -3,6 +3,7 @@ package rpc
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
 	"time"
 
@@ -41,24 +42,33 @@ const (
 	netV1Namespace = "netv1"
 	netV2Namespace = "netv2"
 	web3Namespace  = "web3"
+	devNamespace   = "evm"
+
+	// graphqlMethod is the method name of the GraphQL queries in the rate limits
+	graphqlMethod = "graphql"
 )
 
 var (
 	// HTTPModules ..
-	HTTPModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "explorer"}
+	HTTPModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "explorer", devNamespace}
 	// WSModules ..
-	WSModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "web3"}
+	WSModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "web3", devNamespace}
 
 	httpListener     net.Listener
 	httpHandler      *rpc.Server
 	wsListener       net.Listener
 	wsHandler        *rpc.Server
+	graphqlListener  net.Listener
+	ipcListener      net.Listener
+	ipcHandler       *rpc.Server
 	httpEndpoint     = ""
 	httpAuthEndpoint = ""
 	wsEndpoint       = ""
 	wsAuthEndpoint   = ""
+	graphqlEndpoint  = ""
 	httpVirtualHosts = []string{"*"}
 	httpTimeouts     = rpc.DefaultHTTPTimeouts
+	rpcLimits        = rpc.Limits{}
 	httpOrigins      = []string{"*"}
 	wsOrigins        = []string{"*"}
 )
@@ -71,8 +81,9 @@ func (n Version) Namespace() string {
 	return HTTPModules[n]
 }
 
-// StartServers starts the http & ws servers
-func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerConfig, rpcOpt harmony.RpcOptConfig) error {
+// StartServers starts the http, ws, ipc & graphql servers
+func StartServers(hmy *hmy.Harmony, apis []rpc.API, graphqlHandler http.Handler, config nodeconfig.RPCServerConfig, rpcOpt harmony.RpcOptConfig) error {
+	nodeAPIs := apis[:len(apis):len(apis)]
 	apis = append(apis, getAPIs(hmy, config)...)
 	authApis := append(apis, getAuthAPIs(hmy, config.DebugEnabled, config.RateLimiterEnabled, config.RequestsPerSecond)...)
 	// load method filter from file (if exist)
@@ -85,6 +96,21 @@ func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerC
 	} else {
 		rmf.ExposeAll()
 	}
+	trustedProxies := make([]*net.IPNet, 0, len(rpcOpt.TrustedProxies))
+	for _, cidr := range rpcOpt.TrustedProxies {
+		_, proxies, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxies %q: %v", cidr, err)
+		}
+		trustedProxies = append(trustedProxies, proxies)
+	}
+	rpcLimits = rpc.Limits{
+		BatchRequestLimit:    rpcOpt.BatchRequestLimit,
+		BatchResponseMaxSize: rpcOpt.BatchResponseMaxSize,
+		MethodRateLimits:     rpcOpt.MethodRateLimits,
+		IPRateLimit:          rpcOpt.IPRateLimit,
+		TrustedProxies:       trustedProxies,
+	}
 	if config.HTTPEnabled {
 		httpEndpoint = fmt.Sprintf("%v:%v", config.HTTPIp, config.HTTPPort)
 		if err := startHTTP(apis, &rmf); err != nil {
@@ -109,10 +135,23 @@ func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerC
 		}
 	}
 
+	if config.IPCEnabled {
+		if err := startIPC(getIPCAPIs(hmy, nodeAPIs, authApis, config), config.IPCPath); err != nil {
+			return err
+		}
+	}
+
+	if config.GraphQLEnabled && graphqlHandler != nil {
+		graphqlEndpoint = fmt.Sprintf("%v:%v", config.GraphQLIp, config.GraphQLPort)
+		if err := startGraphQL(graphqlHandler); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// StopServers stops the http & ws servers
+// StopServers stops the http, ws, ipc & graphql servers
 func StopServers() error {
 	if httpListener != nil {
 		if err := httpListener.Close(); err != nil {
@@ -140,6 +179,29 @@ func StopServers() error {
 		wsHandler.Stop()
 		wsHandler = nil
 	}
+	if ipcListener != nil {
+		endpoint := ipcListener.Addr().String()
+		if err := ipcListener.Close(); err != nil {
+			return err
+		}
+		ipcListener = nil
+		utils.Logger().Info().
+			Str("url", endpoint).
+			Msg("IPC endpoint closed")
+	}
+	if ipcHandler != nil {
+		ipcHandler.Stop()
+		ipcHandler = nil
+	}
+	if graphqlListener != nil {
+		if err := graphqlListener.Close(); err != nil {
+			return err
+		}
+		graphqlListener = nil
+		utils.Logger().Info().
+			Str("url", fmt.Sprintf("http://%s", graphqlEndpoint)).
+			Msg("GraphQL endpoint closed")
+	}
 	return nil
 }
 
@@ -150,6 +212,21 @@ func getAuthAPIs(hmy *hmy.Harmony, debugEnable bool, rateLimiterEnable bool, rat
 	}
 }
 
+// getIPCAPIs returns all the API methods for the IPC interface, which only
+// local users can reach: the debug APIs are always exposed and the requests
+// are not rate limited. The node APIs, e.g. the filters, are shared with the
+// other endpoints, as are the services of the authenticated endpoints when
+// they already have no rate limit.
+func getIPCAPIs(hmy *hmy.Harmony, nodeAPIs, authApis []rpc.API, config nodeconfig.RPCServerConfig) []rpc.API {
+	if config.DebugEnabled && !config.RateLimiterEnabled {
+		return authApis
+	}
+	config.DebugEnabled = true
+	config.RateLimiterEnabled = false
+	apis := append(nodeAPIs[:len(nodeAPIs):len(nodeAPIs)], getAPIs(hmy, config)...)
+	return append(apis, getAuthAPIs(hmy, true, false, config.RequestsPerSecond)...)
+}
+
 // getAPIs returns all the API methods for the RPC interface
 func getAPIs(hmy *hmy.Harmony, config nodeconfig.RPCServerConfig) []rpc.API {
 	publicAPIs := []rpc.API{
@@ -192,27 +269,26 @@ func getAPIs(hmy *hmy.Harmony, config nodeconfig.RPCServerConfig) []rpc.API {
 		)
 	}
 
-	publicDebugAPIs := []rpc.API{
+	if config.DebugEnabled {
+		return append(publicAPIs, getDebugAPIs(hmy)...)
+	}
+	return publicAPIs
+}
+
+// getDebugAPIs returns the public and private debug API methods
+func getDebugAPIs(hmy *hmy.Harmony) []rpc.API {
+	return []rpc.API{
 		//Public debug API
 		NewPublicDebugAPI(hmy, V1),
 		NewPublicDebugAPI(hmy, V2),
-	}
-
-	privateAPIs := []rpc.API{
 		NewPrivateDebugAPI(hmy, V1),
 		NewPrivateDebugAPI(hmy, V2),
 	}
-
-	if config.DebugEnabled {
-		apis := append(publicAPIs, publicDebugAPIs...)
-		return append(apis, privateAPIs...)
-	}
-	return publicAPIs
 }
 
 func startHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
 	httpListener, httpHandler, err = rpc.StartHTTPEndpoint(
-		httpEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts,
+		httpEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts, rpcLimits,
 	)
 	if err != nil {
 		return err
@@ -229,7 +305,7 @@ func startHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
 
 func startAuthHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
 	httpListener, httpHandler, err = rpc.StartHTTPEndpoint(
-		httpAuthEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts,
+		httpAuthEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts, rpcLimits,
 	)
 	if err != nil {
 		return err
@@ -245,7 +321,7 @@ func startAuthHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
 }
 
 func startWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
-	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsEndpoint, apis, WSModules, rmf, wsOrigins, true)
+	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsEndpoint, apis, WSModules, rmf, wsOrigins, true, rpcLimits)
 	if err != nil {
 		return err
 	}
@@ -258,7 +334,7 @@ func startWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
 }
 
 func startAuthWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
-	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsAuthEndpoint, apis, WSModules, rmf, wsOrigins, true)
+	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsAuthEndpoint, apis, WSModules, rmf, wsOrigins, true, rpcLimits)
 	if err != nil {
 		return err
 	}
@@ -269,3 +345,35 @@ func startAuthWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
 	fmt.Printf("Started Auth-WS server at: %v\n", wsAuthEndpoint)
 	return nil
 }
+
+func startIPC(apis []rpc.API, endpoint string) (err error) {
+	// The IPC endpoint is only reachable by local users, expose all methods
+	var rmf rpc.RpcMethodFilter
+	rmf.ExposeAll()
+	ipcListener, ipcHandler, err = rpc.StartIPCEndpoint(endpoint, apis, &rmf)
+	if err != nil {
+		return err
+	}
+
+	utils.Logger().Info().
+		Str("url", endpoint).
+		Msg("IPC endpoint opened")
+	fmt.Printf("Started IPC server at: %v\n", endpoint)
+	return nil
+}
+
+func startGraphQL(handler http.Handler) (err error) {
+	if graphqlListener, err = net.Listen("tcp", graphqlEndpoint); err != nil {
+		return err
+	}
+	handler = rpc.NewLimitedHandler(rpcLimits, graphqlMethod, handler)
+	go rpc.NewHTTPServer(httpOrigins, httpVirtualHosts, httpTimeouts, handler).Serve(graphqlListener)
+
+	utils.Logger().Info().
+		Str("url", fmt.Sprintf("http://%s/graphql", graphqlEndpoint)).
+		Str("cors", strings.Join(httpOrigins, ",")).
+		Str("vhosts", strings.Join(httpVirtualHosts, ",")).
+		Msg("GraphQL endpoint opened")
+	fmt.Printf("Started GraphQL server at: %v\n", graphqlEndpoint)
+	return nil
+}
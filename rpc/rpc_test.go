@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/internal/configs/harmony"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPC(t *testing.T) {
+	config := nodeconfig.RPCServerConfig{
+		IPCEnabled: true,
+		IPCPath:    filepath.Join(t.TempDir(), "harmony.ipc"),
+	}
+	require.NoError(t, StartServers(&hmy.Harmony{}, nil, nil, config, harmony.RpcOptConfig{}))
+	defer StopServers()
+
+	client, err := rpc.DialIPC(context.Background(), config.IPCPath)
+	require.NoError(t, err)
+	defer client.Close()
+
+	var v1 hexutil.Uint
+	require.NoError(t, client.Call(&v1, "hmy_protocolVersion"))
+	var v2 int
+	require.NoError(t, client.Call(&v2, "hmyv2_protocolVersion"))
+	require.Equal(t, int(v1), v2)
+}
+
+func TestGetIPCAPIs(t *testing.T) {
+	harmony := &hmy.Harmony{}
+	nodeAPIs := []rpc.API{NewPublicHarmonyAPI(harmony, V1)}
+	tests := []struct {
+		config      nodeconfig.RPCServerConfig
+		wantSharing bool
+	}{
+		{config: nodeconfig.RPCServerConfig{}},
+		{config: nodeconfig.RPCServerConfig{DebugEnabled: true, RateLimiterEnabled: true, RequestsPerSecond: 1}},
+		{config: nodeconfig.RPCServerConfig{DebugEnabled: true}, wantSharing: true},
+	}
+	for i, test := range tests {
+		apis := append(nodeAPIs, getAPIs(harmony, test.config)...)
+		authApis := append(apis, getAuthAPIs(harmony, test.config.DebugEnabled, test.config.RateLimiterEnabled, test.config.RequestsPerSecond)...)
+		ipcAPIs := getIPCAPIs(harmony, nodeAPIs, authApis, test.config)
+
+		// the node APIs, e.g. the filters, are never created again
+		require.True(t, ipcAPIs[0].Service == nodeAPIs[0].Service, "test %d", i)
+		require.Equal(t, test.wantSharing, ipcAPIs[len(ipcAPIs)-1].Service == authApis[len(authApis)-1].Service, "test %d", i)
+
+		var debugAPIs int
+		for _, api := range ipcAPIs {
+			switch s := api.Service.(type) {
+			case *PublicDebugService, *PrivateDebugService:
+				debugAPIs++
+			case *PublicBlockchainService:
+				require.Nil(t, s.limiter, "test %d: IPC requests are rate limited", i)
+			}
+		}
+		require.Equal(t, 4, debugAPIs, "test %d", i)
+	}
+}
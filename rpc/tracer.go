@@ -24,7 +24,9 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/types"
@@ -173,11 +175,99 @@ func (s *PublicTracerService) TraceTransaction(ctx context.Context, hash common.
 	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, config)
 }
 
+// BadBlockResult is a bad block returned by debug_getBadBlocks.
+type BadBlockResult struct {
+	Hash   common.Hash   `json:"hash"`
+	Number uint64        `json:"number"`
+	Header *block.Header `json:"header"`
+	Reason string        `json:"reason"`
+	// TxIndex and TxHash identify the transaction that failed to execute, if
+	// the block was rejected for a transaction.
+	TxIndex *int          `json:"txIndex,omitempty"`
+	TxHash  *common.Hash  `json:"txHash,omitempty"`
+	Peer    string        `json:"peer,omitempty"`
+	Time    int64         `json:"time"`
+	RLP     hexutil.Bytes `json:"rlp"`
+}
+
+// GetBadBlocks returns a page of the last blocks rejected by the chain, ordered
+// by descending block number. The page size defaults to 100.
+func (s *PublicTracerService) GetBadBlocks(ctx context.Context, pageIndex, pageSize *uint32) ([]*BadBlockResult, error) {
+	timer := DoMetricRPCRequest(GetBadBlocks)
+	defer DoRPCRequestDuration(GetBadBlocks, timer)
+
+	var index, size uint64 = 0, uint64(defaultPageSize)
+	if pageIndex != nil {
+		index = uint64(*pageIndex)
+	}
+	if pageSize != nil && *pageSize > 0 {
+		size = uint64(*pageSize)
+	}
+	badBlocks := s.hmy.BlockChain.BadBlocks()
+	results := []*BadBlockResult{}
+	if index*size >= uint64(len(badBlocks)) {
+		return results, nil
+	}
+	badBlocks = badBlocks[index*size:]
+	if uint64(len(badBlocks)) > size {
+		badBlocks = badBlocks[:size]
+	}
+	for _, bad := range badBlocks {
+		data, err := rlp.EncodeToBytes(bad.Block)
+		if err != nil {
+			DoMetricRPCQueryInfo(GetBadBlocks, FailedNumber)
+			return nil, err
+		}
+		result := &BadBlockResult{
+			Hash:   bad.Block.Hash(),
+			Number: bad.Block.NumberU64(),
+			Header: bad.Block.Header(),
+			Reason: bad.Reason.Error(),
+			Peer:   bad.Peer,
+			Time:   bad.Time.Unix(),
+			RLP:    data,
+		}
+		if bad.TxIndex >= 0 {
+			txIndex, txHash := bad.TxIndex, bad.TxHash
+			result.TxIndex, result.TxHash = &txIndex, &txHash
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// ReplayBadBlock re-executes a bad block on the state of its parent with the
+// given tracer, and returns the traces of its transactions up to the failing one
+// along with the state root and gas the local execution ended up with.
+func (s *PublicTracerService) ReplayBadBlock(ctx context.Context, hash common.Hash, config *hmy.TraceConfig) (*hmy.BadBlockReplay, error) {
+	timer := DoMetricRPCRequest(ReplayBadBlock)
+	defer DoRPCRequestDuration(ReplayBadBlock, timer)
+
+	bad, ok := s.hmy.BlockChain.BadBlock(hash)
+	if !ok {
+		DoMetricRPCQueryInfo(ReplayBadBlock, FailedNumber)
+		return nil, fmt.Errorf("bad block %#x not found", hash)
+	}
+	replay, err := s.hmy.ReplayBadBlock(ctx, bad.Block, config)
+	if err != nil {
+		DoMetricRPCQueryInfo(ReplayBadBlock, FailedNumber)
+		return nil, err
+	}
+	return replay, nil
+}
+
+// TraceCallConfig is the config for traceCall API. It holds one more
+// field to override the state for tracing.
+type TraceCallConfig struct {
+	hmy.TraceConfig
+	StateOverrides *StateOverride
+}
+
 // TraceCall lets you trace a given eth_call. It collects the structured logs created during the execution of EVM
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
 // You can provide -2 as a block number to trace on top of the pending block.
 // NOTE: Our version only supports block number as an input
-func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, config *hmy.TraceConfig) (interface{}, error) {
+func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, config *TraceCallConfig) (interface{}, error) {
 	timer := DoMetricRPCRequest(TraceCall)
 	defer DoRPCRequestDuration(TraceCall, timer)
 
@@ -202,9 +292,19 @@ func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, bloc
 		}
 	}
 
+	// Apply the customized state rules if required.
+	var traceConfig *hmy.TraceConfig
+	if config != nil {
+		if err := config.StateOverrides.Apply(statedb); err != nil {
+			DoMetricRPCQueryInfo(TraceCall, FailedNumber)
+			return nil, err
+		}
+		traceConfig = &config.TraceConfig
+	}
+
 	// Execute the trace
 	msg := args.ToMessage(s.hmy.RPCGasCap)
 	vmctx := core.NewEVMContext(msg, header, s.hmy.BlockChain, nil)
 	// Trace the transaction and return
-	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, config)
+	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, traceConfig)
 }
@@ -163,7 +163,7 @@ func (s *PublicTransactionService) GetStakingTransactionsCount(
 // EstimateGas returns an estimate of the amount of gas needed to execute the
 // given transaction against the current pending block.
 func (s *PublicTransactionService) EstimateGas(
-	ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash,
+	ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *StateOverride,
 ) (hexutil.Uint64, error) {
 	timer := DoMetricRPCRequest(RpcEstimateGas)
 	defer DoRPCRequestDuration(RpcEstimateGas, timer)
@@ -171,7 +171,7 @@ func (s *PublicTransactionService) EstimateGas(
 	if blockNrOrHash != nil {
 		bNrOrHash = *blockNrOrHash
 	}
-	gas, err := EstimateGas(ctx, s.hmy, args, bNrOrHash, nil)
+	gas, err := EstimateGas(ctx, s.hmy, args, bNrOrHash, overrides, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -315,8 +315,6 @@ func (s *PublicTransactionService) GetTransactionsHistory(
 	defer DoRPCRequestDuration(GetTransactionsHistory, timer)
 	// Fetch transaction history
 	var address string
-	var result []common.Hash
-	var err error
 	if strings.HasPrefix(args.Address, "one1") {
 		address = args.Address
 	} else {
@@ -331,17 +329,24 @@ func (s *PublicTransactionService) GetTransactionsHistory(
 			return nil, err
 		}
 	}
-	hashes, err := s.hmy.GetTransactionsHistory(address, args.TxType, args.Order)
+	query, err := newTxHistoryQuery(address, args)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTransactionsHistory, FailedNumber)
+		return nil, err
+	}
+	if query.Directive != nil {
+		DoMetricRPCQueryInfo(GetTransactionsHistory, FailedNumber)
+		return nil, errors.New("directive only filters the staking transactions history")
+	}
+	result, cursor, err := s.hmy.GetTransactionsHistory(query)
 	if err != nil {
 		DoMetricRPCQueryInfo(GetTransactionsHistory, FailedNumber)
 		return nil, err
 	}
-
-	result = returnHashesWithPagination(hashes, args.PageIndex, args.PageSize)
 
 	// Just hashes have same response format for all versions
 	if !args.FullTx {
-		return StructuredResponse{"transactions": result}, nil
+		return txHistoryResponse("transactions", result, cursor), nil
 	}
 
 	// Full transactions have different response format
@@ -357,7 +362,7 @@ func (s *PublicTransactionService) GetTransactionsHistory(
 			// Legacy behavior is to not return RPC errors
 		}
 	}
-	return StructuredResponse{"transactions": txs}, nil
+	return txHistoryResponse("transactions", txs, cursor), nil
 }
 
 // GetStakingTransactionsHistory returns the list of transactions hashes that involve a particular address.
@@ -369,8 +374,6 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 
 	// Fetch transaction history
 	var address string
-	var result []common.Hash
-	var err error
 	if strings.HasPrefix(args.Address, "one1") {
 		address = args.Address
 	} else {
@@ -389,7 +392,12 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 			return nil, nil
 		}
 	}
-	hashes, err := s.hmy.GetStakingTransactionsHistory(address, args.TxType, args.Order)
+	query, err := newTxHistoryQuery(address, args)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetStakingTransactionsHistory, FailedNumber)
+		return nil, err
+	}
+	result, cursor, err := s.hmy.GetStakingTransactionsHistory(query)
 	if err != nil {
 		utils.Logger().Debug().
 			Err(err).
@@ -399,11 +407,9 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 		return nil, nil
 	}
 
-	result = returnHashesWithPagination(hashes, args.PageIndex, args.PageSize)
-
 	// Just hashes have same response format for all versions
 	if !args.FullTx {
-		return StructuredResponse{"staking_transactions": result}, nil
+		return txHistoryResponse("staking_transactions", result, cursor), nil
 	}
 
 	// Full transactions have different response format
@@ -419,7 +425,7 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 			// Legacy behavior is to not return RPC errors
 		}
 	}
-	return StructuredResponse{"staking_transactions": txs}, nil
+	return txHistoryResponse("staking_transactions", txs, cursor), nil
 }
 
 // GetBlockTransactionCountByNumber returns the number of transactions in the block with the given block number.
@@ -821,23 +827,57 @@ func (s *PublicTransactionService) ResendCx(ctx context.Context, txID common.Has
 	return success, nil
 }
 
-// returnHashesWithPagination returns result with pagination (offset, page in TxHistoryArgs).
-func returnHashesWithPagination(hashes []common.Hash, pageIndex uint32, pageSize uint32) []common.Hash {
-	size := defaultPageSize
-	if pageSize > 0 {
-		size = pageSize
+// newTxHistoryQuery returns the query of the page of the transactions history
+// selected by the arguments. Without cursor, the page is selected by its index.
+func newTxHistoryQuery(address string, args TxHistoryArgs) (hmy.TxHistoryQuery, error) {
+	query := hmy.TxHistoryQuery{
+		Address:   address,
+		TxType:    args.TxType,
+		FromBlock: args.FromBlock,
+		ToBlock:   args.ToBlock,
+		Order:     args.Order,
+		Cursor:    args.Cursor,
+		PageIndex: args.PageIndex,
+		PageSize:  defaultPageSize,
 	}
-	if uint64(size)*uint64(pageIndex) >= uint64(len(hashes)) {
-		return make([]common.Hash, 0)
+	if args.PageSize > 0 {
+		query.PageSize = args.PageSize
 	}
-	if uint64(size)*uint64(pageIndex)+uint64(size) > uint64(len(hashes)) {
-		return hashes[size*pageIndex:]
+	if args.ToBlock != 0 && args.FromBlock > args.ToBlock {
+		return hmy.TxHistoryQuery{}, errors.New("fromBlock is after toBlock")
 	}
-	return hashes[size*pageIndex : size*pageIndex+size]
+	if args.Directive != "" {
+		directive, err := parseDirective(args.Directive)
+		if err != nil {
+			return hmy.TxHistoryQuery{}, err
+		}
+		query.Directive = &directive
+	}
+	return query, nil
+}
+
+func parseDirective(name string) (staking.Directive, error) {
+	for d := staking.DirectiveCreateValidator; d <= staking.DirectiveCollectRewards; d++ {
+		if strings.EqualFold(d.String(), name) {
+			return d, nil
+		}
+	}
+	return 0, errors.Errorf("unknown staking directive %v", name)
+}
+
+// txHistoryResponse returns the transactions of a page of the transactions history,
+// with the cursor of the next page if there is one.
+func txHistoryResponse(key string, txs interface{}, cursor string) StructuredResponse {
+	response := StructuredResponse{key: txs}
+	if cursor != "" {
+		response["nextCursor"] = cursor
+	}
+	return response
 }
 
-// EstimateGas - estimate gas cost for a given operation
-func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, gasCap *big.Int) (uint64, error) {
+// EstimateGas - estimate gas cost for a given operation, optionally on top of
+// a state with the given overrides applied
+func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, gasCap *big.Int) (uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo  uint64 = params.TxGas - 1
@@ -866,6 +906,9 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOr
 		if err != nil {
 			return 0, err
 		}
+		if err := overrides.Apply(state); err != nil {
+			return 0, err
+		}
 		balance := state.GetBalance(*args.From) // from can't be nil
 		available := new(big.Int).Set(balance)
 		if args.Value != nil {
@@ -898,7 +941,7 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOr
 	executable := func(gas uint64) (bool, *core.ExecutionResult, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
 
-		result, err := DoEVMCall(ctx, hmy, args, blockNrOrHash, 0)
+		result, err := DoEVMCall(ctx, hmy, args, blockNrOrHash, overrides, 0)
 		if err != nil {
 			if errors.Is(err, core.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
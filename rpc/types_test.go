@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -94,3 +96,44 @@ func TestDelegation_IntoStructuredResponse(t *testing.T) {
 
 	require.JSONEq(t, string(js1), string(js2))
 }
+
+func TestStateOverride_Apply(t *testing.T) {
+	var overrides StateOverride
+	err := json.Unmarshal([]byte(`{
+		"0x0000000000000000000000000000000000000001": {
+			"nonce": "0x5",
+			"code": "0x6001",
+			"balance": "0x64",
+			"stateDiff": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"
+			}
+		},
+		"0x0000000000000000000000000000000000000002": {
+			"state": {
+				"0x0000000000000000000000000000000000000000000000000000000000000003": "0x0000000000000000000000000000000000000000000000000000000000000004"
+			}
+		}
+	}`), &overrides)
+	require.NoError(t, err)
+
+	db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	require.NoError(t, err)
+	addr1, addr2 := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	db.SetState(addr1, common.HexToHash("0x05"), common.HexToHash("0x06"))
+	db.SetState(addr2, common.HexToHash("0x05"), common.HexToHash("0x06"))
+
+	require.NoError(t, overrides.Apply(db))
+	require.Equal(t, uint64(5), db.GetNonce(addr1))
+	require.Equal(t, []byte{0x60, 0x01}, db.GetCode(addr1))
+	require.Equal(t, big.NewInt(100), db.GetBalance(addr1))
+	// stateDiff keeps the other slots, state replaces the whole storage
+	require.Equal(t, common.HexToHash("0x02"), db.GetState(addr1, common.HexToHash("0x01")))
+	require.Equal(t, common.HexToHash("0x06"), db.GetState(addr1, common.HexToHash("0x05")))
+	require.Equal(t, common.HexToHash("0x04"), db.GetState(addr2, common.HexToHash("0x03")))
+	require.Equal(t, common.Hash{}, db.GetState(addr2, common.HexToHash("0x05")))
+
+	// state and stateDiff are mutually exclusive
+	slots := map[common.Hash]common.Hash{}
+	conflict := StateOverride{addr1: OverrideAccount{State: &slots, StateDiff: &slots}}
+	require.Error(t, conflict.Apply(db))
+}